@@ -0,0 +1,47 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Handler serves the blobs of the store over HTTP, the request path below prefix being the key.
+// Blob keys are never reused for different content, so responses may be cached indefinitely.
+func Handler(store Store, prefix string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, prefix)
+		if !validKey(key) {
+			http.NotFound(w, r)
+			return
+		}
+
+		blob, contentType, err := store.Get(r.Context(), key)
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			log.Error().Err(err).Str("key", key).Msg("Failed to read blob")
+			http.Error(w, "Failed to read blob", http.StatusInternalServerError)
+			return
+		}
+		defer blob.Close()
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		if r.Method == http.MethodHead {
+			return
+		}
+		if _, err := io.Copy(w, blob); err != nil {
+			log.Error().Err(err).Str("key", key).Msg("Failed to send blob")
+		}
+	})
+}
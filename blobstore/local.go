@@ -0,0 +1,125 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores blobs as files below a root directory.
+// The content type is not stored; it is derived from the key's extension when the blob is read.
+type Local struct {
+	Root    string
+	BaseURL string
+}
+
+// NewLocal creates a Local store rooted at dir, creating the directory if needed.
+// Blob URLs are the keys appended to baseURL.
+func NewLocal(dir, baseURL string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return &Local{Root: dir, BaseURL: baseURL}, nil
+}
+
+// Put writes the blob to a temporary file first and renames it into place, so readers never see a partial file.
+func (store *Local) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	target, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), target)
+}
+
+// Get opens the file stored under the key.
+func (store *Local) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	target, err := store.path(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", ErrNotFound
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return file, contentType, nil
+}
+
+// Delete removes the file stored under the key.
+func (store *Local) Delete(ctx context.Context, key string) error {
+	target, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Prefix returns the URL path blobs are served under, the path of the base URL, e.g. "/media/" for "https://example.com/media/".
+func (store *Local) Prefix() string {
+	prefix := store.BaseURL
+	if u, err := url.Parse(store.BaseURL); err == nil {
+		prefix = u.Path
+	}
+	if !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// URL returns the key appended to the store's base URL.
+func (store *Local) URL(key string) string {
+	return store.BaseURL + key
+}
+
+func (store *Local) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(store.Root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,27 @@
+package blobstore
+
+import "testing"
+
+func TestLocalPrefix(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"/media/", "/media/"},
+		{"/media", "/media/"},
+		{"/static/images/", "/static/images/"},
+		{"https://example.com/media/", "/media/"},
+		{"https://example.com/files", "/files/"},
+		{"https://example.com", "/"},
+	}
+
+	for _, tt := range tests {
+		store, err := NewLocal(t.TempDir(), tt.baseURL)
+		if err != nil {
+			t.Fatalf("NewLocal returned error: %v", err)
+		}
+		if got := store.Prefix(); got != tt.want {
+			t.Errorf("Prefix() for %q = %q, want %q", tt.baseURL, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNotFound is returned by Get when no blob is stored under the key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps binary objects such as uploaded images under slash separated keys, e.g. "movies/12/poster-3f9a.jpg".
+// The local filesystem is the only backend for now; an S3-compatible one only has to implement the same interface.
+type Store interface {
+	// Put stores the content read from r under the key, replacing any previous blob.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// Get opens the blob stored under the key and returns its content type. The caller closes the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	// Delete removes the blob stored under the key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the address clients fetch the blob from.
+	URL(key string) string
+}
+
+// FromEnv builds the Store selected by the BLOB_STORE environment variable.
+// "local", the default, keeps blobs under BLOB_DIR (default "media") and serves them under BLOB_BASE_URL (default "/media/").
+// Returns an error for unknown backends.
+func FromEnv() (Store, error) {
+	switch backend := strings.ToLower(os.Getenv("BLOB_STORE")); backend {
+	case "", "local":
+		dir := os.Getenv("BLOB_DIR")
+		if dir == "" {
+			dir = "media"
+		}
+		baseURL := os.Getenv("BLOB_BASE_URL")
+		if baseURL == "" {
+			baseURL = "/media/"
+		}
+		return NewLocal(dir, baseURL)
+	default:
+		return nil, fmt.Errorf("unsupported blob store %q", backend)
+	}
+}
+
+// validKey reports whether the key is a relative slash separated path without "." or ".." elements,
+// so that no backend can be made to read or write outside of its root.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, element := range strings.Split(key, "/") {
+		if element == "" || element == "." || element == ".." {
+			return false
+		}
+	}
+	return true
+}
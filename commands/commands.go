@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"vk.com/m/services"
+)
+
+// command is a maintenance task run from the command line instead of serving HTTP.
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+// registry maps command names, the first positional argument of the binary, to their implementation.
+var registry = map[string]command{
+	"export":     {usage: "export the actor-movie network as GraphML, GEXF or DOT", run: export},
+	"purge":      {usage: "permanently remove the movies and actors trashed longer than the retention period", run: purge},
+	"quality":    {usage: "run the data-quality checks and write the report, failing when issues are found", run: quality},
+	"similarity": {usage: "recompute the similar movie recommendations of the whole catalogue", run: similarity},
+}
+
+// Run executes the command named by args[0], passing it the remaining arguments.
+// Returns an error if the command is unknown or fails.
+func Run(args []string) error {
+	cmd, ok := registry[args[0]]
+	if !ok {
+		printUsage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	return cmd.run(args[1:])
+}
+
+// connect opens the database connection used by a command.
+// The caller is responsible for closing it.
+func connect() (*services.Postgresql, error) {
+	return services.NewPostgreSQL(context.Background())
+}
+
+// printUsage lists the available commands on stderr.
+func printUsage() {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, registry[name].usage)
+	}
+}
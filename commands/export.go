@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"vk.com/m/graphexport"
+	"vk.com/m/services"
+)
+
+// export writes the actor-movie network to a file.
+// The -query flag accepts the MovieFind search parameters in URL query form, e.g. -query "title=war&actorIds=1,2".
+// The output goes to a file rather than stdout because the database layer logs its queries to stdout.
+func export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "graphml", "output format [graphml|gexf|dot]")
+	projection := flags.String("projection", "bipartite", "graph to export [bipartite|actors]")
+	query := flags.String("query", "", "MovieFind search parameters restricting the export, e.g. \"title=war&match=any\"")
+	out := flags.String("out", "", "output file (default: catalogue-<projection>.<format>)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	spec, ok := graphexport.Formats[*format]
+	if !ok {
+		return fmt.Errorf("unsupported graph format %q", *format)
+	}
+	if !services.GraphProjections[*projection] {
+		return fmt.Errorf("unsupported projection %q", *projection)
+	}
+
+	params, err := url.ParseQuery(*query)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	path := *out
+	if path == "" {
+		path = fmt.Sprintf("catalogue-%s.%s", *projection, spec.Extension)
+	}
+
+	PG, err := connect()
+	if err != nil {
+		return err
+	}
+	defer PG.Close()
+
+	// The graph is written to a temporary file renamed over path once complete, so a failed export leaves no
+	// truncated output behind and does not destroy an earlier export.
+	file, err := os.CreateTemp(filepath.Dir(path), ".export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if err := file.Chmod(0o644); err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriter(file)
+	if err := PG.WriteGraph(buffered, *format, *projection, params); err != nil {
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return err
+	}
+
+	log.Info().Str("path", path).Str("format", *format).Str("projection", *projection).Msg("Graph exported successfully")
+	return nil
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// purge permanently removes the movies and actors that have been in the trash for longer than the retention period.
+// The period defaults to TRASH_RETENTION_DAYS, the one the API reports to administrators, and can be overridden with -days.
+// It is meant to run periodically, e.g. once a day.
+func purge(args []string) error {
+	flags := flag.NewFlagSet("purge", flag.ContinueOnError)
+	days := flags.Int("days", -1, "retention period in days (default: TRASH_RETENTION_DAYS or 30)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	PG, err := connect()
+	if err != nil {
+		return err
+	}
+	defer PG.Close()
+
+	retention := PG.TrashRetention
+	if *days >= 0 {
+		retention = time.Duration(*days) * 24 * time.Hour
+	}
+
+	movies, actors, err := PG.PurgeTrash(retention)
+	if err != nil {
+		return fmt.Errorf("purged %d movies and %d actors before failing: %w", movies, actors, err)
+	}
+
+	log.Info().Int("movies", movies).Int("actors", actors).Dur("retention", retention).Msg("Trash purged successfully")
+	return nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"vk.com/m/services"
+)
+
+// quality runs the data-quality checks and writes the report to a JSON file.
+// The command fails when a check of the -fail-on severity or a more serious one finds issues, so a nightly job can alert on it.
+// The output goes to a file rather than stdout because the database layer logs its queries to stdout.
+func quality(args []string) error {
+	flags := flag.NewFlagSet("quality", flag.ContinueOnError)
+	checks := flags.String("checks", "", "comma-separated checks to run (default: all): "+strings.Join(services.QualityCheckNames(), ", "))
+	failOn := flags.String("fail-on", services.SeverityError, "fail when a check of this severity or above finds issues [error|warning|info|none]")
+	out := flags.String("out", "", "output file (default: quality-report-<date>.json)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	switch *failOn {
+	case services.SeverityError, services.SeverityWarning, services.SeverityInfo, "none":
+	default:
+		return fmt.Errorf("unsupported severity %q", *failOn)
+	}
+
+	var names []string
+	if *checks != "" {
+		names = strings.Split(*checks, ",")
+	}
+
+	path := *out
+	if path == "" {
+		path = fmt.Sprintf("quality-report-%s.json", time.Now().Format("2006-01-02"))
+	}
+
+	PG, err := connect()
+	if err != nil {
+		return err
+	}
+	defer PG.Close()
+
+	report, err := PG.RunQualityChecks(names)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(encoded, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, result := range report.Results {
+		if result.Count > 0 {
+			log.Warn().Str("check", result.Check).Str("severity", result.Severity).Int("count", result.Count).Msg("Data-quality issues found")
+			if services.SeverityAtLeast(result.Severity, *failOn) {
+				failed = append(failed, result.Check)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("data-quality checks failed: %s (report written to %s)", strings.Join(failed, ", "), path)
+	}
+
+	log.Info().Str("path", path).Int("issues", report.Issues).Msg("Quality report written successfully")
+	return nil
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"flag"
+
+	"github.com/rs/zerolog/log"
+)
+
+// similarity recomputes the recommendations of every movie.
+// Edits queue the movies whose recommendations they affect, which the API refreshes in the background; this job is meant to
+// run periodically to pick up everything else, such as new movies released in the same era as existing ones.
+// With -queued it only works through the queue, for deployments that run it instead of the API's background worker.
+func similarity(args []string) error {
+	flags := flag.NewFlagSet("similarity", flag.ContinueOnError)
+	queued := flags.Bool("queued", false, "only refresh the movies queued by edits")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	PG, err := connect()
+	if err != nil {
+		return err
+	}
+	defer PG.Close()
+
+	if *queued {
+		refreshed, err := PG.ProcessSimilarityQueue()
+		if err != nil {
+			return err
+		}
+		log.Info().Int("movies", refreshed).Msg("Queued movie similarities refreshed successfully")
+		return nil
+	}
+
+	if err := PG.RefreshAllSimilarities(); err != nil {
+		return err
+	}
+
+	log.Info().Msg("Movie similarities recomputed successfully")
+	return nil
+}
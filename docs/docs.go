@@ -22,7 +22,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Adds a new actor with the given details. Requires 'admin' role.",
+                "description": "Adds a new actor with the given details. Gender is one of female, male, non-binary or other, and Aliases lists the stage, birth or alternate names the actor is also found by. ExternalIDs record the actor's identifiers in IMDb, Kinopoisk or Wikidata; adding an actor under an identifier that is already recorded is refused, so ingestion jobs can look people up by it instead of duplicating them. Requires 'admin' role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -59,7 +59,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body"
+                        "description": "Invalid request body, gender, date of birth or death, alias or external ID"
                     },
                     "401": {
                         "description": "Unauthorized or Invalid token"
@@ -67,27 +67,30 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden - Role not allowed"
                     },
+                    "409": {
+                        "description": "One of the external IDs is already recorded"
+                    },
                     "500": {
                         "description": "Error creating actor"
                     }
                 }
             }
         },
-        "/v1/actor-delete/{id}": {
-            "delete": {
+        "/v1/actor-collaborators/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes the actor with the specified ID, including removing all associated movies. Requires 'admin' role.",
+                "description": "Retrieves the actors who appeared in movies together with the actor with the specified ID, ranked by the number of shared movies. Available to both 'admin' and 'user' roles.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "actor"
                 ],
-                "summary": "Deletes an actor",
+                "summary": "Lists an actor's frequent collaborators",
                 "parameters": [
                     {
                         "type": "string",
@@ -102,14 +105,26 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of collaborators to return, at most 100 (default: 10)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted the actor"
+                        "description": "Successfully retrieved the collaborators",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Collaborator"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Invalid actor ID or URL format"
+                        "description": "Invalid actor ID or limit"
                     },
                     "401": {
                         "description": "Unauthorized or Invalid token"
@@ -118,29 +133,26 @@ const docTemplate = `{
                         "description": "Forbidden - Role not allowed"
                     },
                     "500": {
-                        "description": "Actor not found or could not be deleted"
+                        "description": "Error retrieving collaborators"
                     }
                 }
             }
         },
-        "/v1/actor-edit/{id}": {
-            "put": {
+        "/v1/actor-delete/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Edits an actor with the specified ID based on the given update fields. Requires 'admin' role.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Moves the actor with the specified ID to the trash. The actor is hidden from lists and searches but keeps their movies, crew credits and other links until restored or purged, after which everything is removed for good. Requires 'admin' role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "actor"
                 ],
-                "summary": "Edits an existing actor",
+                "summary": "Moves an actor to the trash",
                 "parameters": [
                     {
                         "type": "string",
@@ -155,27 +167,14 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Fields to update",
-                        "name": "updates",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated the actor",
-                        "schema": {
-                            "$ref": "#/definitions/models.Actor"
-                        }
+                        "description": "Successfully moved the actor to the trash"
                     },
                     "400": {
-                        "description": "Invalid request body or actor ID"
+                        "description": "Invalid actor ID or URL format"
                     },
                     "401": {
                         "description": "Unauthorized or Invalid token"
@@ -187,26 +186,26 @@ const docTemplate = `{
                         "description": "Actor not found"
                     },
                     "500": {
-                        "description": "Failed to save actor"
+                        "description": "Actor could not be deleted"
                     }
                 }
             }
         },
-        "/v1/actor-list": {
+        "/v1/actor-duplicates": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all actors, including their associated movies. Available to both 'admin' and 'user' roles.",
+                "description": "Suggests pairs of actors that are probably the same person, best matches first. Names and aliases are compared after normalizing case, punctuation, the letter ё and word order, so \"Mikhalkov, Nikita\" matches \"Nikita Mikhalkov\"; matching dates of birth raise the score and different ones lower it. Requires 'admin' role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "actor"
                 ],
-                "summary": "Lists all actors",
+                "summary": "Finds likely duplicate actors",
                 "parameters": [
                     {
                         "type": "string",
@@ -214,85 +213,138 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only look for duplicates of the actor with this ID",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum score in percent (default: 85)",
+                        "name": "minScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of pairs to return (default: 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved all actors",
+                        "description": "Successfully retrieved the candidates",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Actor"
+                                "$ref": "#/definitions/models.DuplicateCandidate"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid actor ID, score or limit"
+                    },
                     "401": {
                         "description": "Unauthorized or Invalid token"
                     },
                     "403": {
                         "description": "Forbidden - Role not allowed"
                     },
+                    "404": {
+                        "description": "Actor not found"
+                    },
                     "500": {
                         "description": "Error retrieving actors"
                     }
                 }
             }
         },
-        "/v1/login": {
-            "post": {
-                "description": "handles login requests by checking username and password",
+        "/v1/actor-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits an actor with the specified ID based on the given update fields: name, gender (female, male, non-binary or other), dateOfBirth and dateOfDeath (null to clear), placeOfBirth, biography and aliases, which replaces the actor's aliases with the given names or {name, kind} objects. Movies may be given as IDs or as objects {id, character, billingOrder, creditType} to set the actor's credit in them; creditType is one of lead, supporting, cameo or voice. Requires 'admin' role.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "User login",
+                "tags": [
+                    "actor"
+                ],
+                "summary": "Edits an existing actor",
                 "parameters": [
                     {
-                        "description": "Login Credentials",
-                        "name": "LoginRequest",
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/routes.LoginRequest"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns login token",
+                        "description": "Successfully updated the actor",
                         "schema": {
-                            "$ref": "#/definitions/routes.LoginResponse"
+                            "$ref": "#/definitions/models.Actor"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or Unauthorized"
+                        "description": "Invalid request body, actor ID, gender, date of death, alias, credit or unknown movie IDs"
                     },
                     "401": {
-                        "description": "Invalid request or Unauthorized"
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Actor not found"
+                    },
+                    "500": {
+                        "description": "Failed to save actor"
                     }
                 }
             }
         },
-        "/v1/movie-add": {
-            "post": {
+        "/v1/actor-external-id-delete/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Adds a new movie with the given details including title, description, release date, and rating. Requires 'admin' role.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Removes the identifier of the actor with the specified ID in the given external database. Requires 'admin' role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movie"
+                    "external"
                 ],
-                "summary": "Adds a new movie",
+                "summary": "Removes an external ID of an actor",
                 "parameters": [
                     {
                         "type": "string",
@@ -302,24 +354,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Movie to add",
-                        "name": "movie",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Movie"
-                        }
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "External database [imdb|kinopoisk|wikidata]",
+                        "name": "source",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully added the movie",
-                        "schema": {
-                            "$ref": "#/definitions/models.Movie"
-                        }
+                        "description": "Successfully removed the identifier"
                     },
                     "400": {
-                        "description": "Invalid request body"
+                        "description": "Invalid actor ID or source"
                     },
                     "401": {
                         "description": "Unauthorized or Invalid token"
@@ -327,27 +381,33 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden - Role not allowed"
                     },
+                    "404": {
+                        "description": "The actor has no identifier from this source"
+                    },
                     "500": {
-                        "description": "Error creating movie"
+                        "description": "Identifier could not be removed"
                     }
                 }
             }
         },
-        "/v1/movie-delete/{id}": {
-            "delete": {
+        "/v1/actor-external-id/{id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes the movie with the specified ID, including removing all associations with actors. Requires 'admin' role.",
+                "description": "Records the identifier of the actor with the specified ID in an external database, replacing their previous identifier from the same source. Sources are imdb (nm0000209), kinopoisk (7987) and wikidata (Q48337). Setting an identifier the actor already has is a no-op. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movie"
+                    "external"
                 ],
-                "summary": "Deletes a movie",
+                "summary": "Records an external ID of an actor",
                 "parameters": [
                     {
                         "type": "string",
@@ -358,18 +418,30 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Actor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Source and identifier",
+                        "name": "externalId",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalID"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted the movie"
+                        "description": "Successfully recorded the identifier",
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalID"
+                        }
                     },
                     "400": {
-                        "description": "Invalid movie ID or URL format"
+                        "description": "Invalid request body, actor ID, source or identifier format"
                     },
                     "401": {
                         "description": "Unauthorized or Invalid token"
@@ -377,30 +449,33 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden - Role not allowed"
                     },
+                    "404": {
+                        "description": "Actor not found"
+                    },
+                    "409": {
+                        "description": "The identifier is already recorded for another movie or person"
+                    },
                     "500": {
-                        "description": "Movie not found or could not be deleted"
+                        "description": "Error saving identifier"
                     }
                 }
             }
         },
-        "/v1/movie-edit/{id}": {
-            "put": {
+        "/v1/actor-follow/{id}": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Edits a movie with the specified ID based on the given update fields such as title, description, release date, rating, and associated actors. Requires 'admin' role.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Makes the authenticated user follow the actor with the specified ID. The user is then notified whenever the actor is added to a movie. Following an actor twice has no effect. Available to both 'admin' and 'user' roles.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movie"
+                    "follow"
                 ],
-                "summary": "Edits an existing movie",
+                "summary": "Follows an actor",
                 "parameters": [
                     {
                         "type": "string",
@@ -411,62 +486,49 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Actor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Fields to update",
-                        "name": "updates",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated the movie",
+                        "description": "Successfully followed the actor",
                         "schema": {
-                            "$ref": "#/definitions/models.Movie"
+                            "$ref": "#/definitions/models.ActorFollow"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or movie ID"
+                        "description": "Invalid actor ID"
                     },
                     "401": {
                         "description": "Unauthorized or Invalid token"
                     },
-                    "403": {
-                        "description": "Forbidden - Role not allowed"
-                    },
                     "404": {
-                        "description": "Movie not found"
+                        "description": "Actor not found"
                     },
                     "500": {
-                        "description": "Error saving movie"
+                        "description": "Error following actor"
                     }
                 }
             }
         },
-        "/v1/movie-find": {
+        "/v1/actor-followed": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Searches for movies by a fragment of the title or by a fragment of an actor's name. Available to both 'admin' and 'user' roles.",
+                "description": "Retrieves the actors the authenticated user follows, most recently followed first. Available to both 'admin' and 'user' roles.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movie"
+                    "follow"
                 ],
-                "summary": "Searches for movies by title or actor name",
+                "summary": "Lists the followed actors",
                 "parameters": [
                     {
                         "type": "string",
@@ -474,30 +536,71 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the followed actors",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ActorFollow"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
                     },
+                    "500": {
+                        "description": "Error retrieving followed actors"
+                    }
+                }
+            }
+        },
+        "/v1/actor-history/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves every change made to the actor with the specified ID, newest first: who made it, when, and the fields it changed with their values before and after. Filmography changes list all the actor's movies with the credits before and after. The history is kept when the actor is trashed or purged. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revision"
+                ],
+                "summary": "Lists the revisions of an actor",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Fragment of the movie title",
-                        "name": "title",
-                        "in": "query"
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Fragment of the actor's name",
-                        "name": "actor",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully found movies",
+                        "description": "Successfully retrieved the history",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Movie"
+                                "$ref": "#/definitions/models.Revision"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid actor ID or URL format"
+                    },
                     "401": {
                         "description": "Unauthorized or Invalid token"
                     },
@@ -505,26 +608,26 @@ const docTemplate = `{
                         "description": "Forbidden - Role not allowed"
                     },
                     "500": {
-                        "description": "Error retrieving movie list"
+                        "description": "Error retrieving the history"
                     }
                 }
             }
         },
-        "/v1/movie-list": {
+        "/v1/actor-list": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all movies, including their titles, descriptions, release dates, ratings, and associated actors with sorting. Available to both 'admin' and 'user' roles.",
+                "description": "Retrieves a list of all actors, including their associated movies in release order with the actor's credit in each, their crew credits, the series episodes they guest starred in, their award nominations, aliases and external IDs. Available to both 'admin' and 'user' roles.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movie"
+                    "actor"
                 ],
-                "summary": "Lists all movies",
+                "summary": "Lists all actors",
                 "parameters": [
                     {
                         "type": "string",
@@ -535,18 +638,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Sort by [title|rating|releasedate], prepend '-' for descending order (default: '-rating')",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved all movies",
+                        "description": "Successfully retrieved all actors",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Movie"
+                                "$ref": "#/definitions/models.Actor"
                             }
                         }
                     },
@@ -557,62 +660,7404 @@ const docTemplate = `{
                         "description": "Forbidden - Role not allowed"
                     },
                     "500": {
-                        "description": "Error retrieving movie list"
+                        "description": "Error retrieving actors"
                     }
                 }
             }
-        }
+        },
+        "/v1/actor-lookup": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the actor recorded under the given identifier of an external database, e.g. their IMDb ID, with their filmography, crew credits, guest appearances, award nominations, aliases and external IDs. The response is localized for the Accept-Language header. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "external"
+                ],
+                "summary": "Finds an actor by external ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "External database [imdb|kinopoisk|wikidata]",
+                        "name": "source",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identifier in the external database, e.g. 'nm0000209'",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully found the actor",
+                        "schema": {
+                            "$ref": "#/definitions/models.Actor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid source or identifier format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "No actor with this identifier"
+                    },
+                    "500": {
+                        "description": "Error retrieving actor"
+                    }
+                }
+            }
+        },
+        "/v1/actor-merge/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves the movies, episodes, crew credits, followers, translations, aliases, nominations and external IDs of the duplicate actor over to the actor with the specified ID in a single transaction, then removes the duplicate. Details the survivor lacks, such as the date of birth, biography or photo, are taken from the duplicate and its name is kept as an alias. Where both had the same link, such as a credit in the same movie, the survivor's is kept. The duplicate's ID keeps resolving to the survivor, and the merge is recorded in the history of both. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "actor"
+                ],
+                "summary": "Merges a duplicate actor into another",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the actor to keep",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the duplicate actor to merge into it",
+                        "name": "duplicate",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully merged the actors",
+                        "schema": {
+                            "$ref": "#/definitions/models.Actor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor ID, missing duplicate or an actor merged into itself"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Actor not found"
+                    },
+                    "500": {
+                        "description": "Error merging the actors"
+                    }
+                }
+            }
+        },
+        "/v1/actor-path": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Finds the shortest chain of shared movies connecting two actors, searching from both ends at once. The path alternates actor and movie nodes. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "actor"
+                ],
+                "summary": "Finds the shortest connection between two actors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the first actor",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the second actor",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of movies on the path, at most 10 (default: 6)",
+                        "name": "maxDepth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully found a path",
+                        "schema": {
+                            "$ref": "#/definitions/models.ActorPath"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor IDs or depth"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "No path within the depth limit"
+                    },
+                    "500": {
+                        "description": "Error searching for a path"
+                    }
+                }
+            }
+        },
+        "/v1/actor-photo-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes the photo and thumbnails of the actor with the specified ID. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Removes an actor photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully removed the photo",
+                        "schema": {
+                            "$ref": "#/definitions/models.Actor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Actor not found"
+                    },
+                    "500": {
+                        "description": "Error removing the photo"
+                    }
+                }
+            }
+        },
+        "/v1/actor-photo/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Uploads the photo of the actor with the specified ID as the \"image\" field of a multipart form, replacing any previous one. JPEG, PNG and GIF images up to 10 MB are accepted; the format is detected from the content. Small, medium and large JPEG thumbnails are generated. Requires 'admin' role.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Uploads an actor photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Actor photo",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully uploaded the photo",
+                        "schema": {
+                            "$ref": "#/definitions/models.Actor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor ID, form or image"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Actor not found"
+                    },
+                    "413": {
+                        "description": "Image larger than 10 MB"
+                    },
+                    "415": {
+                        "description": "Unsupported image format"
+                    },
+                    "500": {
+                        "description": "Error storing the photo"
+                    }
+                }
+            }
+        },
+        "/v1/actor-propose/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submits a change to the actor with the specified ID for an admin to approve. The body takes the same update fields as ActorEdit; nothing is changed until the proposal is approved. Available to both 'admin' and 'user' roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proposal"
+                ],
+                "summary": "Proposes a change to an actor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update, as for ActorEdit",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully submitted the proposal",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditProposal"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor ID, request body, unknown update fields or invalid values"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "404": {
+                        "description": "Actor not found"
+                    },
+                    "500": {
+                        "description": "Error saving the proposal"
+                    }
+                }
+            }
+        },
+        "/v1/actor-restore/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Takes the actor with the specified ID out of the trash. Their movies, crew credits, guest appearances, followers and every other link come back with them, since they are kept while the actor is trashed. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restores an actor from the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully restored the actor",
+                        "schema": {
+                            "$ref": "#/definitions/models.Actor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Actor not in the trash"
+                    },
+                    "500": {
+                        "description": "Error restoring actor"
+                    }
+                }
+            }
+        },
+        "/v1/actor-revert/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Puts the name, gender, dates, place of birth, biography, aliases and movies with their credits of the actor back to how they were after the given revision, and records the revert as a new revision. Revisions of deletions and restores carry no state and cannot be reverted to; trashed actors must be restored first. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revision"
+                ],
+                "summary": "Reverts an actor to a previous revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the revision to revert to",
+                        "name": "revision",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully reverted the actor",
+                        "schema": {
+                            "$ref": "#/definitions/models.Actor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor ID, missing revision or a revision that cannot be reverted to"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Actor or revision not found"
+                    },
+                    "500": {
+                        "description": "Error reverting the actor"
+                    }
+                }
+            }
+        },
+        "/v1/actor-translation-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the name of the actor with the specified ID in the given language. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Deletes an actor name transliteration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the translation",
+                        "name": "language",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the translation"
+                    },
+                    "400": {
+                        "description": "Invalid actor ID or language"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Translation could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/actor-translation/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates or replaces the name of the actor with the specified ID as written in the given language. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Sets an actor name transliteration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Language and name",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ActorTranslation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully saved the translation",
+                        "schema": {
+                            "$ref": "#/definitions/models.ActorTranslation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, actor ID, language or empty name"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Actor not found"
+                    },
+                    "500": {
+                        "description": "Error saving translation"
+                    }
+                }
+            }
+        },
+        "/v1/actor-translations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves every translation of the name of the actor with the specified ID, ordered by language. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Lists the name transliterations of an actor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the translations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ActorTranslation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving translations"
+                    }
+                }
+            }
+        },
+        "/v1/actor-unfollow/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Makes the authenticated user stop following the actor with the specified ID. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "follow"
+                ],
+                "summary": "Unfollows an actor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully unfollowed the actor"
+                    },
+                    "400": {
+                        "description": "Invalid actor ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "500": {
+                        "description": "Error unfollowing actor"
+                    }
+                }
+            }
+        },
+        "/v1/audit-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the audit trail of authenticated requests, logins and failed logins, newest first. Each entry records the user, role, endpoint, outcome, client IP and request ID, and is chained to the previous entry by its hash. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Queries the audit trail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries at or after this time, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries before this time, RFC 3339, or on or before this day, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only entries of the user with this ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries of this action [request|login|login-failed]",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries with this outcome [success|denied|rejected|error]",
+                        "name": "outcome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries to return, at most 1000 (default: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the audit trail",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid time, user ID, limit or offset"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving the audit trail"
+                    }
+                }
+            }
+        },
+        "/v1/audit-verify": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Recomputes the hash chain of the whole audit trail and reports the first entry that was altered, or that no longer links to the entry before it. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Verifies the audit trail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The result of the check",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuditVerification"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error reading the audit trail"
+                    }
+                }
+            }
+        },
+        "/v1/award-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds an awarding body, such as a festival or an academy, with the given name. Award names are unique. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Adds a new award",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Award to add, with its Name",
+                        "name": "award",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Award"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the award",
+                        "schema": {
+                            "$ref": "#/definitions/models.Award"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or empty name"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "An award with this name already exists"
+                    },
+                    "500": {
+                        "description": "Error creating award"
+                    }
+                }
+            }
+        },
+        "/v1/award-category-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a category, such as \"Best Picture\", to the award with the given AwardID. Category names are unique within an award. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Adds a category to an award",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Category to add, with AwardID and Name",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the category",
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, empty name or unknown award"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "The award already has a category with this name"
+                    },
+                    "500": {
+                        "description": "Error creating category"
+                    }
+                }
+            }
+        },
+        "/v1/award-category-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the award category with the specified ID together with its nominations. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Deletes an award category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the category"
+                    },
+                    "400": {
+                        "description": "Invalid category ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Category could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/award-category-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the award category with the specified ID based on the given update fields. Category names are unique within an award. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Renames an award category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the category",
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or category ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Category not found"
+                    },
+                    "409": {
+                        "description": "The award already has a category with this name"
+                    },
+                    "500": {
+                        "description": "Failed to save category"
+                    }
+                }
+            }
+        },
+        "/v1/award-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the award with the specified ID together with its ceremonies, categories and nominations. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Deletes an award",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Award ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the award"
+                    },
+                    "400": {
+                        "description": "Invalid award ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Award could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/award-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the award with the specified ID based on the given update fields. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Renames an award",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Award ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the award",
+                        "schema": {
+                            "$ref": "#/definitions/models.Award"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or award ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Award not found"
+                    },
+                    "409": {
+                        "description": "An award with this name already exists"
+                    },
+                    "500": {
+                        "description": "Failed to save award"
+                    }
+                }
+            }
+        },
+        "/v1/award-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all awards ordered by name, each with its ceremonies, most recent first, and its categories. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Lists all awards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved all awards",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Award"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving awards"
+                    }
+                }
+            }
+        },
+        "/v1/ceremony-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds the edition of the award with the given AwardID held in Year, with an optional Name and Date (YYYY-MM-DD). An award holds at most one ceremony a year. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Adds a ceremony to an award",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Ceremony to add",
+                        "name": "ceremony",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardCeremony"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the ceremony",
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardCeremony"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, year, date or award"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "The award already has a ceremony that year"
+                    },
+                    "500": {
+                        "description": "Error creating ceremony"
+                    }
+                }
+            }
+        },
+        "/v1/ceremony-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the award ceremony with the specified ID together with its nominations. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Deletes a ceremony",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ceremony ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the ceremony"
+                    },
+                    "400": {
+                        "description": "Invalid ceremony ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Ceremony could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/ceremony-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the ceremony with the specified ID based on the given update fields: year, name and date (YYYY-MM-DD, empty to clear). An award holds at most one ceremony a year. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Edits an award ceremony",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ceremony ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the ceremony",
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardCeremony"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, ceremony ID, year or date"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Ceremony not found"
+                    },
+                    "409": {
+                        "description": "The award already has a ceremony that year"
+                    },
+                    "500": {
+                        "description": "Failed to save ceremony"
+                    }
+                }
+            }
+        },
+        "/v1/collection-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a franchise or collection of movies with the given name and description. Collection names are unique. Movies are added through CollectionEdit. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collection"
+                ],
+                "summary": "Adds a new collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Collection to add, with Name and Description",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the collection",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or empty name"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "A collection with this name already exists"
+                    },
+                    "500": {
+                        "description": "Error creating collection"
+                    }
+                }
+            }
+        },
+        "/v1/collection-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the collection with the specified ID. The movies themselves are kept. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collection"
+                ],
+                "summary": "Deletes a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the collection"
+                    },
+                    "400": {
+                        "description": "Invalid collection ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Collection not found"
+                    },
+                    "500": {
+                        "description": "Collection could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/collection-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the collection with the specified ID based on the given update fields: name, description, and movies, an array of movie IDs replacing the collection's contents in viewing order. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collection"
+                ],
+                "summary": "Edits a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the collection",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, collection ID or unknown movie IDs"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Collection not found"
+                    },
+                    "409": {
+                        "description": "A collection with this name already exists"
+                    },
+                    "500": {
+                        "description": "Failed to save collection"
+                    }
+                }
+            }
+        },
+        "/v1/collection-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all collections ordered by name, each with its movies in viewing order. Movies are listed without their cast and crew; use CollectionGet for the full details. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collection"
+                ],
+                "summary": "Lists all collections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved all collections",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Collection"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving collections"
+                    }
+                }
+            }
+        },
+        "/v1/collection/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the collection with the specified ID with the full details of its movies, in viewing order. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collection"
+                ],
+                "summary": "Shows a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the collection",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid collection ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Collection not found"
+                    },
+                    "500": {
+                        "description": "Error retrieving collection"
+                    }
+                }
+            }
+        },
+        "/v1/country-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the ISO 3166-1 countries movies can be linked to, ordered by code. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "studio"
+                ],
+                "summary": "Lists the countries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the countries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Country"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving countries"
+                    }
+                }
+            }
+        },
+        "/v1/crew-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Credits the person with the given actor ID on the movie for a job behind the camera. The job is one of director, screenwriter, composer, cinematographer or producer; a person can hold several jobs on the same movie. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "crew"
+                ],
+                "summary": "Credits a person on a movie's crew",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Crew credit to add, with ActorID, MovieID and Job",
+                        "name": "credit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CrewCredit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the crew credit",
+                        "schema": {
+                            "$ref": "#/definitions/models.CrewCredit"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, job, person or movie"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "The person already holds this job on the movie"
+                    },
+                    "500": {
+                        "description": "Error creating crew credit"
+                    }
+                }
+            }
+        },
+        "/v1/crew-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the crew credit with the specified ID. The person and the movie are kept. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "crew"
+                ],
+                "summary": "Removes a crew credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Crew credit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the crew credit"
+                    },
+                    "400": {
+                        "description": "Invalid crew credit ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Crew credit not found"
+                    },
+                    "500": {
+                        "description": "Crew credit could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/episode-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds an episode with the given number, title and air date to the season with the given SeasonID. Guest stars are linked by actor ID and must already exist. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Adds an episode to a season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Episode to add",
+                        "name": "episode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Episode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the episode",
+                        "schema": {
+                            "$ref": "#/definitions/models.Episode"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, episode number, empty title, air date, unknown season or unknown guest star IDs"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "The season already has an episode with this number"
+                    },
+                    "500": {
+                        "description": "Error creating episode"
+                    }
+                }
+            }
+        },
+        "/v1/episode-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the episode with the specified ID and its guest star links. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Deletes an episode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Episode ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the episode"
+                    },
+                    "400": {
+                        "description": "Invalid episode ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Episode not found or could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/episode-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the episode with the specified ID based on the given update fields such as title, number, airdate and guestStars (an array of actor IDs). Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Edits an existing episode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Episode ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the episode",
+                        "schema": {
+                            "$ref": "#/definitions/models.Episode"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, episode ID, air date or unknown guest star IDs"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Episode not found"
+                    },
+                    "409": {
+                        "description": "The season already has an episode with this number"
+                    },
+                    "500": {
+                        "description": "Failed to save episode"
+                    }
+                }
+            }
+        },
+        "/v1/genre-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a new genre with the given name. Genre names are unique. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genre"
+                ],
+                "summary": "Adds a new genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Genre to add",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the genre",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or empty name"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "A genre with this name already exists"
+                    },
+                    "500": {
+                        "description": "Error creating genre"
+                    }
+                }
+            }
+        },
+        "/v1/genre-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the genre with the specified ID and unlinks it from all movies. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genre"
+                ],
+                "summary": "Deletes a genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the genre"
+                    },
+                    "400": {
+                        "description": "Invalid genre ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Genre not found"
+                    },
+                    "500": {
+                        "description": "Genre could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/genre-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the genre with the specified ID based on the given update fields. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genre"
+                ],
+                "summary": "Renames a genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the genre",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or genre ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Genre not found"
+                    },
+                    "409": {
+                        "description": "A genre with this name already exists"
+                    },
+                    "500": {
+                        "description": "Failed to save genre"
+                    }
+                }
+            }
+        },
+        "/v1/genre-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all genres ordered by name. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genre"
+                ],
+                "summary": "Lists all genres",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved all genres",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Genre"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving genres"
+                    }
+                }
+            }
+        },
+        "/v1/graph-export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams the actor/movie bipartite graph, or the weighted actor co-appearance graph, as GraphML, GEXF or DOT. Accepts the MovieFind search parameters to export only part of the catalogue. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "text/xml",
+                    "text/vnd.graphviz"
+                ],
+                "tags": [
+                    "graph"
+                ],
+                "summary": "Exports the actor-movie network",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Output format [graphml|gexf|dot] (default: 'graphml')",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Graph to export [bipartite|actors] (default: 'bipartite')",
+                        "name": "projection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export movies whose title contains this fragment",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only export movies featuring actors whose names contain these fragments",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export movies featuring these comma separated actor IDs",
+                        "name": "actorIds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Co-star semantics [all|any] (default: 'all')",
+                        "name": "match",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The graph document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format, projection or filter"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error exporting the graph"
+                    }
+                }
+            }
+        },
+        "/v1/language-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the ISO 639-1 languages movies can be linked to, ordered by code. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "studio"
+                ],
+                "summary": "Lists the languages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the languages",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Language"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving languages"
+                    }
+                }
+            }
+        },
+        "/v1/login": {
+            "post": {
+                "description": "handles login requests by checking username and password against the user accounts; successful and failed logins are written to the audit trail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login Credentials",
+                        "name": "LoginRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/routes.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns login token",
+                        "schema": {
+                            "$ref": "#/definitions/routes.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or Unauthorized"
+                    },
+                    "401": {
+                        "description": "Invalid request or Unauthorized"
+                    }
+                }
+            }
+        },
+        "/v1/movie-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a new movie with the given details including title, description, release date, and rating. Each actor may carry a Credit with the character, billing order and credit type. Genres, tags and studios are linked by ID and must already exist. Countries of origin and spoken languages are given by their ISO 3166-1 and ISO 639-1 codes, and certifications as age ratings per country code. ExternalIDs record the movie's identifiers in IMDb, Kinopoisk or Wikidata; adding a movie under an identifier that is already recorded is refused, so ingestion jobs can look movies up by it instead of duplicating them. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Adds a new movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Movie to add",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the movie",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, runtime, country or language code, certification, external ID or unknown genre, tag or studio IDs"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "One of the external IDs is already recorded"
+                    },
+                    "500": {
+                        "description": "Error creating movie"
+                    }
+                }
+            }
+        },
+        "/v1/movie-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves the movie with the specified ID to the trash. The movie is hidden from lists, searches, watchlists and recommendations but keeps its cast, crew, reviews and other links until restored or purged, after which everything is removed for good. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Moves a movie to the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully moved the movie to the trash"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "500": {
+                        "description": "Movie could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/movie-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits a movie with the specified ID based on the given update fields such as title, description, release date (null to clear), rating, runtime, and associated actors, genres, tags and studios (arrays of IDs). Countries and languages are arrays of ISO 3166-1 and ISO 639-1 codes and certifications an object of age ratings keyed by country code, e.g. {\"RU\": \"16+\"}; each replaces the current values. Actors may also be given as objects {id, character, billingOrder, creditType} to set their credit; creditType is one of lead, supporting, cameo or voice. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Edits an existing movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the movie",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, movie ID, credit, runtime, country or language code, certification or unknown actor, genre, tag or studio IDs"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "500": {
+                        "description": "Error saving movie"
+                    }
+                }
+            }
+        },
+        "/v1/movie-external-id-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes the identifier of the movie with the specified ID in the given external database. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "external"
+                ],
+                "summary": "Removes an external ID of a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "External database [imdb|kinopoisk|wikidata]",
+                        "name": "source",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully removed the identifier"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or source"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "The movie has no identifier from this source"
+                    },
+                    "500": {
+                        "description": "Identifier could not be removed"
+                    }
+                }
+            }
+        },
+        "/v1/movie-external-id/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Records the identifier of the movie with the specified ID in an external database, replacing its previous identifier from the same source. Sources are imdb (tt0111161), kinopoisk (326) and wikidata (Q172241). Setting an identifier the movie already has is a no-op. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "external"
+                ],
+                "summary": "Records an external ID of a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source and identifier",
+                        "name": "externalId",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalID"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully recorded the identifier",
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalID"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, movie ID, source or identifier format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "409": {
+                        "description": "The identifier is already recorded for another movie or person"
+                    },
+                    "500": {
+                        "description": "Error saving identifier"
+                    }
+                }
+            }
+        },
+        "/v1/movie-find": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Searches for movies by a fragment of the title or by a fragment of an actor's name. Several actors can be given by ID or name fragment to find movies featuring all or any of them. Movies can also be searched by crew, e.g. the movies directed by someone. Titles and names are matched in every language they are translated into, and the results are localized for the Accept-Language header. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Searches for movies by title, actor or crew member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fragment of the movie title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Fragment of an actor's name; repeat the parameter to search for several co-stars",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated actor IDs to search for co-stars",
+                        "name": "actorIds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Co-star semantics [all|any]: movies featuring all or any of the actors (default: 'all')",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fragment of the name of the movie's director",
+                        "name": "director",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fragment of the name of a crew member",
+                        "name": "crew",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crew job the crew search is restricted to [director|screenwriter|composer|cinematographer|producer]",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated facets to aggregate [decade|rating|actor|genre|tag]; when set the response is an object with movies and facets",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Drill down to a release decade, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Drill down to a rating band, e.g. 8 for 8.0-8.9",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Drill down to movies featuring the actor with this ID",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only movies of this genre; repeat the parameter to require several genres",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only movies with this tag; repeat the parameter to require several tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only movies that won at least one award",
+                        "name": "awardWinner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fragment of the name of one of the movie's studios",
+                        "name": "studio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ISO 3166-1 code of a country of origin; repeat the parameter to require several countries",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ISO 639-1 code of a spoken language; repeat the parameter to require several languages",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Age rating in a country as COUNTRY:RATING, e.g. 'RU:16+'",
+                        "name": "certification",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum running time in minutes",
+                        "name": "runtimeMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum running time in minutes",
+                        "name": "runtimeMax",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully found movies (a models.MovieResults object when facets are requested)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid actor IDs, match, job, awardWinner flag, production filter or drill-down value"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving movie list"
+                    }
+                }
+            }
+        },
+        "/v1/movie-history/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves every change made to the movie with the specified ID, newest first: who made it, when, and the fields it changed with their values before and after. Cast changes list the full cast with the credits before and after. The history is kept when the movie is trashed or purged. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revision"
+                ],
+                "summary": "Lists the revisions of a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Revision"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving the history"
+                    }
+                }
+            }
+        },
+        "/v1/movie-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of all movies, including their titles, descriptions, release dates, ratings, and associated actors in billing order with their credits, with sorting. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Lists all movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by [title|rating|releasedate|communityrating], prepend '-' for descending order (default: '-rating')",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fragment of the name of the movie's director",
+                        "name": "director",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fragment of the name of a crew member",
+                        "name": "crew",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crew job the crew search is restricted to [director|screenwriter|composer|cinematographer|producer]",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated facets to aggregate [decade|rating|actor|genre|tag]; when set the response is an object with movies and facets",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Drill down to a release decade, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Drill down to a rating band, e.g. 8 for 8.0-8.9",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Drill down to movies featuring the actor with this ID",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only movies of this genre; repeat the parameter to require several genres",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only movies with this tag; repeat the parameter to require several tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only movies that won at least one award",
+                        "name": "awardWinner",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved all movies (a models.MovieResults object when facets are requested)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid drill-down value or awardWinner flag"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving movie list"
+                    }
+                }
+            }
+        },
+        "/v1/movie-lookup": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the movie recorded under the given identifier of an external database, e.g. its IMDb ID, with its cast, crew, genres, tags, production details and external IDs. The response is localized for the Accept-Language header. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "external"
+                ],
+                "summary": "Finds a movie by external ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "External database [imdb|kinopoisk|wikidata]",
+                        "name": "source",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identifier in the external database, e.g. 'tt0111161'",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully found the movie",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid source or identifier format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "No movie with this identifier"
+                    },
+                    "500": {
+                        "description": "Error retrieving movie"
+                    }
+                }
+            }
+        },
+        "/v1/movie-poster-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes the poster and thumbnails of the movie with the specified ID. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Removes a movie poster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully removed the poster",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "500": {
+                        "description": "Error removing the poster"
+                    }
+                }
+            }
+        },
+        "/v1/movie-poster/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Uploads the poster of the movie with the specified ID as the \"image\" field of a multipart form, replacing any previous one. JPEG, PNG and GIF images up to 10 MB are accepted; the format is detected from the content. Small, medium and large JPEG thumbnails are generated. Requires 'admin' role.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Uploads a movie poster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Poster image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully uploaded the poster",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID, form or image"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "413": {
+                        "description": "Image larger than 10 MB"
+                    },
+                    "415": {
+                        "description": "Unsupported image format"
+                    },
+                    "500": {
+                        "description": "Error storing the poster"
+                    }
+                }
+            }
+        },
+        "/v1/movie-propose/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submits a change to the movie with the specified ID for an admin to approve. The body takes the same update fields as MovieEdit; nothing is changed until the proposal is approved. Available to both 'admin' and 'user' roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proposal"
+                ],
+                "summary": "Proposes a change to a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update, as for MovieEdit",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully submitted the proposal",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditProposal"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID, request body, unknown update fields or invalid values"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "500": {
+                        "description": "Error saving the proposal"
+                    }
+                }
+            }
+        },
+        "/v1/movie-relation-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Records that RelatedMovieID is the Type of MovieID, e.g. its sequel, remake or director's cut. The relation is listed on both movies, with the inverse type on the related one. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Links two related movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Relation to add, with MovieID, RelatedMovieID and Type [sequel|prequel|remake|original|directors-cut|theatrical-cut|alternate-version|spin-off|spun-off-from]",
+                        "name": "relation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MovieRelation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the relation",
+                        "schema": {
+                            "$ref": "#/definitions/models.MovieRelation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, type or movie"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "The movies are already related this way"
+                    },
+                    "500": {
+                        "description": "Error creating relation"
+                    }
+                }
+            }
+        },
+        "/v1/movie-relation-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the movie relation with the specified ID, as listed in the relationId of a related title. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Unlinks two related movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Relation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the relation"
+                    },
+                    "400": {
+                        "description": "Invalid relation ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Relation not found"
+                    },
+                    "500": {
+                        "description": "Relation could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/movie-restore/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Takes the movie with the specified ID out of the trash. Its cast, crew, genres, reviews, watchlist entries and every other link come back with it, since they are kept while the movie is trashed. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restores a movie from the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully restored the movie",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie not in the trash"
+                    },
+                    "500": {
+                        "description": "Error restoring movie"
+                    }
+                }
+            }
+        },
+        "/v1/movie-revert/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Puts the title, description, release date, ratings, runtime, cast with their credits, genres, tags, studios, countries, languages and certifications of the movie back to how they were after the given revision, and records the revert as a new revision. Revisions of deletions and restores carry no state and cannot be reverted to; trashed movies must be restored first. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revision"
+                ],
+                "summary": "Reverts a movie to a previous revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the revision to revert to",
+                        "name": "revision",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully reverted the movie",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID, missing revision or a revision that cannot be reverted to"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie or revision not found"
+                    },
+                    "500": {
+                        "description": "Error reverting the movie"
+                    }
+                }
+            }
+        },
+        "/v1/movie-reviews/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the user reviews of the movie with the specified ID, most recent first, with their authors. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Lists the reviews of a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the reviews",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Review"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving reviews"
+                    }
+                }
+            }
+        },
+        "/v1/movie-similar/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the precomputed recommendations for the movie with the specified ID, best matches first. Scores combine shared cast, genre and tag overlap, release era proximity and rating. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Lists movies similar to a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of recommendations to return (default: 10, at most 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the similar movies",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MovieSimilarity"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or limit"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving similar movies"
+                    }
+                }
+            }
+        },
+        "/v1/movie-translation-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the translation of the movie with the specified ID into the given language. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Deletes a movie translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the translation",
+                        "name": "language",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the translation"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or language"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Translation could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/movie-translation/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates or replaces the title and description of the movie with the specified ID in the given language. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Sets a movie translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Language, title and optional description",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MovieTranslation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully saved the translation",
+                        "schema": {
+                            "$ref": "#/definitions/models.MovieTranslation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, movie ID, language or empty title"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "500": {
+                        "description": "Error saving translation"
+                    }
+                }
+            }
+        },
+        "/v1/movie-translations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves every translation of the movie with the specified ID, ordered by language. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Lists the translations of a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the translations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MovieTranslation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving translations"
+                    }
+                }
+            }
+        },
+        "/v1/movie/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the movie with the specified ID with its cast, crew, genres and tags, the titles related to it such as sequels, remakes or alternate cuts, the collections it belongs to and its award nominations and wins. The response is localized for the Accept-Language header. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Shows a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the movie",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "500": {
+                        "description": "Error retrieving movie"
+                    }
+                }
+            }
+        },
+        "/v1/nomination-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Records that the movie with the given MovieID, and optionally the person with the given ActorID, was nominated in CategoryID at CeremonyID. Set Won for a win. The category and the ceremony must belong to the same award. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Adds a nomination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Nomination to add",
+                        "name": "nomination",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Nomination"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the nomination",
+                        "schema": {
+                            "$ref": "#/definitions/models.Nomination"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, unknown ceremony, category, movie or person, or mismatched award"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "The nomination is already recorded"
+                    },
+                    "500": {
+                        "description": "Error creating nomination"
+                    }
+                }
+            }
+        },
+        "/v1/nomination-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the nomination with the specified ID. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Deletes a nomination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomination ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the nomination"
+                    },
+                    "400": {
+                        "description": "Invalid nomination ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Nomination could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/nomination-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the nomination with the specified ID based on the given update fields: won, and actorId, the nominated person, which can be set to null for a movie-only nomination. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Edits a nomination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomination ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the nomination",
+                        "schema": {
+                            "$ref": "#/definitions/models.Nomination"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, nomination ID, actorId or unknown person"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Nomination not found"
+                    },
+                    "409": {
+                        "description": "The nomination is already recorded"
+                    },
+                    "500": {
+                        "description": "Failed to save nomination"
+                    }
+                }
+            }
+        },
+        "/v1/notification-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the authenticated user's notification feed, newest first. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "follow"
+                ],
+                "summary": "Lists one's notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of notifications to return (default: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the notifications",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "500": {
+                        "description": "Error retrieving notifications"
+                    }
+                }
+            }
+        },
+        "/v1/notification-read-all": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Marks every unread notification of the authenticated user as read. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "follow"
+                ],
+                "summary": "Marks all notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully marked the notifications as read"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "500": {
+                        "description": "Error updating notifications"
+                    }
+                }
+            }
+        },
+        "/v1/notification-read/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Marks the authenticated user's notification with the specified ID as read. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "follow"
+                ],
+                "summary": "Marks a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully marked the notification as read",
+                        "schema": {
+                            "$ref": "#/definitions/models.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid notification ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "404": {
+                        "description": "Notification not found"
+                    },
+                    "500": {
+                        "description": "Error updating notification"
+                    }
+                }
+            }
+        },
+        "/v1/proposal-approve/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Applies the pending proposal with the specified ID as an edit of its movie or actor, recorded in their history under the approving admin, and marks it approved. A proposal for an actor that was merged into another applies to the actor they were merged into. A proposal that no longer applies, for instance because it names a deleted genre, fails like the edit would and stays pending. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proposal"
+                ],
+                "summary": "Approves an edit proposal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Proposal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully approved the proposal",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditProposal"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid proposal ID or a proposal that no longer applies"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Proposal, movie or actor not found"
+                    },
+                    "409": {
+                        "description": "Proposal already approved or rejected"
+                    },
+                    "500": {
+                        "description": "Error applying the proposal"
+                    }
+                }
+            }
+        },
+        "/v1/proposal-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the edit proposals with the given status, oldest first, with their submitters. Pending proposals carry the changes they would make, with the current and the proposed value of each field. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proposal"
+                ],
+                "summary": "Lists the moderation queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status of the proposals [pending|approved|rejected] (default: 'pending')",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only proposals for [movie|actor]",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the proposals",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EditProposal"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status or type"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving the proposals"
+                    }
+                }
+            }
+        },
+        "/v1/proposal-mine": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the edit proposals submitted by the authenticated user, newest first, with their status and the reason of rejected ones. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proposal"
+                ],
+                "summary": "Lists the user's own proposals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only proposals with this status [pending|approved|rejected]",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the proposals",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EditProposal"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "500": {
+                        "description": "Error retrieving the proposals"
+                    }
+                }
+            }
+        },
+        "/v1/proposal-reject/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rejects the pending proposal with the specified ID without applying it. The reason is shown to the submitter. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proposal"
+                ],
+                "summary": "Rejects an edit proposal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Proposal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Why the proposal is rejected",
+                        "name": "rejection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProposalRejection"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully rejected the proposal",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditProposal"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid proposal ID, request body or missing reason"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Proposal not found"
+                    },
+                    "409": {
+                        "description": "Proposal already approved or rejected"
+                    },
+                    "500": {
+                        "description": "Error saving the proposal"
+                    }
+                }
+            }
+        },
+        "/v1/quality-report": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Runs the data-quality checks over the catalogue, such as movies without actors, actors without credits, invalid dates, missing ratings and likely duplicates, and reports the IDs each check flags. Trashed movies and actors are left out. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quality"
+                ],
+                "summary": "Reports data-quality issues",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only run these checks, all of them when omitted",
+                        "name": "check",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The result of every check run",
+                        "schema": {
+                            "$ref": "#/definitions/models.QualityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown check"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error running the checks"
+                    }
+                }
+            }
+        },
+        "/v1/register": {
+            "post": {
+                "description": "creates a regular 'user' account with the given username, password and optional email address, and logs it in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "User registration",
+                "parameters": [
+                    {
+                        "description": "Account Details",
+                        "name": "RegisterRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/routes.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns login token",
+                        "schema": {
+                            "$ref": "#/definitions/routes.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, missing username or password, or invalid email"
+                    },
+                    "409": {
+                        "description": "Username already taken"
+                    }
+                }
+            }
+        },
+        "/v1/review-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submits the authenticated user's rating from 1 to 10 of the movie with the given MovieID, with an optional text review. Each user can review a movie only once; edit the existing review instead. Available to both 'admin' and 'user' roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Rates and reviews a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Review to add, with MovieID, Rating and an optional Text",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the review",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, rating or movie"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "The user already reviewed this movie"
+                    },
+                    "500": {
+                        "description": "Error creating review"
+                    }
+                }
+            }
+        },
+        "/v1/review-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the review with the specified ID. Users can delete their own reviews; admins can delete any review. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Deletes a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the review"
+                    },
+                    "400": {
+                        "description": "Invalid review ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Not the author of the review"
+                    },
+                    "404": {
+                        "description": "Review not found"
+                    },
+                    "500": {
+                        "description": "Review could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/review-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the rating or text of the review with the specified ID. Only the author of a review can edit it. Available to both 'admin' and 'user' roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Edits one's own review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update: rating and text",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the review",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, review ID or rating"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Not the author of the review"
+                    },
+                    "404": {
+                        "description": "Review not found"
+                    },
+                    "500": {
+                        "description": "Failed to save review"
+                    }
+                }
+            }
+        },
+        "/v1/season-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a season with the given number and optional title to the series with the given SeriesID. Season numbers are unique within a series. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Adds a season to a series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Season to add",
+                        "name": "season",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Season"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the season",
+                        "schema": {
+                            "$ref": "#/definitions/models.Season"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, season number or series"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "The series already has a season with this number"
+                    },
+                    "500": {
+                        "description": "Error creating season"
+                    }
+                }
+            }
+        },
+        "/v1/season-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the season with the specified ID together with its episodes and their guest star links. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Deletes a season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the season"
+                    },
+                    "400": {
+                        "description": "Invalid season ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Season not found or could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/season-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the season with the specified ID based on the given update fields, number and title. Season numbers are unique within a series. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Edits an existing season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the season",
+                        "schema": {
+                            "$ref": "#/definitions/models.Season"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, season ID or season number"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Season not found"
+                    },
+                    "409": {
+                        "description": "The series already has a season with this number"
+                    },
+                    "500": {
+                        "description": "Failed to save season"
+                    }
+                }
+            }
+        },
+        "/v1/series-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a new TV series with the given details including title, description, start and end dates, and rating. Seasons and episodes are added separately. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Adds a new series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Series to add",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Series"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the series",
+                        "schema": {
+                            "$ref": "#/definitions/models.Series"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, empty title or invalid start or end date"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error creating series"
+                    }
+                }
+            }
+        },
+        "/v1/series-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the series with the specified ID together with its seasons, episodes and guest star links. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Deletes a series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the series"
+                    },
+                    "400": {
+                        "description": "Invalid series ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Series not found or could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/series-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the series with the specified ID based on the given update fields such as title, description, startdate, enddate and rating. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Edits an existing series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the series",
+                        "schema": {
+                            "$ref": "#/definitions/models.Series"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, series ID or date"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Series not found"
+                    },
+                    "500": {
+                        "description": "Failed to save series"
+                    }
+                }
+            }
+        },
+        "/v1/series-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of all series with their seasons, episodes and guest stars, with sorting. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Lists all series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by field [title|-title|rating|-rating|startdate|-startdate] (default: '-rating')",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved all series",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Series"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving series list"
+                    }
+                }
+            }
+        },
+        "/v1/studio-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a new production company with the given name. Studio names are unique. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "studio"
+                ],
+                "summary": "Adds a new studio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Studio to add",
+                        "name": "studio",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Studio"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the studio",
+                        "schema": {
+                            "$ref": "#/definitions/models.Studio"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or empty name"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "A studio with this name already exists"
+                    },
+                    "500": {
+                        "description": "Error creating studio"
+                    }
+                }
+            }
+        },
+        "/v1/studio-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the studio with the specified ID and unlinks it from all movies. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "studio"
+                ],
+                "summary": "Deletes a studio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Studio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the studio"
+                    },
+                    "400": {
+                        "description": "Invalid studio ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Studio not found or could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/studio-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the studio with the specified ID based on the given update fields. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "studio"
+                ],
+                "summary": "Renames a studio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Studio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the studio",
+                        "schema": {
+                            "$ref": "#/definitions/models.Studio"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or studio ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Studio not found"
+                    },
+                    "409": {
+                        "description": "A studio with this name already exists"
+                    },
+                    "500": {
+                        "description": "Failed to save studio"
+                    }
+                }
+            }
+        },
+        "/v1/studio-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all production companies ordered by name. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "studio"
+                ],
+                "summary": "Lists all studios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved all studios",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Studio"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving studios"
+                    }
+                }
+            }
+        },
+        "/v1/tag-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a new tag with the given keyword. Tag names are unique. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Adds a new tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag to add",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added the tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or empty name"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "409": {
+                        "description": "A tag with this name already exists"
+                    },
+                    "500": {
+                        "description": "Error creating tag"
+                    }
+                }
+            }
+        },
+        "/v1/tag-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the tag with the specified ID and unlinks it from all movies. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Deletes a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the tag"
+                    },
+                    "400": {
+                        "description": "Invalid tag ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Tag not found"
+                    },
+                    "500": {
+                        "description": "Tag could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/tag-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the tag with the specified ID based on the given update fields. Requires 'admin' role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Renames a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or tag ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "404": {
+                        "description": "Tag not found"
+                    },
+                    "409": {
+                        "description": "A tag with this name already exists"
+                    },
+                    "500": {
+                        "description": "Failed to save tag"
+                    }
+                }
+            }
+        },
+        "/v1/tag-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all tags ordered by name. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Lists all tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved all tags",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tag"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving tags"
+                    }
+                }
+            }
+        },
+        "/v1/trash-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the movies and actors in the trash, most recently deleted first, with the time the purge job removes each of them for good. Requires 'admin' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Lists the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list one kind of item [movie|actor]",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the trash",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TrashItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid type"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error retrieving the trash"
+                    }
+                }
+            }
+        },
+        "/v1/watched-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Records that the authenticated user watched the movie with the given MovieID on WatchedOn, which defaults to today. Marking a movie again updates the date. Available to both 'admin' and 'user' roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Marks a movie as watched",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Movie watched, with MovieID and an optional WatchedOn date",
+                        "name": "watched",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchedMovie"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully marked the movie as watched",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchedMovie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or date"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "404": {
+                        "description": "Movie not found"
+                    },
+                    "500": {
+                        "description": "Error saving watched movie"
+                    }
+                }
+            }
+        },
+        "/v1/watched-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes the movie with the specified ID from the authenticated user's watched movies. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Unmarks a watched movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully unmarked the movie"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "404": {
+                        "description": "Movie not marked as watched"
+                    },
+                    "500": {
+                        "description": "Watched movie could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/watched-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the movies the authenticated user marked as watched, most recently watched first, with their full details. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Lists the movies one has watched",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the watched movies",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WatchedMovie"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "500": {
+                        "description": "Error retrieving watched movies"
+                    }
+                }
+            }
+        },
+        "/v1/watchlist-add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates an empty watchlist with the given name for the authenticated user. Lists are private unless Public is set. Available to both 'admin' and 'user' roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Creates a watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Watchlist to create, with Name and Public",
+                        "name": "watchlist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully created the watchlist",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or empty name"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "403": {
+                        "description": "Forbidden - Role not allowed"
+                    },
+                    "500": {
+                        "description": "Error creating watchlist"
+                    }
+                }
+            }
+        },
+        "/v1/watchlist-delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the watchlist with the specified ID. Only the owner of a list can delete it. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Deletes one's own watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted the watchlist"
+                    },
+                    "400": {
+                        "description": "Invalid watchlist ID or URL format"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "404": {
+                        "description": "Watchlist not found"
+                    },
+                    "500": {
+                        "description": "Watchlist could not be deleted"
+                    }
+                }
+            }
+        },
+        "/v1/watchlist-edit/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edits the watchlist with the specified ID based on the given update fields: name, public, and movies, an array of movie IDs replacing the list's contents in the given order. Only the owner of a list can edit it. Available to both 'admin' and 'user' roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Edits one's own watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated the watchlist",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, watchlist ID or unknown movie IDs"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "404": {
+                        "description": "Watchlist not found or owned by another user"
+                    },
+                    "500": {
+                        "description": "Failed to save watchlist"
+                    }
+                }
+            }
+        },
+        "/v1/watchlist-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the authenticated user's watchlists with the full details of their movies. With userId, retrieves the public watchlists of that user instead. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Lists watchlists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List the public watchlists of this user",
+                        "name": "userId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the watchlists",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Watchlist"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "500": {
+                        "description": "Error retrieving watchlists"
+                    }
+                }
+            }
+        },
+        "/v1/watchlist/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the watchlist with the specified ID with the full details of its movies, in order. Users can read their own lists and public lists of other users. Available to both 'admin' and 'user' roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Shows a watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer [JWT token]",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the watchlist",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid watchlist ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized or Invalid token"
+                    },
+                    "404": {
+                        "description": "Watchlist not found or private"
+                    },
+                    "500": {
+                        "description": "Error retrieving watchlist"
+                    }
+                }
+            }
+        }
     },
     "definitions": {
         "models.Actor": {
             "type": "object",
             "properties": {
-                "dateOfBirth": {
+                "Awards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AwardCredit"
+                    }
+                },
+                "Credit": {
+                    "$ref": "#/definitions/models.Credit"
+                },
+                "Language": {
+                    "type": "string"
+                },
+                "Photo": {
+                    "$ref": "#/definitions/models.ImageURLs"
+                },
+                "SeriesCredits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SeriesCredit"
+                    }
+                },
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ActorAlias"
+                    }
+                },
+                "biography": {
+                    "type": "string"
+                },
+                "crewCredits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CrewCredit"
+                    }
+                },
+                "dateOfBirth": {
+                    "type": "string"
+                },
+                "dateOfDeath": {
+                    "type": "string"
+                },
+                "externalIDs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExternalID"
+                    }
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Movie"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "placeOfBirth": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ActorAlias": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ActorFollow": {
+            "type": "object",
+            "properties": {
+                "Actor": {
+                    "$ref": "#/definitions/models.Actor"
+                },
+                "actorID": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ActorPath": {
+            "type": "object",
+            "properties": {
+                "degrees": {
+                    "type": "integer"
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PathNode"
+                    }
+                }
+            }
+        },
+        "models.ActorTranslation": {
+            "type": "object",
+            "properties": {
+                "actorID": {
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "prevHash": {
+                    "type": "string"
+                },
+                "requestID": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditVerification": {
+            "type": "object",
+            "properties": {
+                "brokenAt": {
+                    "type": "integer"
+                },
+                "checked": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Award": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AwardCategory"
+                    }
+                },
+                "ceremonies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AwardCeremony"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AwardCategory": {
+            "type": "object",
+            "properties": {
+                "awardID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AwardCeremony": {
+            "type": "object",
+            "properties": {
+                "awardID": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AwardCredit": {
+            "type": "object",
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "award": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "movieId": {
+                    "type": "integer"
+                },
+                "movieTitle": {
+                    "type": "string"
+                },
+                "nominationId": {
+                    "type": "integer"
+                },
+                "personName": {
+                    "type": "string"
+                },
+                "won": {
+                    "type": "boolean"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Certification": {
+            "type": "object",
+            "properties": {
+                "countryCode": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Collaborator": {
+            "type": "object",
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sharedMovies": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Collection": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CollectionEntry"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CollectionEntry": {
+            "type": "object",
+            "properties": {
+                "Movie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "collectionID": {
+                    "type": "integer"
+                },
+                "movieID": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CollectionPlacement": {
+            "type": "object",
+            "properties": {
+                "collectionId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Country": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Credit": {
+            "type": "object",
+            "properties": {
+                "billingOrder": {
+                    "type": "integer"
+                },
+                "character": {
+                    "type": "string"
+                },
+                "creditType": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CrewCredit": {
+            "type": "object",
+            "properties": {
+                "Movie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "Person": {
+                    "$ref": "#/definitions/models.Actor"
+                },
+                "actorID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job": {
+                    "type": "string"
+                },
+                "movieID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "$ref": "#/definitions/models.Actor"
+                },
+                "duplicate": {
+                    "$ref": "#/definitions/models.Actor"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.EditProposal": {
+            "type": "object",
+            "properties": {
+                "Changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RevisionChange"
+                    }
+                },
+                "User": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "entityID": {
+                    "type": "integer"
+                },
+                "entityType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewerID": {
+                    "type": "integer"
+                },
+                "revisionID": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updates": {
+                    "type": "object"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Episode": {
+            "type": "object",
+            "properties": {
+                "airDate": {
+                    "type": "string"
+                },
+                "guestStars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Actor"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "seasonID": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EpisodeCredit": {
+            "type": "object",
+            "properties": {
+                "episodeId": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExternalID": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImageURLs": {
+            "type": "object",
+            "properties": {
+                "original": {
+                    "type": "string"
+                },
+                "thumbnails": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.Language": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Movie": {
+            "type": "object",
+            "properties": {
+                "Awards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AwardCredit"
+                    }
+                },
+                "Collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CollectionPlacement"
+                    }
+                },
+                "Credit": {
+                    "$ref": "#/definitions/models.Credit"
+                },
+                "Language": {
+                    "type": "string"
+                },
+                "Poster": {
+                    "$ref": "#/definitions/models.ImageURLs"
+                },
+                "Relations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RelatedTitle"
+                    }
+                },
+                "actors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Actor"
+                    }
+                },
+                "certifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Certification"
+                    }
+                },
+                "communityRating": {
+                    "type": "number"
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Country"
+                    }
+                },
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CrewCredit"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "externalIDs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExternalID"
+                    }
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "type": "integer"
+                },
+                "spokenLanguages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Language"
+                    }
+                },
+                "studios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Studio"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "voteCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MovieRelation": {
+            "type": "object",
+            "properties": {
+                "RelatedMovie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movieID": {
+                    "type": "integer"
+                },
+                "relatedMovieID": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MovieSimilarity": {
+            "type": "object",
+            "properties": {
+                "movieID": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "similarMovie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "similarMovieID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MovieTranslation": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "movieID": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Nomination": {
+            "type": "object",
+            "properties": {
+                "actorID": {
+                    "type": "integer"
+                },
+                "categoryID": {
+                    "type": "integer"
+                },
+                "ceremonyID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movieID": {
+                    "type": "integer"
+                },
+                "won": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "actorID": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "movieID": {
+                    "type": "integer"
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PathNode": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "gender": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProposalRejection": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QualityReport": {
+            "type": "object",
+            "properties": {
+                "generatedAt": {
+                    "type": "string"
+                },
+                "issues": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QualityResult"
+                    }
+                }
+            }
+        },
+        "models.QualityResult": {
+            "type": "object",
+            "properties": {
+                "affectedIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "check": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "entityType": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RelatedTitle": {
+            "type": "object",
+            "properties": {
+                "movie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "relationId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Review": {
+            "type": "object",
+            "properties": {
+                "User": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "movies": {
+                "movieID": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Revision": {
+            "type": "object",
+            "properties": {
+                "User": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "action": {
+                    "type": "string"
+                },
+                "changes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Movie"
+                        "$ref": "#/definitions/models.RevisionChange"
                     }
                 },
-                "name": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "entityID": {
+                    "type": "integer"
+                },
+                "entityType": {
                     "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mergedWith": {
+                    "type": "integer"
+                },
+                "revertedTo": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Movie": {
+        "models.RevisionChange": {
             "type": "object",
             "properties": {
-                "actors": {
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Season": {
+            "type": "object",
+            "properties": {
+                "episodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Actor"
+                        "$ref": "#/definitions/models.Episode"
                     }
                 },
+                "id": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "seriesID": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Series": {
+            "type": "object",
+            "properties": {
                 "description": {
                     "type": "string"
                 },
+                "endDate": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "rating": {
                     "type": "number"
                 },
-                "releaseDate": {
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Season"
+                    }
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
+                }
+            }
+        },
+        "models.SeriesCredit": {
+            "type": "object",
+            "properties": {
+                "episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EpisodeCredit"
+                    }
+                },
+                "seriesId": {
+                    "type": "integer"
                 },
                 "title": {
                     "type": "string"
                 }
             }
         },
+        "models.Studio": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Tag": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TrashItem": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "purgeAt": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WatchedMovie": {
+            "type": "object",
+            "properties": {
+                "movie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "movieID": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer"
+                },
+                "watchedOn": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Watchlist": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WatchlistEntry"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "public": {
+                    "type": "boolean"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WatchlistEntry": {
+            "type": "object",
+            "properties": {
+                "addedAt": {
+                    "type": "string"
+                },
+                "movie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "movieID": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "watchlistID": {
+                    "type": "integer"
+                }
+            }
+        },
         "routes.LoginRequest": {
             "type": "object",
             "properties": {
@@ -631,6 +8076,20 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "routes.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
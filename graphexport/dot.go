@@ -0,0 +1,46 @@
+package graphexport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// dotQuoter escapes the characters that would end or break a quoted DOT identifier.
+var dotQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotWriter writes Graphviz DOT documents. Actors are drawn as ellipses and movies as boxes.
+type dotWriter struct {
+	out      io.Writer
+	edgeMode bool
+}
+
+func newDOTWriter(out io.Writer) (*dotWriter, error) {
+	_, err := io.WriteString(out, "graph G {\n")
+	return &dotWriter{out: out}, err
+}
+
+func (d *dotWriter) Node(node Node) error {
+	if d.edgeMode {
+		return errNodeAfterEdge
+	}
+	shape := "ellipse"
+	if node.Kind == "movie" {
+		shape = "box"
+	}
+	_, err := fmt.Fprintf(d.out, "  \"%s\" [label=\"%s\", kind=\"%s\", shape=%s];\n",
+		dotQuoter.Replace(node.ID), dotQuoter.Replace(node.Label), dotQuoter.Replace(node.Kind), shape)
+	return err
+}
+
+func (d *dotWriter) Edge(edge Edge) error {
+	d.edgeMode = true
+	_, err := fmt.Fprintf(d.out, "  \"%s\" -- \"%s\" [weight=%d];\n",
+		dotQuoter.Replace(edge.Source), dotQuoter.Replace(edge.Target), edge.Weight)
+	return err
+}
+
+func (d *dotWriter) Close() error {
+	_, err := io.WriteString(d.out, "}\n")
+	return err
+}
@@ -0,0 +1,67 @@
+package graphexport
+
+import (
+	"fmt"
+	"io"
+)
+
+// Node is a vertex of an exported graph.
+//
+// Fields:
+// - ID: The identifier of the node, unique within the graph (e.g. "a12" for actor 12, "m7" for movie 7).
+// - Label: The human readable label, the actor's name or the movie's title.
+// - Kind: The type of entity the node stands for, "actor" or "movie".
+type Node struct {
+	ID    string
+	Label string
+	Kind  string
+}
+
+// Edge is a connection between two nodes of an exported graph.
+//
+// Fields:
+// - Source: The ID of the first node.
+// - Target: The ID of the second node.
+// - Weight: The weight of the edge, e.g. the number of movies two actors shared. Bipartite edges have a weight of 1.
+type Edge struct {
+	Source string
+	Target string
+	Weight int
+}
+
+// Writer serializes a graph to an output stream one element at a time, so that arbitrarily large graphs can be exported
+// without holding them in memory. Callers must write every node before the first edge and finish with Close.
+type Writer interface {
+	// Node writes a single node.
+	Node(node Node) error
+	// Edge writes a single edge. Once an edge has been written no more nodes may follow.
+	Edge(edge Edge) error
+	// Close writes the trailer of the document. It does not close the underlying stream.
+	Close() error
+}
+
+// Formats maps the supported format names to the file extension and MIME type used when serving them.
+var Formats = map[string]struct {
+	Extension   string
+	ContentType string
+}{
+	"graphml": {Extension: "graphml", ContentType: "application/xml"},
+	"gexf":    {Extension: "gexf", ContentType: "application/xml"},
+	"dot":     {Extension: "dot", ContentType: "text/vnd.graphviz"},
+}
+
+// NewWriter creates a Writer for the given format ("graphml", "gexf" or "dot") and writes the document header to out.
+// The graph is undirected; bipartite graphs simply never connect two nodes of the same kind.
+// Returns an error if the format is unknown or the header cannot be written.
+func NewWriter(format string, out io.Writer) (Writer, error) {
+	switch format {
+	case "graphml":
+		return newGraphMLWriter(out)
+	case "gexf":
+		return newGEXFWriter(out)
+	case "dot":
+		return newDOTWriter(out)
+	default:
+		return nil, fmt.Errorf("unsupported graph format %q", format)
+	}
+}
@@ -0,0 +1,111 @@
+package graphexport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	testNodes = []Node{
+		{ID: "a1", Label: `Tom "T" Hanks`, Kind: "actor"},
+		{ID: "m7", Label: "Sense & Sensibility <1995>", Kind: "movie"},
+	}
+	testEdges = []Edge{{Source: "a1", Target: "m7", Weight: 1}}
+)
+
+// writeGraph writes the test graph in the given format and returns the document.
+func writeGraph(t *testing.T, format string, nodes []Node, edges []Edge) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(format, &buf)
+	if err != nil {
+		t.Fatalf("NewWriter(%q) returned error: %v", format, err)
+	}
+	for _, node := range nodes {
+		if err := writer.Node(node); err != nil {
+			t.Fatalf("Node returned error: %v", err)
+		}
+	}
+	for _, edge := range edges {
+		if err := writer.Edge(edge); err != nil {
+			t.Fatalf("Edge returned error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestWriters(t *testing.T) {
+	tests := []struct {
+		format string
+		xml    bool
+		want   []string
+	}{
+		{"graphml", true, []string{
+			`<node id="a1"><data key="label">Tom &#34;T&#34; Hanks</data><data key="kind">actor</data></node>`,
+			`<data key="label">Sense &amp; Sensibility &lt;1995&gt;</data>`,
+			`<edge id="e1" source="a1" target="m7"><data key="weight">1</data></edge>`,
+		}},
+		{"gexf", true, []string{
+			`<node id="a1" label="Tom &#34;T&#34; Hanks">`,
+			"</nodes>\n    <edges>",
+			`<edge id="1" source="a1" target="m7" weight="1"/>`,
+		}},
+		{"dot", false, []string{
+			`"a1" [label="Tom \"T\" Hanks", kind="actor", shape=ellipse];`,
+			`"m7" [label="Sense & Sensibility <1995>", kind="movie", shape=box];`,
+			`"a1" -- "m7" [weight=1];`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			doc := writeGraph(t, tt.format, testNodes, testEdges)
+			for _, want := range tt.want {
+				if !strings.Contains(doc, want) {
+					t.Errorf("document does not contain %q:\n%s", want, doc)
+				}
+			}
+			if tt.xml {
+				if err := xml.Unmarshal([]byte(doc), new(interface{})); err != nil {
+					t.Errorf("document is not well-formed XML: %v\n%s", err, doc)
+				}
+			}
+		})
+	}
+}
+
+func TestWritersWithoutEdges(t *testing.T) {
+	for _, format := range []string{"graphml", "gexf"} {
+		doc := writeGraph(t, format, testNodes, nil)
+		if err := xml.Unmarshal([]byte(doc), new(interface{})); err != nil {
+			t.Errorf("%s document without edges is not well-formed XML: %v\n%s", format, err, doc)
+		}
+	}
+}
+
+func TestNodeAfterEdge(t *testing.T) {
+	for format := range Formats {
+		writer, err := NewWriter(format, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("NewWriter(%q) returned error: %v", format, err)
+		}
+		writer.Node(testNodes[0])
+		writer.Edge(testEdges[0])
+		if err := writer.Node(testNodes[1]); !errors.Is(err, errNodeAfterEdge) {
+			t.Errorf("%s: Node after Edge returned %v, want errNodeAfterEdge", format, err)
+		}
+	}
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	if _, err := NewWriter("svg", &bytes.Buffer{}); err == nil {
+		t.Error("NewWriter(\"svg\") returned no error")
+	}
+}
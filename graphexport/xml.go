@@ -0,0 +1,108 @@
+package graphexport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errNodeAfterEdge is returned when a node is written once the edges section has started.
+var errNodeAfterEdge = errors.New("nodes must be written before edges")
+
+// escape returns s with XML special characters replaced by entities.
+func escape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// graphMLWriter writes GraphML documents, the XML format read by Gephi, yEd and most graph libraries.
+type graphMLWriter struct {
+	out      io.Writer
+	edgeMode bool
+	edges    int
+}
+
+func newGraphMLWriter(out io.Writer) (*graphMLWriter, error) {
+	_, err := io.WriteString(out, xml.Header+
+		`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`+"\n"+
+		`  <key id="label" for="node" attr.name="label" attr.type="string"/>`+"\n"+
+		`  <key id="kind" for="node" attr.name="kind" attr.type="string"/>`+"\n"+
+		`  <key id="weight" for="edge" attr.name="weight" attr.type="int"/>`+"\n"+
+		`  <graph id="G" edgedefault="undirected">`+"\n")
+	return &graphMLWriter{out: out}, err
+}
+
+func (g *graphMLWriter) Node(node Node) error {
+	if g.edgeMode {
+		return errNodeAfterEdge
+	}
+	_, err := fmt.Fprintf(g.out, `    <node id="%s"><data key="label">%s</data><data key="kind">%s</data></node>`+"\n",
+		escape(node.ID), escape(node.Label), escape(node.Kind))
+	return err
+}
+
+func (g *graphMLWriter) Edge(edge Edge) error {
+	g.edgeMode = true
+	g.edges++
+	_, err := fmt.Fprintf(g.out, `    <edge id="e%d" source="%s" target="%s"><data key="weight">%d</data></edge>`+"\n",
+		g.edges, escape(edge.Source), escape(edge.Target), edge.Weight)
+	return err
+}
+
+func (g *graphMLWriter) Close() error {
+	_, err := io.WriteString(g.out, "  </graph>\n</graphml>\n")
+	return err
+}
+
+// gexfWriter writes GEXF 1.3 documents, Gephi's native format.
+// GEXF keeps nodes and edges in separate sections, so the writer opens the edges section on the first edge.
+type gexfWriter struct {
+	out      io.Writer
+	edgeMode bool
+	edges    int
+}
+
+func newGEXFWriter(out io.Writer) (*gexfWriter, error) {
+	_, err := io.WriteString(out, xml.Header+
+		`<gexf xmlns="http://gexf.net/1.3" version="1.3">`+"\n"+
+		`  <graph defaultedgetype="undirected">`+"\n"+
+		`    <attributes class="node">`+"\n"+
+		`      <attribute id="kind" title="kind" type="string"/>`+"\n"+
+		`    </attributes>`+"\n"+
+		`    <nodes>`+"\n")
+	return &gexfWriter{out: out}, err
+}
+
+func (g *gexfWriter) Node(node Node) error {
+	if g.edgeMode {
+		return errNodeAfterEdge
+	}
+	_, err := fmt.Fprintf(g.out, `      <node id="%s" label="%s"><attvalues><attvalue for="kind" value="%s"/></attvalues></node>`+"\n",
+		escape(node.ID), escape(node.Label), escape(node.Kind))
+	return err
+}
+
+func (g *gexfWriter) Edge(edge Edge) error {
+	if !g.edgeMode {
+		g.edgeMode = true
+		if _, err := io.WriteString(g.out, "    </nodes>\n    <edges>\n"); err != nil {
+			return err
+		}
+	}
+	g.edges++
+	_, err := fmt.Fprintf(g.out, `      <edge id="%d" source="%s" target="%s" weight="%d"/>`+"\n",
+		g.edges, escape(edge.Source), escape(edge.Target), edge.Weight)
+	return err
+}
+
+func (g *gexfWriter) Close() error {
+	section := "    </edges>\n"
+	if !g.edgeMode {
+		section = "    </nodes>\n"
+	}
+	_, err := io.WriteString(g.out, section+"  </graph>\n</gexf>\n")
+	return err
+}
@@ -0,0 +1,121 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"net/http"
+
+	// Register the decoders of the accepted formats.
+	_ "image/gif"
+	_ "image/png"
+)
+
+// MaxPixels caps the width times height of accepted images, so that a small compressed file
+// cannot make the server allocate gigabytes when decoded.
+const MaxPixels = 50_000_000
+
+// ErrUnsupportedFormat is returned by Decode for content that is not a JPEG, PNG or GIF image.
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// ErrTooLarge is returned by Decode for images with more than MaxPixels pixels.
+var ErrTooLarge = errors.New("image dimensions too large")
+
+// Extensions maps the content types accepted by Decode to the file extension used to store them.
+var Extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// Decode sniffs the content type of data from its first bytes, ignoring whatever the client claimed,
+// checks the image dimensions and decodes it. Returns the image and its content type.
+func Decode(data []byte) (image.Image, string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := Extensions[contentType]; !ok {
+		return nil, "", ErrUnsupportedFormat
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	return img, contentType, nil
+}
+
+// Thumbnail scales the image down to the given width, keeping its aspect ratio. Images already narrower are not enlarged.
+// Each thumbnail pixel is the average of the source pixels it covers, which keeps downscaled images smooth.
+// Transparent areas are flattened onto white, since thumbnails are encoded as JPEG.
+func Thumbnail(src image.Image, width int) *image.RGBA {
+	source := flatten(src)
+	sw, sh := source.Rect.Dx(), source.Rect.Dy()
+
+	if width > sw {
+		width = sw
+	}
+	height := sh * width / sw
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := span(y, height, sh)
+		for x := 0; x < width; x++ {
+			x0, x1 := span(x, width, sw)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := source.Pix[sy*source.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					pixel := row[sx*4 : sx*4+4]
+					for c := range sum {
+						sum[c] += int(pixel[c])
+					}
+				}
+			}
+
+			count := (y1 - y0) * (x1 - x0)
+			offset := dst.PixOffset(x, y)
+			for c := range sum {
+				dst.Pix[offset+c] = uint8(sum[c] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// EncodeJPEG writes the image as a JPEG of good quality.
+func EncodeJPEG(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+}
+
+// flatten draws the image onto an opaque white canvas whose bounds start at the origin.
+func flatten(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	canvas := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(canvas, canvas.Rect, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(canvas, canvas.Rect, src, bounds.Min, draw.Over)
+	return canvas
+}
+
+// span returns the range of source coordinates covered by destination coordinate i when scaling size n to size m.
+func span(i, n, m int) (int, int) {
+	start, end := i*m/n, (i+1)*m/n
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
@@ -0,0 +1,107 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+// encodePNG returns a PNG of the given size filled with c.
+func encodePNG(t *testing.T, width, height int, c color.Color) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode returned error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withDimensions rewrites the width and height in the header of a PNG, so the dimension check can be tested without a huge image.
+func withDimensions(data []byte, width, height uint32) []byte {
+	// The IHDR chunk follows the 8 byte signature: length, type, width, height, ... and a CRC of type and data.
+	binary.BigEndian.PutUint32(data[16:], width)
+	binary.BigEndian.PutUint32(data[20:], height)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	var gifData bytes.Buffer
+	if err := gif.Encode(&gifData, image.NewPaletted(image.Rect(0, 0, 2, 2), color.Palette{color.Black}), nil); err != nil {
+		t.Fatalf("gif.Encode returned error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		wantType string
+		wantErr  error
+	}{
+		{"png", encodePNG(t, 4, 3, color.Black), "image/png", nil},
+		{"gif", gifData.Bytes(), "image/gif", nil},
+		{"text", []byte("not an image at all"), "", ErrUnsupportedFormat},
+		{"too many pixels", withDimensions(encodePNG(t, 1, 1, color.Black), MaxPixels/1000+1, 1000), "", ErrTooLarge},
+	}
+
+	for _, tt := range tests {
+		_, contentType, err := Decode(tt.data)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: Decode error = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if contentType != tt.wantType {
+			t.Errorf("%s: Decode content type = %q, want %q", tt.name, contentType, tt.wantType)
+		}
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name                      string
+		width, height, thumbWidth int
+		wantWidth, wantHeight     int
+	}{
+		{"halved", 200, 100, 100, 100, 50},
+		{"aspect ratio kept", 300, 450, 92, 92, 138},
+		{"narrower images are not enlarged", 50, 80, 185, 50, 80},
+		{"height at least one pixel", 1000, 1, 10, 10, 1},
+	}
+
+	for _, tt := range tests {
+		src := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+		bounds := Thumbnail(src, tt.thumbWidth).Bounds()
+		if bounds.Dx() != tt.wantWidth || bounds.Dy() != tt.wantHeight {
+			t.Errorf("%s: Thumbnail size = %dx%d, want %dx%d", tt.name, bounds.Dx(), bounds.Dy(), tt.wantWidth, tt.wantHeight)
+		}
+	}
+}
+
+func TestThumbnailPixels(t *testing.T) {
+	// Each thumbnail pixel covers two source pixels: black and white average to grey, and transparency is flattened onto white.
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	src.Set(0, 0, color.Black)
+	src.Set(1, 0, color.White)
+	src.Set(2, 0, color.Transparent)
+	src.Set(3, 0, color.Transparent)
+
+	thumbnail := Thumbnail(src, 2)
+	if got := thumbnail.RGBAAt(0, 0); got.R != 127 || got.A != 255 {
+		t.Errorf("averaged pixel = %v, want opaque grey", got)
+	}
+	if got := thumbnail.RGBAAt(1, 0); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("transparent pixel = %v, want white", got)
+	}
+}
@@ -0,0 +1,19 @@
+package iso
+
+import "strings"
+
+// Country returns the upper-cased ISO 3166-1 alpha-2 code and the English short name of the country,
+// reporting whether the code is officially assigned. Codes are matched case-insensitively.
+func Country(code string) (string, string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	name, ok := Countries[code]
+	return code, name, ok
+}
+
+// Language returns the lower-cased ISO 639-1 code and the English name of the language,
+// reporting whether the code is part of the standard. Codes are matched case-insensitively.
+func Language(code string) (string, string, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	name, ok := Languages[code]
+	return code, name, ok
+}
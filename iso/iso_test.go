@@ -0,0 +1,49 @@
+package iso
+
+import "testing"
+
+func TestCountry(t *testing.T) {
+	tests := []struct {
+		code     string
+		wantCode string
+		wantName string
+		wantOK   bool
+	}{
+		{"RU", "RU", "Russian Federation", true},
+		{"us", "US", "United States of America", true},
+		{" fr ", "FR", "France", true},
+		{"UK", "UK", "", false},
+		{"RUS", "RUS", "", false},
+		{"", "", "", false},
+	}
+
+	for _, tt := range tests {
+		code, name, ok := Country(tt.code)
+		if code != tt.wantCode || ok != tt.wantOK || (ok && name != tt.wantName) {
+			t.Errorf("Country(%q) = %q, %q, %v, want %q, %q, %v", tt.code, code, name, ok, tt.wantCode, tt.wantName, tt.wantOK)
+		}
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		code     string
+		wantCode string
+		wantName string
+		wantOK   bool
+	}{
+		{"ru", "ru", "Russian", true},
+		{"EN", "en", "English", true},
+		{" de ", "de", "German", true},
+		{"rus", "rus", "", false},
+		{"en-gb", "en-gb", "", false},
+		{"xx", "xx", "", false},
+	}
+
+	for _, tt := range tests {
+		code, name, ok := Language(tt.code)
+		if code != tt.wantCode || ok != tt.wantOK || (ok && name != tt.wantName) {
+			t.Errorf("Language(%q) = %q, %q, %v, want %q, %q, %v", tt.code, code, name, ok, tt.wantCode, tt.wantName, tt.wantOK)
+		}
+	}
+}
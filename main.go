@@ -3,6 +3,8 @@ package main
 import (
 	"flag"
 
+	"github.com/rs/zerolog/log"
+	"vk.com/m/commands"
 	_ "vk.com/m/docs"
 	"vk.com/m/routes"
 	"vk.com/m/utils"
@@ -15,6 +17,14 @@ var (
 func main() {
 	flag.Parse()
 	utils.InitLogger()
+
+	if flag.NArg() > 0 {
+		if err := commands.Run(flag.Args()); err != nil {
+			log.Fatal().Err(err).Msg("Command failed")
+		}
+		return
+	}
+
 	routes.Routes(addr)
 
 }
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"vk.com/m/models"
+)
+
+// Auditor appends entries to the audit trail. It must not fail the request, so errors are its own to log.
+type Auditor interface {
+	Audit(entry *models.AuditEntry)
+}
+
+var auditor Auditor
+
+// SetAuditor makes every request passing AuthMiddleware, and every call to Audit, write to the given audit trail.
+// Until it is called nothing is audited.
+func SetAuditor(a Auditor) {
+	auditor = a
+}
+
+// Audit fills in the request details of the entry and appends it to the audit trail.
+func Audit(w http.ResponseWriter, r *http.Request, entry *models.AuditEntry) {
+	if auditor == nil {
+		return
+	}
+	if entry.RequestID == "" {
+		entry.RequestID = requestID(w, r)
+	}
+	entry.Method = r.Method
+	entry.Endpoint = r.URL.Path
+	entry.IP = clientIP(r)
+	entry.Outcome = outcome(entry.Status)
+	auditor.Audit(entry)
+}
+
+// requestID returns the X-Request-ID sent by the client, or a new random one, and echoes it in the response.
+func requestID(w http.ResponseWriter, r *http.Request) string {
+	id := strings.TrimSpace(r.Header.Get("X-Request-ID"))
+	if id == "" || len(id) > 64 {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err == nil {
+			id = hex.EncodeToString(buf)
+		}
+	}
+	w.Header().Set("X-Request-ID", id)
+	return id
+}
+
+// clientIP returns the address of the client the request came from.
+// Forwarding headers are not trusted, since any client can set them.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// outcome summarizes an HTTP status for the audit trail.
+func outcome(status int) string {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return "denied"
+	case status >= 500:
+		return "error"
+	case status >= 400:
+		return "rejected"
+	default:
+		return "success"
+	}
+}
+
+// statusRecorder remembers the status code written by the handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(data []byte) (int, error) {
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+	return recorder.ResponseWriter.Write(data)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush streamed exports.
+func (recorder *statusRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
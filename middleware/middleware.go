@@ -7,17 +7,31 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog/log"
 	"vk.com/m/auth"
+	"vk.com/m/models"
 )
 
 // AuthMiddleware is a middleware for JWT authentication
 // @Summary JWT Authentication Middleware
 // @Description It validates the JWT token and ensures the role is allowed to access the endpoint
+// The token claims are passed on in the request context, see auth.ClaimsFromContext
+// Every request is written to the audit trail with its outcome, see SetAuditor
 // @Param Authorization header string true "Bearer [JWT token]"
 // @Success 200 "Access granted"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
 func AuthMiddleware(next http.Handler, allowedRoles ...string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entry := &models.AuditEntry{Action: "request", RequestID: requestID(w, r)}
+		recorder := &statusRecorder{ResponseWriter: w}
+		w = recorder
+		defer func() {
+			entry.Status = recorder.status
+			if entry.Status == 0 {
+				entry.Status = http.StatusOK
+			}
+			Audit(w, r, entry)
+		}()
+
 		authHeaderParts := strings.Split(r.Header.Get("Authorization"), " ")
 		if len(authHeaderParts) != 2 || authHeaderParts[0] != "Bearer" {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
@@ -37,6 +51,8 @@ func AuthMiddleware(next http.Handler, allowedRoles ...string) http.Handler {
 			return
 		}
 
+		entry.UserID, entry.Role = &claims.UserID, claims.Role
+
 		roleIsAllowed := false
 		for _, role := range allowedRoles {
 			if claims.Role == role {
@@ -53,6 +69,6 @@ func AuthMiddleware(next http.Handler, allowedRoles ...string) http.Handler {
 			log.Info().Str("role", claims.Role).Msg("Access granted")
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(auth.WithClaims(r.Context(), claims)))
 	})
 }
@@ -1,19 +1,54 @@
 package models
 
+import "gorm.io/gorm"
+
 // Actor represents an actor in the movie database.
-// It contains information about the actor's ID, name, gender, date of birth, and the movies they've acted in.
+// It contains information about the actor's ID, name, gender, life dates, biography, and the movies they've acted in.
+// Crew members are stored as actors too, so an Actor is really any person credited on a movie, in front of or behind the camera.
 // The struct uses GORM annotations to define how it maps to your database schema, specifying field properties like primary keys and field types.
 //
 // Fields:
 // - ID: The unique identifier for the actor. It's marked as the primary key in the database.
 // - Name: The name of the actor, stored as a varchar(255) in the database and cannot be null.
-// - Gender: The gender of the actor, stored as a single character (M or F) indicating male or female, respectively.
+// - Gender: The gender of the actor, one of "female", "male", "non-binary" or "other", stored as a varchar(20). Empty when not stated.
 // - DateOfBirth: The date of birth of the actor, stored as a string. No specific type is enforced in the database schema through GORM annotations.
+// - DateOfDeath: The date of death of the actor, stored as a string like DateOfBirth. Empty while the actor is alive or when unknown.
+// - PlaceOfBirth: Where the actor was born, stored as a varchar(255).
+// - Biography: A free text biography of the actor.
+// - Aliases: The stage names, birth names and other names the actor is known by, stored in the "actor_aliases" table.
 // - Movies: A slice of pointers to Movie structs, representing the many-to-many relationship between actors and movies. This is managed through the "actormovies" join table.
+// - CrewCredits: The jobs the person held behind the camera, stored in the "crew_credits" table.
+// - ExternalIDs: The identifiers of the person in other databases such as IMDb, stored in the "external_ids" table.
+// - SeriesCredits: The series episodes the actor guest starred in, grouped by series. Not stored on the actors table.
+// - PhotoKey: The blob store key of the uploaded photo, stored as a varchar(255). Empty without a photo. Not sent to clients.
+// - Photo: The URLs of the photo and its thumbnails, derived from PhotoKey whenever the actor is loaded.
+// - Credit: The role played, set only when the actor is listed as part of a movie's cast. Not stored on the actors table.
+// - Awards: The award nominations and wins of the person. Not stored on the actors table.
+// - Language: The language Name is given in, set only when the response was localized for the client. Not stored on the actors table.
+// - DeletedAt: When the actor was moved to the trash. GORM hides trashed actors from every query on the model. Not sent to clients.
 type Actor struct {
-	ID          int    `gorm:"primary_key"`
-	Name        string `gorm:"type:varchar(255);not null"`
-	Gender      string `gorm:"type:char(1)"`
-	DateOfBirth string
-	Movies      []*Movie `gorm:"many2many:actormovies;"`
+	ID            int    `gorm:"primary_key"`
+	Name          string `gorm:"type:varchar(255);not null"`
+	Gender        string `gorm:"type:varchar(20)"`
+	DateOfBirth   string
+	DateOfDeath   string
+	PlaceOfBirth  string          `gorm:"type:varchar(255)"`
+	Biography     string          `gorm:"type:text"`
+	Aliases       []*ActorAlias   `gorm:"foreignKey:ActorID"`
+	Movies        []*Movie        `gorm:"many2many:actormovies;"`
+	CrewCredits   []*CrewCredit   `gorm:"foreignKey:ActorID"`
+	ExternalIDs   []*ExternalID   `gorm:"foreignKey:ActorID"`
+	SeriesCredits []*SeriesCredit `gorm:"-" json:"SeriesCredits,omitempty"`
+	PhotoKey      string          `gorm:"type:varchar(255)" json:"-"`
+	Photo         *ImageURLs      `gorm:"-" json:"Photo,omitempty"`
+	Credit        *Credit         `gorm:"-" json:"Credit,omitempty"`
+	Awards        []*AwardCredit  `gorm:"-" json:"Awards,omitempty"`
+	Language      string          `gorm:"-" json:"Language,omitempty"`
+	DeletedAt     gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// AfterFind fills in the photo URLs of actors read from the database.
+func (actor *Actor) AfterFind(tx *gorm.DB) error {
+	actor.Photo = NewImageURLs(actor.PhotoKey)
+	return nil
 }
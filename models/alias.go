@@ -0,0 +1,16 @@
+package models
+
+// ActorAlias is another name an actor is known by, such as a stage name or their name at birth.
+// Aliases are matched by the actor name searches just like the actor's own name.
+//
+// Fields:
+// - ID: The unique identifier for the alias, serving as the primary key in the database.
+// - ActorID: The ID of the actor the alias belongs to. Not sent to clients.
+// - Name: The alias itself, stored as a varchar(255) and not nullable.
+// - Kind: What kind of name it is, one of "stage", "birth" or "alternate", stored as a varchar(20).
+type ActorAlias struct {
+	ID      int    `gorm:"primary_key"`
+	ActorID int    `gorm:"index;not null" json:"-"`
+	Name    string `gorm:"type:varchar(255);not null"`
+	Kind    string `gorm:"type:varchar(20);not null;default:alternate"`
+}
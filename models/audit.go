@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// AuditEntry is one line of the audit trail: an authenticated request, a login or a failed login.
+// Entries are only ever appended. Each one carries the hash of the previous entry and its own hash over both,
+// so removing or altering an entry breaks the chain from that point on.
+//
+// Fields:
+// - ID: The unique identifier for the entry, serving as the primary key in the database. Entries are chained in ID order.
+// - CreatedAt: When the request was handled.
+// - UserID: The ID of the authenticated user. Null when the token was missing or invalid, or the login failed.
+// - Username: The username given to a login, stored as a varchar(100). Empty for other requests.
+// - Role: The role carried by the token, stored as a varchar(20).
+// - Action: What was audited, one of "request", "login" or "login-failed", stored as a varchar(20).
+// - Method: The HTTP method of the request, stored as a varchar(10).
+// - Endpoint: The path of the request, stored as a varchar(255).
+// - Status: The HTTP status code of the response.
+// - Outcome: The status summarized as "success", "denied" (401 or 403), "rejected" (other 4xx) or "error" (5xx), stored as a varchar(10).
+// - IP: The address of the client, stored as a varchar(45).
+// - RequestID: The X-Request-ID of the request, generated when the client sent none, stored as a varchar(64).
+// - PrevHash: The hash of the previous entry, empty for the first one.
+// - Hash: The SHA-256 of the entry's fields and PrevHash, hex encoded.
+type AuditEntry struct {
+	ID        int       `gorm:"primary_key"`
+	CreatedAt time.Time `gorm:"index"`
+	UserID    *int      `gorm:"index"`
+	Username  string    `gorm:"type:varchar(100)"`
+	Role      string    `gorm:"type:varchar(20)"`
+	Action    string    `gorm:"type:varchar(20);not null;index"`
+	Method    string    `gorm:"type:varchar(10)"`
+	Endpoint  string    `gorm:"type:varchar(255)"`
+	Status    int
+	Outcome   string `gorm:"type:varchar(10);not null"`
+	IP        string `gorm:"type:varchar(45)"`
+	RequestID string `gorm:"type:varchar(64)"`
+	PrevHash  string `gorm:"type:varchar(64)"`
+	Hash      string `gorm:"type:varchar(64);not null;uniqueIndex"`
+}
+
+// AuditVerification is the result of checking the hash chain of the audit trail.
+//
+// Fields:
+// - Checked: The number of entries checked.
+// - Valid: Whether every entry matches its hash and links to the entry before it.
+// - BrokenAt: The ID of the first entry that does not, set only when the chain is broken.
+type AuditVerification struct {
+	Checked  int  `json:"checked"`
+	Valid    bool `json:"valid"`
+	BrokenAt *int `json:"brokenAt,omitempty"`
+}
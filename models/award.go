@@ -0,0 +1,86 @@
+package models
+
+// Award is an awarding body, such as a film festival or an academy.
+//
+// Fields:
+// - ID: The unique identifier for the award, serving as the primary key in the database.
+// - Name: The name of the award, stored as a varchar(150). Unique and not nullable.
+// - Ceremonies: The editions of the award, one per year.
+// - Categories: The categories the award is given in.
+type Award struct {
+	ID         int              `gorm:"primary_key"`
+	Name       string           `gorm:"type:varchar(150);not null;uniqueIndex"`
+	Ceremonies []*AwardCeremony `gorm:"foreignKey:AwardID"`
+	Categories []*AwardCategory `gorm:"foreignKey:AwardID"`
+}
+
+// AwardCeremony is the edition of an award held in a given year.
+//
+// Fields:
+// - ID: The unique identifier for the ceremony, serving as the primary key in the database.
+// - AwardID: The ID of the award. An award holds at most one ceremony a year.
+// - Year: The year of the ceremony.
+// - Name: The name of the edition, e.g. "96th Academy Awards", stored as a varchar(150). Optional.
+// - Date: The date the ceremony was held on, stored as a string. Optional.
+type AwardCeremony struct {
+	ID      int    `gorm:"primary_key"`
+	AwardID int    `gorm:"not null;uniqueIndex:idx_award_ceremony"`
+	Year    int    `gorm:"not null;uniqueIndex:idx_award_ceremony"`
+	Name    string `gorm:"type:varchar(150)"`
+	Date    string
+}
+
+// AwardCategory is a category an award is given in, such as "Best Picture".
+//
+// Fields:
+// - ID: The unique identifier for the category, serving as the primary key in the database.
+// - AwardID: The ID of the award. Category names are unique within an award.
+// - Name: The name of the category, stored as a varchar(150) and not nullable.
+type AwardCategory struct {
+	ID      int    `gorm:"primary_key"`
+	AwardID int    `gorm:"not null;uniqueIndex:idx_award_category"`
+	Name    string `gorm:"type:varchar(150);not null;uniqueIndex:idx_award_category"`
+}
+
+// Nomination records that a movie, and optionally a person for their work on it, was nominated in a category at a ceremony.
+// Winning nominations have Won set. The same movie and person are nominated at most once per category and ceremony.
+//
+// Fields:
+// - ID: The unique identifier for the nomination, serving as the primary key in the database.
+// - CeremonyID: The ID of the ceremony.
+// - CategoryID: The ID of the category, which must belong to the same award as the ceremony.
+// - MovieID: The ID of the nominated movie.
+// - ActorID: The ID of the nominated person, or null for categories honouring the movie as a whole.
+// - Won: Whether the nomination won.
+type Nomination struct {
+	ID         int  `gorm:"primary_key"`
+	CeremonyID int  `gorm:"not null;index"`
+	CategoryID int  `gorm:"not null;index"`
+	MovieID    int  `gorm:"not null;index"`
+	ActorID    *int `gorm:"index"`
+	Won        bool `gorm:"not null;default:false"`
+}
+
+// AwardCredit is one nomination as listed on a movie or a person, with the names it refers to resolved.
+//
+// Fields:
+// - NominationID: The ID of the nomination.
+// - Award: The name of the award.
+// - Year: The year of the ceremony.
+// - Category: The name of the category.
+// - Won: Whether the nomination won.
+// - MovieID: The ID of the nominated movie.
+// - MovieTitle: The title of the nominated movie.
+// - ActorID: The ID of the nominated person, if any.
+// - PersonName: The name of the nominated person, if any.
+type AwardCredit struct {
+	NominationID int    `json:"nominationId"`
+	Award        string `json:"award"`
+	Year         int    `json:"year"`
+	Category     string `json:"category"`
+	Won          bool   `json:"won"`
+	MovieID      int    `json:"movieId"`
+	MovieTitle   string `json:"movieTitle"`
+	ActorID      *int   `json:"actorId,omitempty"`
+	PersonName   string `json:"personName,omitempty"`
+}
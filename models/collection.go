@@ -0,0 +1,73 @@
+package models
+
+// Collection groups movies into a franchise, such as a trilogy, in viewing order.
+//
+// Fields:
+// - ID: The unique identifier for the collection, serving as the primary key in the database.
+// - Name: The name of the collection, stored as a varchar(150). Unique and not nullable.
+// - Description: A description of the collection, allowing for up to varchar(1000) characters. Optional.
+// - Entries: The movies of the collection, in order.
+type Collection struct {
+	ID          int                `gorm:"primary_key"`
+	Name        string             `gorm:"type:varchar(150);not null;uniqueIndex"`
+	Description string             `gorm:"type:varchar(1000)"`
+	Entries     []*CollectionEntry `gorm:"foreignKey:CollectionID"`
+}
+
+// CollectionEntry places a movie in a collection.
+//
+// Fields:
+// - CollectionID: The ID of the collection. Part of the composite primary key.
+// - MovieID: The ID of the movie. Part of the composite primary key, so a movie appears in a collection at most once.
+// - Position: The position of the movie in the collection, starting at 1.
+// - Movie: The movie itself.
+type CollectionEntry struct {
+	CollectionID int    `gorm:"primaryKey;autoIncrement:false"`
+	MovieID      int    `gorm:"primaryKey;autoIncrement:false;index"`
+	Position     int    `gorm:"not null"`
+	Movie        *Movie `gorm:"foreignKey:MovieID" json:"Movie,omitempty"`
+}
+
+// MovieRelation links two related titles. A relation reads "RelatedMovie is the Type of Movie",
+// so a row with Type "sequel" says that RelatedMovie is a sequel of Movie.
+//
+// Fields:
+// - ID: The unique identifier for the relation, serving as the primary key in the database.
+// - MovieID: The ID of the movie the relation starts from.
+// - RelatedMovieID: The ID of the related movie.
+// - Type: How RelatedMovie relates to Movie, one of "sequel", "prequel", "remake", "original", "directors-cut",
+// "theatrical-cut", "alternate-version", "spin-off" or "spun-off-from". Two movies are linked with a given type at most once.
+// - RelatedMovie: The related movie, loaded when listing relations.
+type MovieRelation struct {
+	ID             int    `gorm:"primary_key"`
+	MovieID        int    `gorm:"not null;uniqueIndex:idx_movie_relation"`
+	RelatedMovieID int    `gorm:"not null;uniqueIndex:idx_movie_relation;index"`
+	Type           string `gorm:"type:varchar(30);not null;uniqueIndex:idx_movie_relation"`
+	RelatedMovie   *Movie `gorm:"foreignKey:RelatedMovieID" json:"RelatedMovie,omitempty"`
+}
+
+// RelatedTitle is one relation of a movie as seen from that movie, whichever side of the MovieRelation it is stored on.
+//
+// Fields:
+// - RelationID: The ID of the underlying MovieRelation, used to delete it.
+// - Type: How Movie relates to the movie it is listed on, e.g. "sequel" when Movie is its sequel.
+// - Movie: The related movie.
+type RelatedTitle struct {
+	RelationID int    `json:"relationId"`
+	Type       string `json:"type"`
+	Movie      *Movie `json:"movie"`
+}
+
+// CollectionPlacement tells where a movie stands in one of its collections.
+//
+// Fields:
+// - CollectionID: The ID of the collection.
+// - Name: The name of the collection.
+// - Position: The position of the movie in the collection, starting at 1.
+// - Size: The number of movies in the collection.
+type CollectionPlacement struct {
+	CollectionID int    `json:"collectionId"`
+	Name         string `json:"name"`
+	Position     int    `json:"position"`
+	Size         int    `json:"size"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// Credit describes the part an actor played in a movie.
+// It is stored on the "actormovies" join table and attached to the actors of a movie, or the movies of an actor, when they are listed.
+//
+// Fields:
+// - Character: The name of the character played, stored as a varchar(255). Optional.
+// - BillingOrder: The actor's position in the credits, starting at 1. Zero means the position is unknown and sorts last.
+// - CreditType: The kind of appearance, one of "lead", "supporting", "cameo" or "voice". Defaults to "supporting".
+type Credit struct {
+	Character    string `gorm:"type:varchar(255)"`
+	BillingOrder int    `gorm:"not null;default:0"`
+	CreditType   string `gorm:"type:varchar(20);not null;default:supporting"`
+}
+
+// ActorMovie is the "actormovies" join table linking actors to the movies they appeared in, together with their credit.
+//
+// Fields:
+// - ActorID: The ID of the actor. Part of the composite primary key.
+// - MovieID: The ID of the movie. Part of the composite primary key.
+// - Credit: The character, billing order and credit type of the appearance.
+type ActorMovie struct {
+	ActorID int `gorm:"primaryKey"`
+	MovieID int `gorm:"primaryKey"`
+	Credit  `gorm:"embedded"`
+}
+
+// TableName keeps the join table name GORM derived from the many2many tags before the credit columns were added.
+func (ActorMovie) TableName() string {
+	return "actormovies"
+}
@@ -0,0 +1,20 @@
+package models
+
+// CrewCredit links a person to a movie they worked on behind the camera.
+// People are stored as actors, so the same person can both appear in a movie's cast and be credited on its crew.
+//
+// Fields:
+// - ID: The unique identifier for the credit, serving as the primary key in the database.
+// - ActorID: The ID of the person credited. A person holds a given job on a movie at most once.
+// - MovieID: The ID of the movie the person worked on.
+// - Job: The job held, one of "director", "screenwriter", "composer", "cinematographer" or "producer".
+// - Person: The person credited, loaded when listing a movie's crew.
+// - Movie: The movie worked on, loaded when listing a person's crew credits.
+type CrewCredit struct {
+	ID      int    `gorm:"primary_key"`
+	ActorID int    `gorm:"not null;uniqueIndex:idx_crew_credit"`
+	MovieID int    `gorm:"not null;uniqueIndex:idx_crew_credit;index"`
+	Job     string `gorm:"type:varchar(30);not null;uniqueIndex:idx_crew_credit"`
+	Person  *Actor `gorm:"foreignKey:ActorID" json:"Person,omitempty"`
+	Movie   *Movie `gorm:"foreignKey:MovieID" json:"Movie,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// ExternalID links a movie or a person to its record in another database, such as IMDb, Kinopoisk or Wikidata.
+// Exactly one of MovieID and ActorID is set. An identifier belongs to one entity only,
+// and an entity has at most one identifier per source.
+//
+// Fields:
+// - ID: The unique identifier of the link, serving as the primary key in the database. Not sent to clients.
+// - Source: The external database, one of "imdb", "kinopoisk" or "wikidata", stored as a varchar(20).
+// - Value: The identifier in the external database, such as "tt0111161", stored as a varchar(20). Unique per source.
+// - MovieID: The ID of the linked movie, or null for a person. Not sent to clients.
+// - ActorID: The ID of the linked person, or null for a movie. Not sent to clients.
+type ExternalID struct {
+	ID      int    `gorm:"primary_key" json:"-"`
+	Source  string `gorm:"type:varchar(20);not null;uniqueIndex:idx_external_id;uniqueIndex:idx_external_movie;uniqueIndex:idx_external_actor"`
+	Value   string `gorm:"type:varchar(20);not null;uniqueIndex:idx_external_id"`
+	MovieID *int   `gorm:"uniqueIndex:idx_external_movie" json:"-"`
+	ActorID *int   `gorm:"uniqueIndex:idx_external_actor" json:"-"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// ActorFollow records that a user follows an actor and wants to hear about their new movies.
+//
+// Fields:
+// - UserID: The ID of the follower. Part of the composite primary key.
+// - ActorID: The ID of the followed actor. Part of the composite primary key.
+// - CreatedAt: When the user started following the actor.
+// - Actor: The followed actor, loaded when listing follows.
+type ActorFollow struct {
+	UserID    int `gorm:"primaryKey;autoIncrement:false"`
+	ActorID   int `gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time
+	Actor     *Actor `gorm:"foreignKey:ActorID" json:"Actor,omitempty"`
+}
+
+// Notification is an entry of a user's notification feed, created when a followed actor is added to a movie.
+//
+// Fields:
+// - ID: The unique identifier for the notification, serving as the primary key in the database.
+// - UserID: The ID of the recipient.
+// - ActorID: The ID of the followed actor the notification is about.
+// - MovieID: The ID of the movie the actor was added to.
+// - Message: The text of the notification, stored as a varchar(500).
+// - Read: Whether the user has marked the notification as read.
+// - CreatedAt: When the notification was created.
+type Notification struct {
+	ID        int    `gorm:"primary_key"`
+	UserID    int    `gorm:"not null;index"`
+	ActorID   int    `gorm:"not null"`
+	MovieID   int    `gorm:"not null"`
+	Message   string `gorm:"type:varchar(500);not null"`
+	Read      bool   `gorm:"not null;default:false"`
+	CreatedAt time.Time
+}
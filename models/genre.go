@@ -0,0 +1,12 @@
+package models
+
+// Genre represents a movie genre such as "Drama" or "Science Fiction".
+// Movies are linked to genres through the "moviegenres" join table.
+//
+// Fields:
+// - ID: The unique identifier for the genre, serving as the primary key in the database.
+// - Name: The name of the genre, stored as a varchar(100). It is unique and cannot be null.
+type Genre struct {
+	ID   int    `gorm:"primary_key"`
+	Name string `gorm:"type:varchar(100);not null;uniqueIndex"`
+}
@@ -0,0 +1,37 @@
+package models
+
+// Collaborator is an actor who appeared in movies together with another actor.
+// It is returned by the collaborators endpoint, ranked by the number of shared movies.
+//
+// Fields:
+// - ActorID: The ID of the collaborating actor.
+// - Name: The name of the collaborating actor.
+// - SharedMovies: The number of distinct movies both actors appeared in.
+type Collaborator struct {
+	ActorID      int    `json:"actorId"`
+	Name         string `json:"name"`
+	SharedMovies int    `json:"sharedMovies"`
+}
+
+// PathNode is a single step of a connection path between two actors.
+// Paths alternate between actor and movie nodes, starting and ending with an actor.
+//
+// Fields:
+// - Type: Either "actor" or "movie".
+// - ID: The ID of the actor or movie.
+// - Name: The actor's name or the movie's title.
+type PathNode struct {
+	Type string `json:"type"`
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ActorPath is the shortest connection between two actors through the movies they shared with others.
+//
+// Fields:
+// - Degrees: The number of movies on the path, 0 when both ends are the same actor.
+// - Nodes: The path itself, alternating actor and movie nodes.
+type ActorPath struct {
+	Degrees int        `json:"degrees"`
+	Nodes   []PathNode `json:"nodes"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"path"
+	"strings"
+)
+
+// MediaURL turns the key of a stored image into the URL clients download it from.
+// It is set at startup from the configured blob store; while nil, no image URLs are included in responses.
+var MediaURL func(key string) string
+
+// ThumbnailSizes lists the thumbnails generated for every uploaded image, by name and width in pixels.
+var ThumbnailSizes = []struct {
+	Name  string
+	Width int
+}{
+	{"small", 92},
+	{"medium", 185},
+	{"large", 500},
+}
+
+// ImageURLs holds the download addresses of an uploaded image and its thumbnails.
+//
+// Fields:
+// - Original: The URL of the image as uploaded.
+// - Thumbnails: The URLs of the JPEG thumbnails, keyed by size name ("small", "medium" and "large").
+type ImageURLs struct {
+	Original   string            `json:"original"`
+	Thumbnails map[string]string `json:"thumbnails"`
+}
+
+// NewImageURLs returns the URLs of the image stored under key, or nil if there is no image or no MediaURL.
+func NewImageURLs(key string) *ImageURLs {
+	if key == "" || MediaURL == nil {
+		return nil
+	}
+
+	urls := &ImageURLs{Original: MediaURL(key), Thumbnails: make(map[string]string, len(ThumbnailSizes))}
+	for _, size := range ThumbnailSizes {
+		urls.Thumbnails[size.Name] = MediaURL(ThumbnailKey(key, size.Name))
+	}
+	return urls
+}
+
+// ThumbnailKey returns the key of the named thumbnail of the image stored under key,
+// e.g. "movies/3/poster-9c1f-small.jpg" for "movies/3/poster-9c1f.png".
+func ThumbnailKey(key, size string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "-" + size + ".jpg"
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ActorRedirect keeps the ID of an actor that was merged into another one resolving to the actor that absorbed it.
+// Redirects are flattened on every merge, so an old ID always points directly at a live actor.
+//
+// Fields:
+// - FromID: The ID of the merged duplicate. Serves as the primary key.
+// - ToID: The ID of the actor the duplicate was merged into.
+// - CreatedAt: When the merge happened.
+type ActorRedirect struct {
+	FromID    int `gorm:"primaryKey;autoIncrement:false"`
+	ToID      int `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// DuplicateCandidate is a pair of actors that are likely the same person, as suggested by the duplicate finder.
+//
+// Fields:
+// - Actor: The actor with the lower ID, usually the one to keep.
+// - Duplicate: The other actor.
+// - Score: How likely the two are the same person, from 0 to 1. Combines the similarity of their names and aliases with their dates of birth.
+// - Reasons: What the score is based on, e.g. "same name" or "different dates of birth".
+type DuplicateCandidate struct {
+	Actor     *Actor   `json:"actor"`
+	Duplicate *Actor   `json:"duplicate"`
+	Score     float64  `json:"score"`
+	Reasons   []string `json:"reasons"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "gorm.io/gorm"
+
 // Movie represents a movie in the database.
 // It includes details about the movie's ID, title, description, release date, and rating, as well as the actors who have appeared in the movie.
 // GORM annotations are used to specify the database schema details, such as primary keys and field types.
@@ -10,12 +12,56 @@ package models
 // - Description: A description of the movie, allowing for up to varchar(1000) characters. This field is not marked as not null, so it's optional.
 // - ReleaseDate: The release date of the movie, stored as a string. Like the DateOfBirth in the Actor model, no specific database type is enforced via GORM.
 // - Rating: The movie's rating, stored as a decimal with one digit after the decimal point (e.g., 8.5). This allows for a rating scale of 0.0 to 9.9.
+// - CommunityRating: The average of the users' own ratings, rounded to one decimal. Maintained from the reviews and zero until the first one.
+// - VoteCount: The number of users who rated the movie.
+// - Runtime: The running time of the movie in minutes. Zero when unknown.
 // - Actors: A slice of pointers to Actor structs, indicating the many-to-many relationship with actors through the "actormovies" join table. This shows which actors have appeared in the movie.
+// - Genres: The genres of the movie, linked through the "moviegenres" join table.
+// - Tags: The keywords attached to the movie, linked through the "movietags" join table.
+// - Studios: The production companies of the movie, linked through the "moviestudios" join table.
+// - Countries: The countries of origin of the movie, linked by ISO 3166-1 code through the "moviecountries" join table.
+// - SpokenLanguages: The languages spoken in the movie, linked by ISO 639-1 code through the "movielanguages" join table.
+// - Certifications: The age ratings of the movie, one per country, stored in the "certifications" table.
+// - ExternalIDs: The identifiers of the movie in other databases such as IMDb, stored in the "external_ids" table.
+// - Crew: The people who worked on the movie behind the camera and their jobs, stored in the "crew_credits" table.
+// - PosterKey: The blob store key of the uploaded poster, stored as a varchar(255). Empty without a poster. Not sent to clients.
+// - Poster: The URLs of the poster and its thumbnails, derived from PosterKey whenever the movie is loaded.
+// - Credit: The role played, set only when the movie is listed as part of an actor's filmography. Not stored on the movies table.
+// - Relations: The related titles, such as sequels, remakes or alternate cuts. Only set on the single-movie response. Not stored on the movies table.
+// - Collections: The franchises the movie belongs to and its position in each. Only set on the single-movie response. Not stored on the movies table.
+// - Awards: The award nominations and wins of the movie and of the people nominated for their work on it. Only set on the single-movie response. Not stored on the movies table.
+// - Language: The language Title and Description are given in, set only when the response was localized for the client. Not stored on the movies table.
+// - DeletedAt: When the movie was moved to the trash. GORM hides trashed movies from every query on the model. Not sent to clients.
 type Movie struct {
-	ID          int    `gorm:"primary_key"`
-	Title       string `gorm:"type:varchar(150);not null"`
-	Description string `gorm:"type:varchar(1000)"`
-	ReleaseDate string
-	Rating      float64  `gorm:"type:decimal(2,1)"`
-	Actors      []*Actor `gorm:"many2many:actormovies;"`
+	ID              int    `gorm:"primary_key"`
+	Title           string `gorm:"type:varchar(150);not null"`
+	Description     string `gorm:"type:varchar(1000)"`
+	ReleaseDate     string
+	Rating          float64                `gorm:"type:decimal(2,1)"`
+	CommunityRating float64                `gorm:"type:decimal(3,1);not null;default:0"`
+	VoteCount       int                    `gorm:"not null;default:0"`
+	Runtime         int                    `gorm:"not null;default:0"`
+	Actors          []*Actor               `gorm:"many2many:actormovies;"`
+	Genres          []*Genre               `gorm:"many2many:moviegenres;"`
+	Tags            []*Tag                 `gorm:"many2many:movietags;"`
+	Studios         []*Studio              `gorm:"many2many:moviestudios;"`
+	Countries       []*Country             `gorm:"many2many:moviecountries;"`
+	SpokenLanguages []*Language            `gorm:"many2many:movielanguages;"`
+	Certifications  []*Certification       `gorm:"foreignKey:MovieID"`
+	ExternalIDs     []*ExternalID          `gorm:"foreignKey:MovieID"`
+	Crew            []*CrewCredit          `gorm:"foreignKey:MovieID"`
+	PosterKey       string                 `gorm:"type:varchar(255)" json:"-"`
+	Poster          *ImageURLs             `gorm:"-" json:"Poster,omitempty"`
+	Credit          *Credit                `gorm:"-" json:"Credit,omitempty"`
+	Relations       []*RelatedTitle        `gorm:"-" json:"Relations,omitempty"`
+	Collections     []*CollectionPlacement `gorm:"-" json:"Collections,omitempty"`
+	Awards          []*AwardCredit         `gorm:"-" json:"Awards,omitempty"`
+	Language        string                 `gorm:"-" json:"Language,omitempty"`
+	DeletedAt       gorm.DeletedAt         `gorm:"index" json:"-"`
+}
+
+// AfterFind fills in the poster URLs of movies read from the database.
+func (movie *Movie) AfterFind(tx *gorm.DB) error {
+	movie.Poster = NewImageURLs(movie.PosterKey)
+	return nil
 }
@@ -0,0 +1,46 @@
+package models
+
+// Studio represents a production company.
+// Movies are linked to studios through the "moviestudios" join table.
+//
+// Fields:
+// - ID: The unique identifier for the studio, serving as the primary key in the database.
+// - Name: The name of the studio, stored as a varchar(150). It is unique and cannot be null.
+type Studio struct {
+	ID   int    `gorm:"primary_key"`
+	Name string `gorm:"type:varchar(150);not null;uniqueIndex"`
+}
+
+// Country is a country as listed in ISO 3166-1. The table is filled from the standard's code list on start
+// and movies are linked to their countries of origin through the "moviecountries" join table.
+//
+// Fields:
+// - Code: The ISO 3166-1 alpha-2 code of the country, upper-cased, serving as the primary key.
+// - Name: The English short name of the country.
+type Country struct {
+	Code string `gorm:"primaryKey;type:char(2)"`
+	Name string `gorm:"type:varchar(100);not null"`
+}
+
+// Language is a language as listed in ISO 639-1. The table is filled from the standard's code list on start
+// and movies are linked to their spoken languages through the "movielanguages" join table.
+//
+// Fields:
+// - Code: The ISO 639-1 code of the language, lower-cased, serving as the primary key.
+// - Name: The English name of the language.
+type Language struct {
+	Code string `gorm:"primaryKey;type:char(2)"`
+	Name string `gorm:"type:varchar(100);not null"`
+}
+
+// Certification is the age rating a movie received in one country, such as "16+" in Russia or "PG-13" in the United States.
+//
+// Fields:
+// - MovieID: The ID of the rated movie. Part of the composite primary key.
+// - CountryCode: The ISO 3166-1 alpha-2 code of the country issuing the rating. Part of the composite primary key.
+// - Rating: The rating, stored as a varchar(10) and not nullable.
+type Certification struct {
+	MovieID     int    `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	CountryCode string `gorm:"primaryKey;type:char(2)"`
+	Rating      string `gorm:"type:varchar(10);not null"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// EditProposal is a change to a movie or an actor submitted by a user for an admin to approve or reject.
+// The proposed updates take the same shape as the MovieEdit and ActorEdit request bodies and are only applied on approval.
+//
+// Fields:
+// - ID: The unique identifier for the proposal, serving as the primary key in the database.
+// - EntityType: Either "movie" or "actor", stored as a varchar(10).
+// - EntityID: The ID of the movie or actor the change is proposed for.
+// - UserID: The ID of the user who submitted the proposal.
+// - Updates: The proposed update fields, as JSON.
+// - Status: One of "pending", "approved" or "rejected", stored as a varchar(10).
+// - Reason: Why the proposal was rejected, allowing for up to varchar(1000) characters. Empty otherwise.
+// - ReviewerID: The ID of the admin who approved or rejected the proposal. Null while pending.
+// - RevisionID: The ID of the revision the approved change was recorded as. Null unless the approval changed anything.
+// - CreatedAt: When the proposal was submitted.
+// - ReviewedAt: When the proposal was approved or rejected. Null while pending.
+// - User: The submitter, loaded in the moderation queue.
+// - Changes: The fields the proposal would change, with their current and proposed values. Only set on pending proposals in the moderation queue. Not stored.
+type EditProposal struct {
+	ID         int       `gorm:"primary_key"`
+	EntityType string    `gorm:"type:varchar(10);not null;index:idx_proposal_entity"`
+	EntityID   int       `gorm:"not null;index:idx_proposal_entity"`
+	UserID     int       `gorm:"not null;index"`
+	Updates    JSONValue `gorm:"type:text;not null" swaggertype:"object"`
+	Status     string    `gorm:"type:varchar(10);not null;default:pending;index"`
+	Reason     string    `gorm:"type:varchar(1000)"`
+	ReviewerID *int
+	RevisionID *int
+	CreatedAt  time.Time
+	ReviewedAt *time.Time
+	User       *User             `gorm:"foreignKey:UserID" json:"User,omitempty"`
+	Changes    []*RevisionChange `gorm:"-" json:"Changes,omitempty"`
+}
+
+// ProposalRejection is the request body of a proposal rejection.
+//
+// Fields:
+// - Reason: Why the proposal is rejected, shown to the submitter. Required.
+type ProposalRejection struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// QualityReport is the result of running the data-quality checks over the catalogue.
+//
+// Fields:
+// - GeneratedAt: When the checks were run.
+// - Issues: The number of checks that found at least one affected movie or actor.
+// - Results: The outcome of every check run, in name order, including the ones that found nothing.
+type QualityReport struct {
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Issues      int             `json:"issues"`
+	Results     []QualityResult `json:"results"`
+}
+
+// QualityResult is the outcome of one data-quality check.
+//
+// Fields:
+// - Check: The name of the check, e.g. "movie-without-actors".
+// - Severity: How serious the problem is, one of "error", "warning" or "info".
+// - Description: What the check looks for.
+// - EntityType: What the affected IDs refer to, "movie" or "actor".
+// - Count: The number of affected movies or actors.
+// - AffectedIDs: The IDs of the affected movies or actors, in ascending order.
+type QualityResult struct {
+	Check       string `json:"check"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	EntityType  string `json:"entityType"`
+	Count       int    `json:"count"`
+	AffectedIDs []int  `json:"affectedIds"`
+}
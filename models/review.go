@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Review is a user's own rating of a movie, with an optional text review.
+// A user reviews a movie at most once; the ratings of all users are averaged into Movie.CommunityRating.
+//
+// Fields:
+// - ID: The unique identifier for the review, serving as the primary key in the database.
+// - UserID: The ID of the user who wrote the review.
+// - MovieID: The ID of the reviewed movie.
+// - Rating: The user's rating, a whole number from 1 to 10.
+// - Text: The optional text of the review, allowing for up to varchar(5000) characters.
+// - CreatedAt: When the review was submitted.
+// - UpdatedAt: When the review was last edited.
+// - User: The author of the review, loaded when listing reviews.
+type Review struct {
+	ID        int    `gorm:"primary_key"`
+	UserID    int    `gorm:"not null;uniqueIndex:idx_review_user_movie"`
+	MovieID   int    `gorm:"not null;uniqueIndex:idx_review_user_movie;index"`
+	Rating    int    `gorm:"not null"`
+	Text      string `gorm:"type:varchar(5000)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	User      *User `gorm:"foreignKey:UserID" json:"User,omitempty"`
+}
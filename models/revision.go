@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Revision is an immutable record of one change to a movie or an actor: who made it, when, and which fields it changed.
+// A revision is written for every create, edit, delete, restore, revert and merge and is never modified afterwards.
+//
+// Fields:
+// - ID: The unique identifier for the revision, serving as the primary key in the database. It is the revision an admin reverts to.
+// - EntityType: Either "movie" or "actor", stored as a varchar(10).
+// - EntityID: The ID of the movie or actor that was changed.
+// - Action: What happened, one of "create", "edit", "delete", "restore", "revert" or "merge", stored as a varchar(10).
+// - UserID: The ID of the user who made the change.
+// - RevertedTo: The ID of the revision whose state was restored, set only on "revert" revisions.
+// - MergedWith: The ID of the other actor of a "merge": the duplicate on the surviving actor's revision and the survivor on the duplicate's.
+// - CreatedAt: When the change was made.
+// - Snapshot: The editable fields of the entity as they were after the change, as JSON. Empty for deletions, restores and merged duplicates. Not sent to clients.
+// - User: The user who made the change, loaded when listing the history.
+// - Changes: The fields the change touched, with their values before and after.
+type Revision struct {
+	ID         int    `gorm:"primary_key"`
+	EntityType string `gorm:"type:varchar(10);not null;index:idx_revision_entity"`
+	EntityID   int    `gorm:"not null;index:idx_revision_entity"`
+	Action     string `gorm:"type:varchar(10);not null"`
+	UserID     int    `gorm:"not null"`
+	RevertedTo *int   `json:",omitempty"`
+	MergedWith *int   `json:",omitempty"`
+	CreatedAt  time.Time
+	Snapshot   string            `gorm:"type:text" json:"-"`
+	User       *User             `gorm:"foreignKey:UserID" json:"User,omitempty"`
+	Changes    []*RevisionChange `gorm:"foreignKey:RevisionID"`
+}
+
+// RevisionChange is the change of a single field within a revision.
+// Cast lists, filmographies and other associations are recorded as a whole, so the change shows the full list before and after.
+//
+// Fields:
+// - ID: The unique identifier for the change, serving as the primary key in the database. Not sent to clients.
+// - RevisionID: The ID of the revision the change belongs to. Not sent to clients.
+// - Field: The name of the changed field, as accepted by the edit endpoints, stored as a varchar(50).
+// - Before: The value before the change, null when the entity was created.
+// - After: The value after the change.
+type RevisionChange struct {
+	ID         int       `gorm:"primary_key" json:"-"`
+	RevisionID int       `gorm:"index;not null" json:"-"`
+	Field      string    `gorm:"type:varchar(50);not null"`
+	Before     JSONValue `gorm:"type:text" swaggertype:"object"`
+	After      JSONValue `gorm:"type:text" swaggertype:"object"`
+}
+
+// JSONValue is a JSON document stored as text and sent to clients as the JSON value itself rather than as a string.
+// An empty JSONValue stands for null.
+type JSONValue string
+
+// MarshalJSON writes the stored document as is.
+func (value JSONValue) MarshalJSON() ([]byte, error) {
+	if value == "" {
+		return []byte("null"), nil
+	}
+	return []byte(value), nil
+}
+
+// UnmarshalJSON stores the document as is, keeping null as the empty value.
+func (value *JSONValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*value = ""
+		return nil
+	}
+	*value = JSONValue(data)
+	return nil
+}
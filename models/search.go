@@ -0,0 +1,41 @@
+package models
+
+// MovieResults is the response body of MovieList and MovieFind.
+// When no facets are requested only Movies is sent to the client, so existing consumers keep receiving a plain array.
+//
+// Fields:
+// - Movies: The movies matching the query, in the requested order.
+// - Facets: Optional aggregations computed over Movies. Nil when the client did not ask for facets.
+type MovieResults struct {
+	Movies []Movie      `json:"movies"`
+	Facets *MovieFacets `json:"facets,omitempty"`
+}
+
+// MovieFacets groups the facet aggregations that can be requested through the "facets" query parameter.
+// Every facet value can be sent back as a drill-down parameter to narrow the results.
+//
+// Fields:
+// - Decade: Movie counts per release decade, e.g. "1990". Drill down with "decade=1990".
+// - Rating: Movie counts per whole-point rating band, e.g. "8" for 8.0-8.9. Drill down with "rating=8".
+// - Actor: Movie counts per actor ID. Drill down with "actorId=42".
+// - Genre: Movie counts per genre name. Drill down with "genre=Drama".
+// - Tag: Movie counts per tag name. Drill down with "tag=heist".
+type MovieFacets struct {
+	Decade []FacetCount `json:"decade,omitempty"`
+	Rating []FacetCount `json:"rating,omitempty"`
+	Actor  []FacetCount `json:"actor,omitempty"`
+	Genre  []FacetCount `json:"genre,omitempty"`
+	Tag    []FacetCount `json:"tag,omitempty"`
+}
+
+// FacetCount is a single bucket of a facet aggregation.
+//
+// Fields:
+// - Value: The value to pass back as a drill-down parameter.
+// - Label: A human readable label for the bucket.
+// - Count: The number of movies in the bucket.
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,81 @@
+package models
+
+// Series represents a TV series in the database, made of seasons of episodes.
+//
+// Fields:
+// - ID: The unique identifier for the series, serving as the primary key in the database.
+// - Title: The title of the series, stored as a varchar(150) and marked as not nullable.
+// - Description: A description of the series, allowing for up to varchar(1000) characters. Optional.
+// - StartDate: The date the first episode aired, stored as a string like Movie.ReleaseDate.
+// - EndDate: The date the last episode aired. Empty while the series is still running.
+// - Rating: The series' rating, stored as a decimal with one digit after the decimal point, like Movie.Rating.
+// - Seasons: The seasons of the series, in order.
+type Series struct {
+	ID          int    `gorm:"primary_key"`
+	Title       string `gorm:"type:varchar(150);not null"`
+	Description string `gorm:"type:varchar(1000)"`
+	StartDate   string
+	EndDate     string
+	Rating      float64   `gorm:"type:decimal(2,1)"`
+	Seasons     []*Season `gorm:"foreignKey:SeriesID"`
+}
+
+// Season represents one season of a series.
+//
+// Fields:
+// - ID: The unique identifier for the season, serving as the primary key in the database.
+// - SeriesID: The ID of the series the season belongs to.
+// - Number: The position of the season in the series, starting at 1. Unique within a series.
+// - Title: An optional title for the season, stored as a varchar(150).
+// - Episodes: The episodes of the season, in order.
+type Season struct {
+	ID       int        `gorm:"primary_key"`
+	SeriesID int        `gorm:"not null;uniqueIndex:idx_season_number"`
+	Number   int        `gorm:"not null;uniqueIndex:idx_season_number"`
+	Title    string     `gorm:"type:varchar(150)"`
+	Episodes []*Episode `gorm:"foreignKey:SeasonID"`
+}
+
+// Episode represents one episode of a season.
+//
+// Fields:
+// - ID: The unique identifier for the episode, serving as the primary key in the database.
+// - SeasonID: The ID of the season the episode belongs to.
+// - Number: The position of the episode in the season, starting at 1. Unique within a season.
+// - Title: The title of the episode, stored as a varchar(150) and marked as not nullable.
+// - AirDate: The date the episode first aired, stored as a string.
+// - GuestStars: The actors appearing in the episode, linked through the "episodeactors" join table.
+type Episode struct {
+	ID         int    `gorm:"primary_key"`
+	SeasonID   int    `gorm:"not null;uniqueIndex:idx_episode_number"`
+	Number     int    `gorm:"not null;uniqueIndex:idx_episode_number"`
+	Title      string `gorm:"type:varchar(150);not null"`
+	AirDate    string
+	GuestStars []*Actor `gorm:"many2many:episodeactors;"`
+}
+
+// SeriesCredit lists the episodes of one series an actor appeared in. It is listed next to the actor's movies.
+//
+// Fields:
+// - SeriesID: The ID of the series.
+// - Title: The title of the series.
+// - Episodes: The episodes the actor appeared in, in airing order.
+type SeriesCredit struct {
+	SeriesID int             `json:"seriesId"`
+	Title    string          `json:"title"`
+	Episodes []EpisodeCredit `json:"episodes"`
+}
+
+// EpisodeCredit identifies one episode an actor appeared in.
+//
+// Fields:
+// - EpisodeID: The ID of the episode.
+// - Season: The number of the season the episode belongs to.
+// - Number: The number of the episode within its season.
+// - Title: The title of the episode.
+type EpisodeCredit struct {
+	EpisodeID int    `json:"episodeId"`
+	Season    int    `json:"season"`
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+}
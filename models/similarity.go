@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// MovieSimilarity is a precomputed "you might also like" recommendation.
+// Rows are produced by the similarity job and refreshed in the background after a movie's cast, genres or tags change; each movie keeps only its best matches.
+//
+// Fields:
+// - MovieID: The movie the recommendation is made for. Part of the composite primary key.
+// - SimilarMovieID: The recommended movie. Part of the composite primary key.
+// - Score: How similar the two movies are, from 0 to 1, combining shared cast, genre and tag overlap, release era proximity and the recommended movie's rating.
+// - SimilarMovie: The recommended movie itself, loaded when listing recommendations.
+type MovieSimilarity struct {
+	MovieID        int     `gorm:"primaryKey;autoIncrement:false"`
+	SimilarMovieID int     `gorm:"primaryKey;autoIncrement:false;index"`
+	Score          float64 `gorm:"not null"`
+	SimilarMovie   *Movie  `gorm:"foreignKey:SimilarMovieID"`
+}
+
+// SimilarityRefresh queues a movie whose recommendations are stale after an edit, until the similarity worker recomputes them.
+// Edits only queue the affected movies, so scoring them against the whole catalogue stays off the request path.
+//
+// Fields:
+// - MovieID: The movie to recompute. Serves as the primary key, so a movie is queued at most once.
+// - QueuedAt: When the movie was last queued.
+type SimilarityRefresh struct {
+	MovieID  int       `gorm:"primaryKey;autoIncrement:false"`
+	QueuedAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// Tag represents a free-form keyword attached to movies, such as "time travel" or "based on a true story".
+// Movies are linked to tags through the "movietags" join table.
+//
+// Fields:
+// - ID: The unique identifier for the tag, serving as the primary key in the database.
+// - Name: The tag itself, stored as a varchar(100). It is unique and cannot be null.
+type Tag struct {
+	ID   int    `gorm:"primary_key"`
+	Name string `gorm:"type:varchar(100);not null;uniqueIndex"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// MovieTranslation holds the title and description of a movie in one language.
+// The Title and Description stored on the movie itself are in the catalogue's own language; translations add the other ones.
+//
+// Fields:
+// - MovieID: The ID of the translated movie. Part of the primary key.
+// - Language: The BCP 47 language tag of the translation, lower-cased, such as "en" or "en-gb". Part of the primary key.
+// - Title: The translated title, stored as a varchar(150) and not nullable.
+// - Description: The translated description, up to varchar(1000). Optional.
+type MovieTranslation struct {
+	MovieID     int    `gorm:"primaryKey;autoIncrement:false"`
+	Language    string `gorm:"primaryKey;type:varchar(35)"`
+	Title       string `gorm:"type:varchar(150);not null"`
+	Description string `gorm:"type:varchar(1000)"`
+}
+
+// ActorTranslation holds the name of an actor as written in one language, usually a transliteration.
+//
+// Fields:
+// - ActorID: The ID of the actor. Part of the primary key.
+// - Language: The BCP 47 language tag of the translation, lower-cased. Part of the primary key.
+// - Name: The name in that language, stored as a varchar(255) and not nullable.
+type ActorTranslation struct {
+	ActorID  int    `gorm:"primaryKey;autoIncrement:false"`
+	Language string `gorm:"primaryKey;type:varchar(35)"`
+	Name     string `gorm:"type:varchar(255);not null"`
+}
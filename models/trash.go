@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// TrashItem is a movie or an actor in the trash, as listed to administrators.
+//
+// Fields:
+// - Type: Either "movie" or "actor".
+// - ID: The ID of the movie or actor, used to restore it.
+// - Name: The title of the movie or the name of the actor.
+// - DeletedAt: When the item was moved to the trash.
+// - PurgeAt: When the purge job removes the item for good, unless it is restored first.
+type TrashItem struct {
+	Type      string    `json:"type"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deletedAt"`
+	PurgeAt   time.Time `json:"purgeAt"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// User represents an account that can log in to the API.
+//
+// Fields:
+// - ID: The unique identifier for the user, serving as the primary key in the database. It is the userId carried by the JWT.
+// - Username: The login name, stored as a varchar(100). It is unique and cannot be null.
+// - PasswordHash: The bcrypt hash of the password. Never sent to clients.
+// - Role: The role granted by the JWT, either "admin" or "user".
+// - Email: An optional address notifications are mailed to when email delivery is enabled.
+type User struct {
+	ID           int    `gorm:"primary_key"`
+	Username     string `gorm:"type:varchar(100);not null;uniqueIndex"`
+	PasswordHash string `gorm:"type:varchar(100);not null" json:"-"`
+	Role         string `gorm:"type:varchar(20);not null"`
+	Email        string `gorm:"type:varchar(255)"`
+}
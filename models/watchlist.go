@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Watchlist is a named, ordered list of movies kept by a user.
+// Lists are private to their owner unless marked public.
+//
+// Fields:
+// - ID: The unique identifier for the list, serving as the primary key in the database.
+// - UserID: The ID of the user owning the list.
+// - Name: The name of the list, stored as a varchar(100) and marked as not nullable.
+// - Public: Whether other users can read the list.
+// - CreatedAt: When the list was created.
+// - Entries: The movies on the list, in the owner's order.
+type Watchlist struct {
+	ID        int    `gorm:"primary_key"`
+	UserID    int    `gorm:"not null;index"`
+	Name      string `gorm:"type:varchar(100);not null"`
+	Public    bool   `gorm:"not null;default:false"`
+	CreatedAt time.Time
+	Entries   []*WatchlistEntry `gorm:"foreignKey:WatchlistID"`
+}
+
+// WatchlistEntry places a movie on a watchlist.
+//
+// Fields:
+// - WatchlistID: The ID of the list. Part of the composite primary key.
+// - MovieID: The ID of the movie. Part of the composite primary key, so a movie appears on a list at most once.
+// - Position: The position of the movie on the list, starting at 1.
+// - AddedAt: When the movie was put on the list.
+// - Movie: The movie itself, with its full details.
+type WatchlistEntry struct {
+	WatchlistID int `gorm:"primaryKey;autoIncrement:false"`
+	MovieID     int `gorm:"primaryKey;autoIncrement:false"`
+	Position    int `gorm:"not null"`
+	AddedAt     time.Time
+	Movie       *Movie `gorm:"foreignKey:MovieID"`
+}
+
+// WatchedMovie records that a user has seen a movie.
+//
+// Fields:
+// - UserID: The ID of the user. Part of the composite primary key.
+// - MovieID: The ID of the movie. Part of the composite primary key.
+// - WatchedOn: The date the user watched the movie, formatted as "2006-01-02".
+// - Movie: The movie itself, with its full details.
+type WatchedMovie struct {
+	UserID    int `gorm:"primaryKey;autoIncrement:false"`
+	MovieID   int `gorm:"primaryKey;autoIncrement:false"`
+	WatchedOn string
+	Movie     *Movie `gorm:"foreignKey:MovieID"`
+}
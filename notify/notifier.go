@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Message is a notification to deliver to a user outside of the API.
+//
+// Fields:
+// - UserID: The ID of the recipient.
+// - Email: The recipient's email address. Empty if the user did not give one.
+// - Subject: A one line summary of the notification.
+// - Body: The full text of the notification.
+// - ActorID: The followed actor the notification is about.
+// - MovieID: The movie the actor was added to.
+type Message struct {
+	UserID  int    `json:"userId"`
+	Email   string `json:"email,omitempty"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	ActorID int    `json:"actorId"`
+	MovieID int    `json:"movieId"`
+}
+
+// Notifier delivers notifications through some external channel.
+// Notifications are always stored and readable through the API; a Notifier only adds a delivery on top of that.
+type Notifier interface {
+	// Notify delivers a single message. Implementations must be safe for concurrent use.
+	Notify(ctx context.Context, message Message) error
+}
+
+// FromEnv builds the Notifier selected by the NOTIFIER environment variable:
+//   - "webhook" POSTs each message as JSON to NOTIFIER_WEBHOOK_URL,
+//   - "smtp" mails each message through the server at SMTP_ADDR (host:port), from SMTP_FROM,
+//   - anything else, including an unset variable, disables delivery.
+//
+// Returns an error if the selected notifier is missing its settings.
+func FromEnv() (Notifier, error) {
+	switch strings.ToLower(os.Getenv("NOTIFIER")) {
+	case "webhook":
+		url := os.Getenv("NOTIFIER_WEBHOOK_URL")
+		if url == "" {
+			return nil, fmt.Errorf("NOTIFIER_WEBHOOK_URL is required by the webhook notifier")
+		}
+		return NewWebhook(url), nil
+	case "smtp":
+		addr, from := os.Getenv("SMTP_ADDR"), os.Getenv("SMTP_FROM")
+		if addr == "" || from == "" {
+			return nil, fmt.Errorf("SMTP_ADDR and SMTP_FROM are required by the smtp notifier")
+		}
+		return NewSMTP(addr, from), nil
+	default:
+		return Nop{}, nil
+	}
+}
+
+// Nop is the Notifier used when delivery is disabled. It drops every message.
+type Nop struct{}
+
+// Notify does nothing.
+func (Nop) Notify(context.Context, Message) error {
+	return nil
+}
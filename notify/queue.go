@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrQueueFull is returned by Queue.Notify when the backlog of undelivered messages is full. The message is dropped.
+var ErrQueueFull = errors.New("notification queue is full")
+
+// Queue delivers messages in the background through a fixed number of workers, so a burst of notifications
+// neither holds up the request that caused it nor opens an unbounded number of connections to the channel behind it.
+type Queue struct {
+	next     Notifier
+	messages chan Message
+	timeout  time.Duration
+}
+
+// NewQueue starts workers goroutines delivering through next, each delivery bounded by timeout.
+// At most size messages wait for a worker; Notify drops messages beyond that.
+func NewQueue(next Notifier, workers, size int, timeout time.Duration) *Queue {
+	queue := &Queue{next: next, messages: make(chan Message, size), timeout: timeout}
+	for i := 0; i < workers; i++ {
+		go queue.work()
+	}
+	return queue
+}
+
+// Notify queues the message for delivery and returns without waiting for it.
+func (q *Queue) Notify(ctx context.Context, message Message) error {
+	select {
+	case q.messages <- message:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return ErrQueueFull
+	}
+}
+
+// work delivers queued messages one at a time, for as long as the process runs.
+func (q *Queue) work() {
+	for message := range q.messages {
+		ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+		if err := q.next.Notify(ctx, message); err != nil {
+			log.Error().Err(err).Int("userID", message.UserID).Msg("Failed to deliver notification")
+		}
+		cancel()
+	}
+}
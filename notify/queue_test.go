@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingNotifier counts the deliveries in progress and blocks them until release is closed.
+type blockingNotifier struct {
+	started chan Message
+	release chan struct{}
+}
+
+func (n *blockingNotifier) Notify(ctx context.Context, message Message) error {
+	n.started <- message
+	<-n.release
+	return nil
+}
+
+func TestQueue(t *testing.T) {
+	next := &blockingNotifier{started: make(chan Message, 10), release: make(chan struct{})}
+	defer close(next.release)
+	queue := NewQueue(next, 2, 1, time.Second)
+
+	// Two messages are taken by the workers and one waits in the backlog; the fourth is dropped.
+	for i := 1; i <= 2; i++ {
+		if err := queue.Notify(context.Background(), Message{UserID: i}); err != nil {
+			t.Fatalf("Notify(%d) returned error: %v", i, err)
+		}
+		<-next.started
+	}
+	if err := queue.Notify(context.Background(), Message{UserID: 3}); err != nil {
+		t.Fatalf("Notify(3) returned error: %v", err)
+	}
+	if err := queue.Notify(context.Background(), Message{UserID: 4}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Notify(4) returned %v, want ErrQueueFull", err)
+	}
+
+	select {
+	case message := <-next.started:
+		t.Errorf("message %d delivered while both workers are busy", message.UserID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// headerValue keeps user supplied text from breaking out of a mail header line.
+var headerValue = strings.NewReplacer("\r", " ", "\n", " ")
+
+// SMTP delivers messages by email through an SMTP server without authentication, such as a local relay or test server.
+type SMTP struct {
+	Addr string
+	From string
+}
+
+// NewSMTP creates an SMTP notifier sending through the server at addr (host:port) from the given address.
+func NewSMTP(addr, from string) *SMTP {
+	return &SMTP{Addr: addr, From: from}
+}
+
+// Notify mails the message to the user's address. Users without an email address are skipped.
+func (mailer *SMTP) Notify(ctx context.Context, message Message) error {
+	if message.Email == "" {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var mail strings.Builder
+	fmt.Fprintf(&mail, "From: %s\r\n", mailer.From)
+	fmt.Fprintf(&mail, "To: %s\r\n", message.Email)
+	fmt.Fprintf(&mail, "Subject: %s\r\n", headerValue.Replace(message.Subject))
+	mail.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	mail.WriteString(message.Body)
+	mail.WriteString("\r\n")
+
+	return smtp.SendMail(mailer.Addr, nil, mailer.From, []string{message.Email}, []byte(mail.String()))
+}
@@ -0,0 +1,36 @@
+-- Widens the actor gender beyond the original 'M'/'F' codes and adds the biography fields.
+-- Existing rows keep their gender: 'M' becomes 'male' and 'F' becomes 'female'.
+-- The API stores an unstated gender and an unknown date of death as '', so both accept the empty string.
+ALTER TABLE vk.actors DROP CONSTRAINT IF EXISTS actors_gender_check;
+ALTER TABLE vk.actors ALTER COLUMN gender TYPE VARCHAR(20);
+UPDATE vk.actors
+SET gender = CASE gender
+        WHEN 'M' THEN 'male'
+        ELSE 'female'
+    END
+WHERE gender IN ('M', 'F');
+ALTER TABLE vk.actors
+ADD CONSTRAINT actors_gender_check CHECK (
+        gender IN ('', 'female', 'male', 'non-binary', 'other')
+    );
+ALTER TABLE vk.actors
+ADD COLUMN date_of_death TEXT NOT NULL DEFAULT '' CHECK (
+        date_of_death = ''
+        OR date_of_death ~ '^\d{4}-\d{2}-\d{2}$'
+    ),
+    ADD COLUMN place_of_birth VARCHAR(255),
+    ADD COLUMN biography TEXT,
+    ADD CONSTRAINT actors_lifespan_check CHECK (
+        CASE
+            WHEN date_of_death = ''
+            OR date_of_birth IS NULL THEN TRUE
+            ELSE date_of_death::date >= date_of_birth
+        END
+    );
+CREATE TABLE vk.actor_aliases (
+    id SERIAL PRIMARY KEY,
+    actor_id INTEGER NOT NULL REFERENCES vk.actors(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'alternate' CHECK (kind IN ('stage', 'birth', 'alternate'))
+);
+CREATE INDEX actor_aliases_actor_id_idx ON vk.actor_aliases(actor_id);
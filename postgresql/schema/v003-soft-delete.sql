@@ -0,0 +1,8 @@
+-- Deleted movies and actors are moved to the trash instead of being removed,
+-- so their links survive until they are restored or purged.
+ALTER TABLE vk.movies
+ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE vk.actors
+ADD COLUMN deleted_at TIMESTAMPTZ;
+CREATE INDEX movies_deleted_at_idx ON vk.movies(deleted_at);
+CREATE INDEX actors_deleted_at_idx ON vk.actors(deleted_at);
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) AuditListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AuditListView()
+}
+
+func (router *Router) AuditVerifyRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AuditVerifyView()
+}
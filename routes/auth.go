@@ -2,10 +2,14 @@ package routes
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/rs/zerolog/log"
 	"vk.com/m/auth"
+	"vk.com/m/middleware"
+	"vk.com/m/models"
+	"vk.com/m/services"
 )
 
 type LoginRequest struct {
@@ -13,13 +17,19 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+type RegisterRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
 type LoginResponse struct {
 	Token string `json:"token"`
 }
 
 // LoginHandler handles user login requests
 // @Summary User login
-// @Description handles login requests by checking username and password
+// @Description handles login requests by checking username and password against the user accounts; successful and failed logins are written to the audit trail
 // @Accept  json
 // @Produce  json
 // @Param   LoginRequest  body      LoginRequest  true  "Login Credentials"
@@ -34,20 +44,66 @@ func (router *Router) LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var role string
-	if req.Username == "admin" && req.Password == "password" {
-		role = "admin"
-		log.Info().Str("username", req.Username).Str("role", role).Msg("User logged in successfully")
-	} else if req.Username == "user" && req.Password == "password" {
-		role = "user"
-		log.Info().Str("username", req.Username).Str("role", role).Msg("User logged in successfully")
-	} else {
+	user, err := router.PG.Authenticate(req.Username, req.Password)
+	if errors.Is(err, services.ErrInvalidCredentials) {
 		log.Warn().Str("username", req.Username).Msg("Unauthorized login attempt")
+		middleware.Audit(w, r, &models.AuditEntry{Action: "login-failed", Username: req.Username, Status: http.StatusUnauthorized})
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
+	} else if err != nil {
+		log.Error().Err(err).Msg("Failed to look up user")
+		http.Error(w, "Failed to look up user", http.StatusInternalServerError)
+		return
+	}
+	log.Info().Str("username", req.Username).Str("role", user.Role).Msg("User logged in successfully")
+	middleware.Audit(w, r, &models.AuditEntry{Action: "login", UserID: &user.ID, Username: user.Username, Role: user.Role, Status: http.StatusOK})
+
+	token, err := auth.GenerateToken(user.ID, user.Role)
+	if err != nil {
+		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
+		return
+	}
+
+	resp := LoginResponse{
+		Token: token,
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// RegisterHandler handles account creation requests
+// @Summary User registration
+// @Description creates a regular 'user' account with the given username, password and optional email address, and logs it in
+// @Accept  json
+// @Produce  json
+// @Param   RegisterRequest  body      RegisterRequest  true  "Account Details"
+// @Success 200 {object} LoginResponse "Returns login token"
+// @Failure 400 "Invalid request, missing username or password, or invalid email"
+// @Failure 409 "Username already taken"
+// @Router /v1/register [post]
+func (router *Router) RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	var req RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid registration request payload")
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	user, err := router.PG.CreateUser(req.Username, req.Password, req.Email, "user")
+	if errors.Is(err, services.ErrUsernameTaken) {
+		log.Warn().Str("username", req.Username).Msg("Username already taken")
+		http.Error(w, "Username already taken", http.StatusConflict)
+		return
+	} else if errors.Is(err, services.ErrMissingCredentials) || errors.Is(err, services.ErrInvalidEmail) {
+		log.Warn().Err(err).Msg("Invalid registration details")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Error().Err(err).Msg("Failed to create user")
+		http.Error(w, "Failed to create user", http.StatusInternalServerError)
+		return
 	}
 
-	token, err := auth.GenerateToken(1, role)
+	token, err := auth.GenerateToken(user.ID, user.Role)
 	if err != nil {
 		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
 		return
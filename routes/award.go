@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) AwardAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AwardAddView()
+}
+
+func (router *Router) AwardEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AwardEditView()
+}
+
+func (router *Router) AwardListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AwardListView()
+}
+
+func (router *Router) AwardDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AwardDeleteView()
+}
+
+func (router *Router) CeremonyAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CeremonyAddView()
+}
+
+func (router *Router) CeremonyDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CeremonyDeleteView()
+}
+
+func (router *Router) CeremonyEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CeremonyEditView()
+}
+
+func (router *Router) AwardCategoryAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AwardCategoryAddView()
+}
+
+func (router *Router) AwardCategoryEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AwardCategoryEditView()
+}
+
+func (router *Router) AwardCategoryDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.AwardCategoryDeleteView()
+}
+
+func (router *Router) NominationAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.NominationAddView()
+}
+
+func (router *Router) NominationEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.NominationEditView()
+}
+
+func (router *Router) NominationDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.NominationDeleteView()
+}
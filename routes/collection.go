@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) CollectionAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CollectionAddView()
+}
+
+func (router *Router) CollectionEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CollectionEditView()
+}
+
+func (router *Router) CollectionGetRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CollectionGetView()
+}
+
+func (router *Router) CollectionListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CollectionListView()
+}
+
+func (router *Router) CollectionDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CollectionDeleteView()
+}
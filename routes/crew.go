@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) CrewAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CrewAddView()
+}
+
+func (router *Router) CrewDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.CrewDeleteView()
+}
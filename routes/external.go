@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) MovieExternalIDSetRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieExternalIDSetView()
+}
+
+func (router *Router) ActorExternalIDSetRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorExternalIDSetView()
+}
+
+func (router *Router) MovieExternalIDDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieExternalIDDeleteView()
+}
+
+func (router *Router) ActorExternalIDDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorExternalIDDeleteView()
+}
+
+func (router *Router) MovieLookupRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieLookupView()
+}
+
+func (router *Router) ActorLookupRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorLookupView()
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) ActorFollowRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorFollowView()
+}
+
+func (router *Router) ActorUnfollowRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorUnfollowView()
+}
+
+func (router *Router) ActorFollowListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorFollowListView()
+}
+
+func (router *Router) NotificationListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.NotificationListView()
+}
+
+func (router *Router) NotificationReadRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.NotificationReadView()
+}
+
+func (router *Router) NotificationReadAllRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.NotificationReadAllView()
+}
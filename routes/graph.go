@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) ActorCollaboratorsRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorCollaboratorsView()
+}
+
+func (router *Router) ActorPathRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorPathView()
+}
+
+func (router *Router) GraphExportRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.GraphExportView()
+}
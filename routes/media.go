@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) MoviePosterUploadRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MoviePosterUploadView()
+}
+
+func (router *Router) MoviePosterDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MoviePosterDeleteView()
+}
+
+func (router *Router) ActorPhotoUploadRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorPhotoUploadView()
+}
+
+func (router *Router) ActorPhotoDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorPhotoDeleteView()
+}
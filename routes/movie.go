@@ -26,7 +26,17 @@ func (router *Router) MovieFindRoute(w http.ResponseWriter, r *http.Request) {
 	view.MovieFindView()
 }
 
+func (router *Router) MovieGetRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieGetView()
+}
+
 func (router *Router) MovieDeleteRoute(w http.ResponseWriter, r *http.Request) {
 	view := views.View{W: w, R: r, PG: router.PG}
 	view.MovieDeleteView()
 }
+
+func (router *Router) MovieSimilarRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieSimilarView()
+}
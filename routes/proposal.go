@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) MovieProposalRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieProposalView()
+}
+
+func (router *Router) ActorProposalRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorProposalView()
+}
+
+func (router *Router) ProposalListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ProposalListView()
+}
+
+func (router *Router) ProposalMineRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ProposalMineView()
+}
+
+func (router *Router) ProposalApproveRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ProposalApproveView()
+}
+
+func (router *Router) ProposalRejectRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ProposalRejectView()
+}
@@ -3,12 +3,16 @@ package routes
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"vk.com/m/services"
 )
 
+// similarityInterval is how often the API refreshes the recommendations of the movies queued by edits.
+const similarityInterval = 30 * time.Second
+
 type Router struct {
 	PG *services.Postgresql
 }
@@ -19,6 +23,8 @@ func Routes(addr *string) {
 		log.Fatal().Err(err).Msg("Failed to initialize PostgreSQL")
 	}
 
+	postgres.StartSimilarityWorker(similarityInterval)
+
 	router := Router{PG: postgres}
 
 	router.V1Routes()
@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) SeriesAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.SeriesAddView()
+}
+
+func (router *Router) SeriesEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.SeriesEditView()
+}
+
+func (router *Router) SeriesListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.SeriesListView()
+}
+
+func (router *Router) SeriesDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.SeriesDeleteView()
+}
+
+func (router *Router) SeasonAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.SeasonAddView()
+}
+
+func (router *Router) SeasonEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.SeasonEditView()
+}
+
+func (router *Router) SeasonDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.SeasonDeleteView()
+}
+
+func (router *Router) EpisodeAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.EpisodeAddView()
+}
+
+func (router *Router) EpisodeEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.EpisodeEditView()
+}
+
+func (router *Router) EpisodeDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.EpisodeDeleteView()
+}
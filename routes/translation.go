@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) MovieTranslationSetRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieTranslationSetView()
+}
+
+func (router *Router) MovieTranslationListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieTranslationListView()
+}
+
+func (router *Router) MovieTranslationDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.MovieTranslationDeleteView()
+}
+
+func (router *Router) ActorTranslationSetRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorTranslationSetView()
+}
+
+func (router *Router) ActorTranslationListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorTranslationListView()
+}
+
+func (router *Router) ActorTranslationDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.ActorTranslationDeleteView()
+}
@@ -7,23 +7,150 @@ import (
 
 	_ "vk.com/m/docs"
 
+	"vk.com/m/blobstore"
 	"vk.com/m/middleware"
 )
 
 func (router *Router) V1Routes() {
 
+	middleware.SetAuditor(router.PG)
+
 	http.HandleFunc("/swagger/", httpSwagger.WrapHandler)
 
+	// Blobs of the local store are served by the API itself, under the path of BLOB_BASE_URL.
+	if local, ok := router.PG.Blobs.(*blobstore.Local); ok {
+		http.Handle(local.Prefix(), blobstore.Handler(local, local.Prefix()))
+	}
+
 	http.HandleFunc("/v1/login", router.LoginHandler)
+	http.HandleFunc("/v1/register", router.RegisterHandler)
 
 	http.Handle("/v1/actor-add", middleware.AuthMiddleware(http.HandlerFunc(router.ActorAddRoute), "admin"))
 	http.Handle("/v1/actor-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorEditRoute), "admin"))
 	http.Handle("/v1/actor-list", middleware.AuthMiddleware(http.HandlerFunc(router.ActorListRoute), "admin", "user"))
 	http.Handle("/v1/actor-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorDeleteRoute), "admin"))
+	http.Handle("/v1/actor-restore/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorRestoreRoute), "admin"))
+	http.Handle("/v1/actor-history/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorHistoryRoute), "admin"))
+	http.Handle("/v1/actor-revert/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorRevertRoute), "admin"))
+	http.Handle("/v1/actor-duplicates", middleware.AuthMiddleware(http.HandlerFunc(router.ActorDuplicatesRoute), "admin"))
+	http.Handle("/v1/actor-merge/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorMergeRoute), "admin"))
+	http.Handle("/v1/actor-collaborators/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorCollaboratorsRoute), "admin", "user"))
+	http.Handle("/v1/actor-path", middleware.AuthMiddleware(http.HandlerFunc(router.ActorPathRoute), "admin", "user"))
+
+	http.Handle("/v1/actor-photo/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorPhotoUploadRoute), "admin"))
+	http.Handle("/v1/actor-photo-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorPhotoDeleteRoute), "admin"))
+	http.Handle("/v1/actor-follow/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorFollowRoute), "admin", "user"))
+	http.Handle("/v1/actor-unfollow/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorUnfollowRoute), "admin", "user"))
+	http.Handle("/v1/actor-followed", middleware.AuthMiddleware(http.HandlerFunc(router.ActorFollowListRoute), "admin", "user"))
+	http.Handle("/v1/notification-list", middleware.AuthMiddleware(http.HandlerFunc(router.NotificationListRoute), "admin", "user"))
+	http.Handle("/v1/notification-read/", middleware.AuthMiddleware(http.HandlerFunc(router.NotificationReadRoute), "admin", "user"))
+	http.Handle("/v1/notification-read-all", middleware.AuthMiddleware(http.HandlerFunc(router.NotificationReadAllRoute), "admin", "user"))
 
 	http.Handle("/v1/movie-add", middleware.AuthMiddleware(http.HandlerFunc(router.MovieAddRoute), "admin"))
 	http.Handle("/v1/movie-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieEditRoute), "admin"))
 	http.Handle("/v1/movie-list", middleware.AuthMiddleware(http.HandlerFunc(router.MovieListRoute), "admin", "user"))
 	http.Handle("/v1/movie-find", middleware.AuthMiddleware(http.HandlerFunc(router.MovieFindRoute), "admin", "user"))
+	http.Handle("/v1/movie/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieGetRoute), "admin", "user"))
 	http.Handle("/v1/movie-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieDeleteRoute), "admin"))
+	http.Handle("/v1/movie-restore/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieRestoreRoute), "admin"))
+	http.Handle("/v1/trash-list", middleware.AuthMiddleware(http.HandlerFunc(router.TrashListRoute), "admin"))
+	http.Handle("/v1/audit-list", middleware.AuthMiddleware(http.HandlerFunc(router.AuditListRoute), "admin"))
+	http.Handle("/v1/audit-verify", middleware.AuthMiddleware(http.HandlerFunc(router.AuditVerifyRoute), "admin"))
+	http.Handle("/v1/quality-report", middleware.AuthMiddleware(http.HandlerFunc(router.QualityReportRoute), "admin"))
+	http.Handle("/v1/movie-history/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieHistoryRoute), "admin"))
+	http.Handle("/v1/movie-revert/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieRevertRoute), "admin"))
+	http.Handle("/v1/movie-poster/", middleware.AuthMiddleware(http.HandlerFunc(router.MoviePosterUploadRoute), "admin"))
+	http.Handle("/v1/movie-poster-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.MoviePosterDeleteRoute), "admin"))
+	http.Handle("/v1/movie-similar/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieSimilarRoute), "admin", "user"))
+
+	http.Handle("/v1/movie-propose/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieProposalRoute), "admin", "user"))
+	http.Handle("/v1/actor-propose/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorProposalRoute), "admin", "user"))
+	http.Handle("/v1/proposal-mine", middleware.AuthMiddleware(http.HandlerFunc(router.ProposalMineRoute), "admin", "user"))
+	http.Handle("/v1/proposal-list", middleware.AuthMiddleware(http.HandlerFunc(router.ProposalListRoute), "admin"))
+	http.Handle("/v1/proposal-approve/", middleware.AuthMiddleware(http.HandlerFunc(router.ProposalApproveRoute), "admin"))
+	http.Handle("/v1/proposal-reject/", middleware.AuthMiddleware(http.HandlerFunc(router.ProposalRejectRoute), "admin"))
+
+	http.Handle("/v1/review-add", middleware.AuthMiddleware(http.HandlerFunc(router.ReviewAddRoute), "admin", "user"))
+	http.Handle("/v1/review-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.ReviewEditRoute), "admin", "user"))
+	http.Handle("/v1/review-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.ReviewDeleteRoute), "admin", "user"))
+	http.Handle("/v1/movie-reviews/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieReviewsRoute), "admin", "user"))
+
+	http.Handle("/v1/watchlist-add", middleware.AuthMiddleware(http.HandlerFunc(router.WatchlistAddRoute), "admin", "user"))
+	http.Handle("/v1/watchlist-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.WatchlistEditRoute), "admin", "user"))
+	http.Handle("/v1/watchlist/", middleware.AuthMiddleware(http.HandlerFunc(router.WatchlistGetRoute), "admin", "user"))
+	http.Handle("/v1/watchlist-list", middleware.AuthMiddleware(http.HandlerFunc(router.WatchlistListRoute), "admin", "user"))
+	http.Handle("/v1/watchlist-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.WatchlistDeleteRoute), "admin", "user"))
+	http.Handle("/v1/watched-add", middleware.AuthMiddleware(http.HandlerFunc(router.WatchedAddRoute), "admin", "user"))
+	http.Handle("/v1/watched-list", middleware.AuthMiddleware(http.HandlerFunc(router.WatchedListRoute), "admin", "user"))
+	http.Handle("/v1/watched-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.WatchedDeleteRoute), "admin", "user"))
+
+	http.Handle("/v1/genre-add", middleware.AuthMiddleware(http.HandlerFunc(router.GenreAddRoute), "admin"))
+	http.Handle("/v1/genre-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.GenreEditRoute), "admin"))
+	http.Handle("/v1/genre-list", middleware.AuthMiddleware(http.HandlerFunc(router.GenreListRoute), "admin", "user"))
+	http.Handle("/v1/genre-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.GenreDeleteRoute), "admin"))
+
+	http.Handle("/v1/tag-add", middleware.AuthMiddleware(http.HandlerFunc(router.TagAddRoute), "admin"))
+	http.Handle("/v1/tag-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.TagEditRoute), "admin"))
+	http.Handle("/v1/tag-list", middleware.AuthMiddleware(http.HandlerFunc(router.TagListRoute), "admin", "user"))
+	http.Handle("/v1/tag-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.TagDeleteRoute), "admin"))
+
+	http.Handle("/v1/crew-add", middleware.AuthMiddleware(http.HandlerFunc(router.CrewAddRoute), "admin"))
+	http.Handle("/v1/crew-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.CrewDeleteRoute), "admin"))
+
+	http.Handle("/v1/movie-relation-add", middleware.AuthMiddleware(http.HandlerFunc(router.MovieRelationAddRoute), "admin"))
+	http.Handle("/v1/movie-relation-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieRelationDeleteRoute), "admin"))
+
+	http.Handle("/v1/collection-add", middleware.AuthMiddleware(http.HandlerFunc(router.CollectionAddRoute), "admin"))
+	http.Handle("/v1/collection-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.CollectionEditRoute), "admin"))
+	http.Handle("/v1/collection/", middleware.AuthMiddleware(http.HandlerFunc(router.CollectionGetRoute), "admin", "user"))
+	http.Handle("/v1/collection-list", middleware.AuthMiddleware(http.HandlerFunc(router.CollectionListRoute), "admin", "user"))
+	http.Handle("/v1/collection-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.CollectionDeleteRoute), "admin"))
+
+	http.Handle("/v1/studio-add", middleware.AuthMiddleware(http.HandlerFunc(router.StudioAddRoute), "admin"))
+	http.Handle("/v1/studio-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.StudioEditRoute), "admin"))
+	http.Handle("/v1/studio-list", middleware.AuthMiddleware(http.HandlerFunc(router.StudioListRoute), "admin", "user"))
+	http.Handle("/v1/studio-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.StudioDeleteRoute), "admin"))
+	http.Handle("/v1/country-list", middleware.AuthMiddleware(http.HandlerFunc(router.CountryListRoute), "admin", "user"))
+	http.Handle("/v1/language-list", middleware.AuthMiddleware(http.HandlerFunc(router.LanguageListRoute), "admin", "user"))
+
+	http.Handle("/v1/award-add", middleware.AuthMiddleware(http.HandlerFunc(router.AwardAddRoute), "admin"))
+	http.Handle("/v1/award-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.AwardEditRoute), "admin"))
+	http.Handle("/v1/award-list", middleware.AuthMiddleware(http.HandlerFunc(router.AwardListRoute), "admin", "user"))
+	http.Handle("/v1/award-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.AwardDeleteRoute), "admin"))
+	http.Handle("/v1/ceremony-add", middleware.AuthMiddleware(http.HandlerFunc(router.CeremonyAddRoute), "admin"))
+	http.Handle("/v1/ceremony-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.CeremonyEditRoute), "admin"))
+	http.Handle("/v1/ceremony-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.CeremonyDeleteRoute), "admin"))
+	http.Handle("/v1/award-category-add", middleware.AuthMiddleware(http.HandlerFunc(router.AwardCategoryAddRoute), "admin"))
+	http.Handle("/v1/award-category-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.AwardCategoryEditRoute), "admin"))
+	http.Handle("/v1/award-category-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.AwardCategoryDeleteRoute), "admin"))
+	http.Handle("/v1/nomination-add", middleware.AuthMiddleware(http.HandlerFunc(router.NominationAddRoute), "admin"))
+	http.Handle("/v1/nomination-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.NominationEditRoute), "admin"))
+	http.Handle("/v1/nomination-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.NominationDeleteRoute), "admin"))
+
+	http.Handle("/v1/movie-external-id/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieExternalIDSetRoute), "admin"))
+	http.Handle("/v1/movie-external-id-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieExternalIDDeleteRoute), "admin"))
+	http.Handle("/v1/movie-lookup", middleware.AuthMiddleware(http.HandlerFunc(router.MovieLookupRoute), "admin", "user"))
+	http.Handle("/v1/actor-external-id/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorExternalIDSetRoute), "admin"))
+	http.Handle("/v1/actor-external-id-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorExternalIDDeleteRoute), "admin"))
+	http.Handle("/v1/actor-lookup", middleware.AuthMiddleware(http.HandlerFunc(router.ActorLookupRoute), "admin", "user"))
+
+	http.Handle("/v1/movie-translation/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieTranslationSetRoute), "admin"))
+	http.Handle("/v1/movie-translations/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieTranslationListRoute), "admin", "user"))
+	http.Handle("/v1/movie-translation-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.MovieTranslationDeleteRoute), "admin"))
+	http.Handle("/v1/actor-translation/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorTranslationSetRoute), "admin"))
+	http.Handle("/v1/actor-translations/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorTranslationListRoute), "admin", "user"))
+	http.Handle("/v1/actor-translation-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.ActorTranslationDeleteRoute), "admin"))
+
+	http.Handle("/v1/series-add", middleware.AuthMiddleware(http.HandlerFunc(router.SeriesAddRoute), "admin"))
+	http.Handle("/v1/series-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.SeriesEditRoute), "admin"))
+	http.Handle("/v1/series-list", middleware.AuthMiddleware(http.HandlerFunc(router.SeriesListRoute), "admin", "user"))
+	http.Handle("/v1/series-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.SeriesDeleteRoute), "admin"))
+	http.Handle("/v1/season-add", middleware.AuthMiddleware(http.HandlerFunc(router.SeasonAddRoute), "admin"))
+	http.Handle("/v1/season-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.SeasonEditRoute), "admin"))
+	http.Handle("/v1/season-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.SeasonDeleteRoute), "admin"))
+	http.Handle("/v1/episode-add", middleware.AuthMiddleware(http.HandlerFunc(router.EpisodeAddRoute), "admin"))
+	http.Handle("/v1/episode-edit/", middleware.AuthMiddleware(http.HandlerFunc(router.EpisodeEditRoute), "admin"))
+	http.Handle("/v1/episode-delete/", middleware.AuthMiddleware(http.HandlerFunc(router.EpisodeDeleteRoute), "admin"))
+
+	http.Handle("/v1/graph-export", middleware.AuthMiddleware(http.HandlerFunc(router.GraphExportRoute), "admin", "user"))
 }
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"net/http"
+
+	"vk.com/m/views"
+)
+
+func (router *Router) WatchlistAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.WatchlistAddView()
+}
+
+func (router *Router) WatchlistEditRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.WatchlistEditView()
+}
+
+func (router *Router) WatchlistGetRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.WatchlistGetView()
+}
+
+func (router *Router) WatchlistListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.WatchlistListView()
+}
+
+func (router *Router) WatchlistDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.WatchlistDeleteView()
+}
+
+func (router *Router) WatchedAddRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.WatchedAddView()
+}
+
+func (router *Router) WatchedListRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.WatchedListView()
+}
+
+func (router *Router) WatchedDeleteRoute(w http.ResponseWriter, r *http.Request) {
+	view := views.View{W: w, R: r, PG: router.PG}
+	view.WatchedDeleteView()
+}
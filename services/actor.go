@@ -3,11 +3,13 @@ package services
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 	"vk.com/m/models"
 	"vk.com/m/utils"
 )
@@ -17,16 +19,17 @@ import (
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
 // @Summary Adds a new actor
-// @Description Adds a new actor with the given details. Requires 'admin' role.
+// @Description Adds a new actor with the given details. Gender is one of female, male, non-binary or other, and Aliases lists the stage, birth or alternate names the actor is also found by. ExternalIDs record the actor's identifiers in IMDb, Kinopoisk or Wikidata; adding an actor under an identifier that is already recorded is refused, so ingestion jobs can look people up by it instead of duplicating them. Requires 'admin' role.
 // @Tags actor
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer [JWT token]"
 // @Param actor body models.Actor true "Actor to add"
 // @Success 200 {object} models.Actor "Successfully added the actor"
-// @Failure 400 "Invalid request body"
+// @Failure 400 "Invalid request body, gender, date of birth or death, alias or external ID"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "One of the external IDs is already recorded"
 // @Failure 500 "Error creating actor"
 // @Router /v1/actor-add [post]
 func (PG *Postgresql) ActorAdd(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
@@ -41,12 +44,52 @@ func (PG *Postgresql) ActorAdd(w http.ResponseWriter, r *http.Request) (*models.
 		return nil, err
 	}
 
-	formattedDate := utils.FormatTime(data.DateOfBirth)
-	data.DateOfBirth = formattedDate
+	dateOfBirth, err := parseDateOfBirth(data.DateOfBirth)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid date of birth")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.DateOfBirth = dateOfBirth
+
+	gender, ok := normalizeGender(data.Gender)
+	if !ok {
+		log.Error().Str("gender", data.Gender).Msg("Invalid gender")
+		http.Error(w, "Invalid gender, expected female, male, non-binary or other", http.StatusBadRequest)
+		return nil, errors.New("invalid gender")
+	}
+	data.Gender = gender
+	if data.DateOfDeath != "" {
+		data.DateOfDeath = utils.FormatTime(data.DateOfDeath)
+	}
+	if err := validateLifespan(data.DateOfBirth, data.DateOfDeath); err != nil {
+		log.Error().Err(err).Msg("Invalid date of death")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	if err := validateAliases(data.Aliases); err != nil {
+		log.Error().Err(err).Msg("Invalid alias")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
 
-	if err := PG.DB.Create(&data).Error; err != nil {
+	if err := validExternalIDs(data.ExternalIDs, true); err != nil {
+		log.Error().Err(err).Msg("Invalid external ID")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		// Crew credits are added through CrewAdd, which validates the job.
+		if err := tx.Omit("CrewCredits").Create(&data).Error; err != nil {
+			return err
+		}
+		_, err := recordActorRevision(tx, r, data.ID, "create", nil)
+		return err
+	})
+	if err != nil {
 		log.Error().Err(err).Msg("Error creating actor")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), associationStatus(err))
 		return nil, err
 	}
 
@@ -60,7 +103,7 @@ func (PG *Postgresql) ActorAdd(w http.ResponseWriter, r *http.Request) (*models.
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
 // @Summary Edits an existing actor
-// @Description Edits an actor with the specified ID based on the given update fields. Requires 'admin' role.
+// @Description Edits an actor with the specified ID based on the given update fields: name, gender (female, male, non-binary or other), dateOfBirth and dateOfDeath (null to clear), placeOfBirth, biography and aliases, which replaces the actor's aliases with the given names or {name, kind} objects. Movies may be given as IDs or as objects {id, character, billingOrder, creditType} to set the actor's credit in them; creditType is one of lead, supporting, cameo or voice. Requires 'admin' role.
 // @Tags actor
 // @Accept json
 // @Produce json
@@ -68,7 +111,7 @@ func (PG *Postgresql) ActorAdd(w http.ResponseWriter, r *http.Request) (*models.
 // @Param id path int true "Actor ID"
 // @Param updates body map[string]interface{} true "Fields to update"
 // @Success 200 {object} models.Actor "Successfully updated the actor"
-// @Failure 400 "Invalid request body or actor ID"
+// @Failure 400 "Invalid request body, actor ID, gender, date of death, alias, credit or unknown movie IDs"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
 // @Failure 404 "Actor not found"
@@ -77,8 +120,6 @@ func (PG *Postgresql) ActorAdd(w http.ResponseWriter, r *http.Request) (*models.
 func (PG *Postgresql) ActorEdit(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
 	log.Info().Msg("ActorEdit called")
 
-	var data models.Actor
-
 	pathParts := strings.Split(r.URL.Path, "/")
 	if len(pathParts) < 4 {
 		log.Error().Msg("Invalid URL format")
@@ -92,13 +133,7 @@ func (PG *Postgresql) ActorEdit(w http.ResponseWriter, r *http.Request) (*models
 		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
 		return nil, err
 	}
-
-	log.Debug().Int("actorID", actorID).Msg("Fetching actor from database")
-	if err := PG.DB.Preload("Movies").First(&data, "id = ?", actorID).Error; err != nil {
-		log.Error().Err(err).Msg("Actor not found")
-		http.Error(w, "Actor not found", http.StatusNotFound)
-		return nil, err
-	}
+	actorID = PG.resolveActorID(actorID)
 
 	var updates map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
@@ -107,71 +142,217 @@ func (PG *Postgresql) ActorEdit(w http.ResponseWriter, r *http.Request) (*models
 		return nil, err
 	}
 
-	log.Debug().Interface("updates", updates).Msg("Applying updates to actor")
-	if err := PG.DB.First(&data, "id = ?", actorID).Error; err != nil {
-		log.Error().Err(err).Msg("Actor not found for updating")
-		http.Error(w, "Actor not found", http.StatusNotFound)
+	data, _, err := PG.editActor(w, r, actorID, updates, &models.Revision{Action: "edit"}, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	for field, value := range updates {
-		switch field {
-		case "name":
-			if name, ok := value.(string); ok {
-				data.Name = name
-			}
-		case "gender":
-			if gender, ok := value.(string); ok && (gender == "M" || gender == "F") {
-				data.Gender = gender
-			}
-		case "dateOfBirth":
-			if dobStr, ok := value.(string); ok {
-				formattedDOB := utils.FormatTime(dobStr)
-				data.DateOfBirth = formattedDOB
-			}
+	log.Info().Msg("Actor updated successfully")
+	return data, nil
+}
+
+// actorEdit holds the ActorEdit update fields, parsed and validated before anything is written.
+// aliases and cast are only applied when aliasesChanged and castChanged are set.
+type actorEdit struct {
+	updates        map[string]interface{}
+	gender         string
+	dateOfBirth    string
+	aliases        []*models.ActorAlias
+	aliasesChanged bool
+	cast           []castEntry
+	castChanged    bool
+}
+
+// parseActorEdit validates the ActorEdit update fields. Every error it returns is the client's mistake.
+func parseActorEdit(updates map[string]interface{}) (*actorEdit, error) {
+	edit := &actorEdit{updates: updates}
+
+	if genderStr, ok := updates["gender"].(string); ok {
+		gender, ok := normalizeGender(genderStr)
+		if !ok {
+			return nil, errors.New("invalid gender, expected female, male, non-binary or other")
 		}
+		edit.gender = gender
 	}
 
-	if movieIDsInterface, ok := updates["movies"].([]interface{}); ok {
-		var moviesToAdd []models.Movie
-		var currentMovieIDs []int
+	if dobStr, ok := updates["dateOfBirth"].(string); ok {
+		dateOfBirth, err := parseDateOfBirth(dobStr)
+		if err != nil {
+			return nil, err
+		}
+		edit.dateOfBirth = dateOfBirth
+	}
 
-		for _, m := range data.Movies {
-			currentMovieIDs = append(currentMovieIDs, m.ID)
+	var aliasesInterface []interface{}
+	aliasesInterface, edit.aliasesChanged = updates["aliases"].([]interface{})
+	aliases, err := parseAliases(aliasesInterface)
+	if err != nil {
+		return nil, err
+	}
+	edit.aliases = aliases
+
+	if movieIDsInterface, ok := updates["movies"].([]interface{}); ok {
+		entries, err := parseCastEntries(movieIDsInterface)
+		if err != nil {
+			return nil, err
 		}
+		edit.cast = append([]castEntry{}, entries...)
+		edit.castChanged = true
+	}
+
+	return edit, nil
+}
+
+// editActor applies the ActorEdit update fields to the actor and returns it with its filmography and aliases,
+// and the revision recorded for the edit, nil when it changed nothing.
+// The fields and the revision are written in a single transaction, so a failed edit changes nothing and every edit is in the history.
+// It writes the error response itself; revert and proposal approval reuse it with their own revision action,
+// and approval with a hook claiming the proposal in the same transaction. hook may be nil.
+func (PG *Postgresql) editActor(w http.ResponseWriter, r *http.Request, actorID int, updates map[string]interface{}, revision *models.Revision, hook editHook) (*models.Actor, *models.Revision, error) {
+	edit, err := parseActorEdit(updates)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid actor update")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, nil, err
+	}
 
-		for _, idInterface := range movieIDsInterface {
-			idInt, err := utils.InterfaceToInt(idInterface)
-			if err == nil && !utils.Contains(currentMovieIDs, idInt) {
-				moviesToAdd = append(moviesToAdd, models.Movie{ID: idInt})
+	var data models.Actor
+	var changedMovieIDs, addedMovieIDs []int
+	var recorded *models.Revision
+	errNotFound := errors.New("actor not found")
+	errInvalid := errors.New("invalid actor update")
+	log.Debug().Interface("updates", updates).Int("actorID", actorID).Msg("Applying updates to actor")
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		apply := func() (*models.Revision, error) {
+			err := tx.Preload("Movies").First(&data, "id = ?", actorID).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errNotFound
+			}
+			if err != nil {
+				return nil, err
+			}
+			before, err := actorSnapshot(tx, actorID)
+			if err != nil {
+				return nil, err
 			}
-		}
 
-		var moviesToRemove []models.Movie
-		for _, currentID := range currentMovieIDs {
-			if !utils.ContainsInterfaceAsInt(movieIDsInterface, currentID) {
-				moviesToRemove = append(moviesToRemove, models.Movie{ID: currentID})
+			for field, value := range edit.updates {
+				switch field {
+				case "name":
+					if name, ok := value.(string); ok {
+						data.Name = name
+					}
+				case "gender":
+					if value == nil {
+						data.Gender = ""
+					} else if _, ok := value.(string); ok {
+						data.Gender = edit.gender
+					}
+				case "dateOfBirth":
+					if value == nil {
+						data.DateOfBirth = ""
+					} else if _, ok := value.(string); ok {
+						data.DateOfBirth = edit.dateOfBirth
+					}
+				case "dateOfDeath":
+					if value == nil {
+						data.DateOfDeath = ""
+					} else if dodStr, ok := value.(string); ok {
+						data.DateOfDeath = utils.FormatTime(dodStr)
+					}
+				case "placeOfBirth":
+					if place, ok := value.(string); ok {
+						data.PlaceOfBirth = strings.TrimSpace(place)
+					}
+				case "biography":
+					if biography, ok := value.(string); ok {
+						data.Biography = biography
+					}
+				}
 			}
-		}
 
-		if len(moviesToAdd) > 0 {
-			log.Debug().Interface("moviesToAdd", moviesToAdd).Msg("Adding movies to actor")
-			PG.DB.Model(&data).Association("Movies").Append(moviesToAdd)
-		}
-		if len(moviesToRemove) > 0 {
-			log.Debug().Interface("moviesToRemove", moviesToRemove).Msg("Removing movies from actor")
-			PG.DB.Model(&data).Association("Movies").Delete(moviesToRemove)
+			if err := validateLifespan(data.DateOfBirth, data.DateOfDeath); err != nil {
+				return nil, fmt.Errorf("%w: %v", errInvalid, err)
+			}
+
+			if edit.castChanged {
+				var currentMovieIDs []int
+				for _, m := range data.Movies {
+					currentMovieIDs = append(currentMovieIDs, m.ID)
+				}
+				added, removed, err := syncAssociation[models.Movie](tx, &data, "Movies", currentMovieIDs, castEntryIDs(edit.cast))
+				if err != nil {
+					return nil, err
+				}
+				changedMovieIDs = append(append([]int{}, added...), removed...)
+				addedMovieIDs = added
+			}
+
+			if err := tx.Omit("PhotoKey").Save(&data).Error; err != nil {
+				return nil, err
+			}
+			if err := applyCredits(tx, edit.cast, func(entry castEntry) (int, int) { return actorID, entry.id }); err != nil {
+				return nil, err
+			}
+			if edit.aliasesChanged {
+				if err := replaceAliases(tx, actorID, edit.aliases); err != nil {
+					return nil, err
+				}
+			}
+
+			after, err := actorSnapshot(tx, actorID)
+			if err != nil {
+				return nil, err
+			}
+			revision.EntityType, revision.EntityID = "actor", actorID
+			recorded, err = recordRevision(tx, r, revision, before, after)
+			return recorded, err
 		}
+		return hook.run(tx, apply)
+	})
+	if errors.Is(err, errNotFound) {
+		log.Error().Err(err).Msg("Actor not found")
+		http.Error(w, "Actor not found", http.StatusNotFound)
+		return nil, nil, err
 	}
-
-	if err := PG.DB.Save(&data).Error; err != nil {
+	if errors.Is(err, errProposalReviewed) {
+		log.Error().Err(err).Msg("Proposal already reviewed")
+		http.Error(w, "Proposal already reviewed", http.StatusConflict)
+		return nil, nil, err
+	}
+	if errors.Is(err, errInvalid) {
+		log.Error().Err(err).Msg("Invalid date of death")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, nil, err
+	}
+	if err != nil {
 		log.Error().Err(err).Msg("Failed to save actor")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, nil, err
+	}
+
+	if len(changedMovieIDs) > 0 {
+		PG.refreshAfterCastChange([]int{actorID}, changedMovieIDs...)
+	}
+	for _, movieID := range addedMovieIDs {
+		PG.notifyFollowers(movieID, []int{actorID})
+	}
+
+	actors := []models.Actor{data}
+	if err := PG.loadFilmography(actors); err != nil {
+		log.Error().Err(err).Msg("Error loading filmography")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return nil, err
+		return nil, nil, err
 	}
+	data = actors[0]
 
-	log.Info().Msg("Actor updated successfully")
-	return &data, nil
+	if err := PG.DB.Where("actor_id = ?", actorID).Order("kind, name").Find(&data.Aliases).Error; err != nil {
+		log.Error().Err(err).Msg("Error loading aliases")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil, err
+	}
+
+	return &data, recorded, nil
 }
 
 // ActorList godoc
@@ -179,10 +360,11 @@ func (PG *Postgresql) ActorEdit(w http.ResponseWriter, r *http.Request) (*models
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
 // @Summary Lists all actors
-// @Description Retrieves a list of all actors, including their associated movies. Available to both 'admin' and 'user' roles.
+// @Description Retrieves a list of all actors, including their associated movies in release order with the actor's credit in each, their crew credits, the series episodes they guest starred in, their award nominations, aliases and external IDs. Available to both 'admin' and 'user' roles.
 // @Tags actor
 // @Produce json
 // @Param Authorization header string true "Bearer [JWT token]"
+// @Param Accept-Language header string false "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'"
 // @Success 200 {array} models.Actor "Successfully retrieved all actors"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
@@ -193,13 +375,33 @@ func (PG *Postgresql) ActorList(w http.ResponseWriter, r *http.Request) (*[]mode
 
 	var data []models.Actor
 
-	if err := PG.DB.Preload("Movies").Find(&data).Error; err != nil {
+	if err := preloadAliases(preloadExternalIDs(PG.DB)).Preload("CrewCredits", func(db *gorm.DB) *gorm.DB {
+		return db.Where("movie_id IN (" + activeMovieIDs + ")").Order("job, id")
+	}).Preload("CrewCredits.Movie").Find(&data).Error; err != nil {
 		log.Error().Err(err).Msg("Error retrieving actors")
 
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return nil, err
 	}
 
+	if err := PG.loadFilmography(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving filmographies")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	if err := PG.loadSeriesCredits(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving series credits")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	if err := PG.loadActorAwards(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving award nominations")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
 	log.Info().Int("count", len(data)).Msg("Successfully retrieved actors")
 
 	return &data, nil
@@ -209,17 +411,18 @@ func (PG *Postgresql) ActorList(w http.ResponseWriter, r *http.Request) (*[]mode
 //
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
-// @Summary Deletes an actor
-// @Description Deletes the actor with the specified ID, including removing all associated movies. Requires 'admin' role.
+// @Summary Moves an actor to the trash
+// @Description Moves the actor with the specified ID to the trash. The actor is hidden from lists and searches but keeps their movies, crew credits and other links until restored or purged, after which everything is removed for good. Requires 'admin' role.
 // @Tags actor
 // @Produce json
 // @Param Authorization header string true "Bearer [JWT token]"
 // @Param id path int true "Actor ID"
-// @Success 200 "Successfully deleted the actor"
+// @Success 200 "Successfully moved the actor to the trash"
 // @Failure 400 "Invalid actor ID or URL format"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
-// @Failure 500 "Actor not found or could not be deleted"
+// @Failure 404 "Actor not found"
+// @Failure 500 "Actor could not be deleted"
 // @Router /v1/actor-delete/{id} [delete]
 func (PG *Postgresql) ActorDelete(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
 
@@ -240,19 +443,40 @@ func (PG *Postgresql) ActorDelete(w http.ResponseWriter, r *http.Request) (*mode
 		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
 		return nil, err
 	}
+	actorID = PG.resolveActorID(actorID)
 
-	if err := PG.DB.Exec("DELETE FROM actormovies WHERE actor_id = ?", actorID).Error; err != nil {
-		log.Error().Err(err).Msg("Failed to delete associated records from the join table")
-		http.Error(w, "Failed to delete associated records from the join table", http.StatusInternalServerError)
+	var movieIDs []int
+	if err := PG.DB.Table("actormovies").Where("actor_id = ?", actorID).Pluck("movie_id", &movieIDs).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to load the actor's movies")
+		http.Error(w, "Failed to load the actor's movies", http.StatusInternalServerError)
 		return nil, err
 	}
 
-	if err := PG.DB.Where("id = ?", actorID).Delete(&models.Actor{}).Error; err != nil {
-		log.Error().Err(err).Msg("Actor not found or could not be deleted")
-		http.Error(w, "Actor not found or could not be deleted", http.StatusInternalServerError)
+	errNotFound := errors.New("actor not found")
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", actorID).Delete(&models.Actor{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errNotFound
+		}
+		_, err := recordRevision(tx, r, &models.Revision{EntityType: "actor", EntityID: actorID, Action: "delete"}, nil, nil)
+		return err
+	})
+	if errors.Is(err, errNotFound) {
+		log.Error().Int("actorID", actorID).Msg("Actor not found")
+		http.Error(w, "Actor not found", http.StatusNotFound)
+		return nil, err
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Actor could not be deleted")
+		http.Error(w, "Actor could not be deleted", http.StatusInternalServerError)
 		return nil, err
 	}
 
+	PG.refreshAfterCastChange(nil, movieIDs...)
+
 	log.Info().Int("actorID", actorID).Msg("Actor successfully deleted")
 	return &data, nil
 }
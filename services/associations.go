@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/utils"
+)
+
+// errUnknownIDs is returned when a request links an entity to IDs that do not exist.
+var errUnknownIDs = errors.New("unknown IDs")
+
+// syncAssociation brings a many-to-many association of owner in line with the requested IDs, using the same add/remove diffing
+// MovieEdit applies to actors: requested IDs missing from currentIDs are appended and current IDs no longer requested are removed.
+// The members are loaded before being linked, so unknown IDs are reported as errUnknownIDs instead of being inserted as empty rows.
+// Returns the IDs that were added and removed.
+func syncAssociation[T any](db *gorm.DB, owner interface{}, association string, currentIDs, requestedIDs []int) (added, removed []int, err error) {
+	for _, id := range requestedIDs {
+		if !utils.Contains(currentIDs, id) && !utils.Contains(added, id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range currentIDs {
+		if !utils.Contains(requestedIDs, id) {
+			removed = append(removed, id)
+		}
+	}
+
+	if len(added) > 0 {
+		var members []T
+		if err := db.Where("id IN ?", added).Find(&members).Error; err != nil {
+			return nil, nil, err
+		}
+		if len(members) != len(added) {
+			return nil, nil, fmt.Errorf("%w: %s %v", errUnknownIDs, association, added)
+		}
+		log.Debug().Ints("added", added).Str("association", association).Msg("Adding association members")
+		if err := db.Model(owner).Association(association).Append(members); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(removed) > 0 {
+		var members []T
+		if err := db.Where("id IN ?", removed).Find(&members).Error; err != nil {
+			return nil, nil, err
+		}
+		log.Debug().Ints("removed", removed).Str("association", association).Msg("Removing association members")
+		if err := db.Model(owner).Association(association).Delete(members); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return added, removed, nil
+}
+
+// associationStatus maps an error returned by syncAssociation or a write to the HTTP status to answer with.
+// Unknown IDs are the client's mistake and a unique violation a conflict with the stored data.
+func associationStatus(err error) int {
+	switch {
+	case errors.Is(err, errUnknownIDs):
+		return http.StatusBadRequest
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
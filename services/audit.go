@@ -0,0 +1,319 @@
+package services
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// auditLockKey is the advisory lock serializing the appends of API instances to the audit trail, so every entry links to the one before it.
+const auditLockKey = 7271001
+
+const (
+	// auditBacklog is how many audit entries can wait for the writer before Audit holds up the audited request.
+	auditBacklog = 1000
+	// auditBatchSize caps the number of entries the writer appends in one transaction.
+	auditBatchSize = 100
+	// auditRetryDelay is how long the writer waits before retrying a batch it could not append, doubling up to auditMaxRetryDelay.
+	auditRetryDelay    = time.Second
+	auditMaxRetryDelay = time.Minute
+	// auditListLimit caps the number of entries AuditList returns at once.
+	auditListLimit = 1000
+)
+
+// auditGuard makes the audit_entries table append-only: updates, deletions and truncation are refused by the database.
+var auditGuard = []string{
+	`CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+	RAISE EXCEPTION 'the audit log is append-only';
+END
+$$`,
+	"DROP TRIGGER IF EXISTS audit_entries_append_only ON audit_entries",
+	"CREATE TRIGGER audit_entries_append_only BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_entries FOR EACH STATEMENT EXECUTE FUNCTION audit_entries_append_only()",
+}
+
+// AuditList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Queries the audit trail
+// @Description Retrieves the audit trail of authenticated requests, logins and failed logins, newest first. Each entry records the user, role, endpoint, outcome, client IP and request ID, and is chained to the previous entry by its hash. Requires 'admin' role.
+// @Tags audit
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param from query string false "Only entries at or after this time, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "Only entries before this time, RFC 3339, or on or before this day, YYYY-MM-DD"
+// @Param userId query int false "Only entries of the user with this ID"
+// @Param action query string false "Only entries of this action [request|login|login-failed]"
+// @Param outcome query string false "Only entries with this outcome [success|denied|rejected|error]"
+// @Param limit query int false "Maximum number of entries to return, at most 1000 (default: 100)"
+// @Param offset query int false "Number of entries to skip (default: 0)"
+// @Success 200 {array} models.AuditEntry "Successfully retrieved the audit trail"
+// @Failure 400 "Invalid time, user ID, limit or offset"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving the audit trail"
+// @Router /v1/audit-list [get]
+func (PG *Postgresql) AuditList(w http.ResponseWriter, r *http.Request) (*[]models.AuditEntry, error) {
+	log.Info().Msg("AuditList called")
+
+	params := r.URL.Query()
+	query := PG.DB.Model(&models.AuditEntry{})
+
+	if from := params.Get("from"); from != "" {
+		start, _, err := parseAuditTime(from)
+		if err != nil {
+			log.Error().Err(err).Str("from", from).Msg("Invalid from parameter")
+			http.Error(w, "Invalid from parameter", http.StatusBadRequest)
+			return nil, err
+		}
+		query = query.Where("created_at >= ?", start)
+	}
+	if to := params.Get("to"); to != "" {
+		end, isDate, err := parseAuditTime(to)
+		if err != nil {
+			log.Error().Err(err).Str("to", to).Msg("Invalid to parameter")
+			http.Error(w, "Invalid to parameter", http.StatusBadRequest)
+			return nil, err
+		}
+		if isDate {
+			end = end.AddDate(0, 0, 1)
+		}
+		query = query.Where("created_at < ?", end)
+	}
+
+	userID, err := intQuery(w, r, "userId", 0)
+	if err != nil {
+		return nil, err
+	}
+	if userID > 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if action := params.Get("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if outcome := params.Get("outcome"); outcome != "" {
+		query = query.Where("outcome = ?", outcome)
+	}
+
+	limit, err := boundedIntQuery(w, r, "limit", 100, 1, auditListLimit)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := boundedIntQuery(w, r, "offset", 0, 0, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.AuditEntry
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving the audit trail")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Audit trail retrieved successfully")
+	return &data, nil
+}
+
+// AuditVerify godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Verifies the audit trail
+// @Description Recomputes the hash chain of the whole audit trail and reports the first entry that was altered, or that no longer links to the entry before it. Requires 'admin' role.
+// @Tags audit
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {object} models.AuditVerification "The result of the check"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error reading the audit trail"
+// @Router /v1/audit-verify [get]
+func (PG *Postgresql) AuditVerify(w http.ResponseWriter, r *http.Request) (*models.AuditVerification, error) {
+	log.Info().Msg("AuditVerify called")
+
+	data, err := PG.VerifyAuditTrail()
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading the audit trail")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	if data.Valid {
+		log.Info().Int("checked", data.Checked).Msg("Audit trail verified")
+	} else {
+		log.Warn().Int("brokenAt", *data.BrokenAt).Msg("Audit trail hash chain is broken")
+	}
+	return data, nil
+}
+
+// Audit queues the entry for the audit trail writer, which links it to the last entry by its hash.
+// It only blocks while the backlog is full, so requests are not serialized on the audit trail.
+// Failures are not returned, as auditing must not fail the audited request: the writer retries a batch until it is
+// appended, and while it cannot write the backlog fills up and Audit blocks, rather than leaving a gap in the trail.
+func (PG *Postgresql) Audit(entry *models.AuditEntry) {
+	entry.ID = 0
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	entry.Username = truncate(entry.Username, 100)
+	entry.Endpoint = truncate(entry.Endpoint, 255)
+
+	PG.audits <- entry
+}
+
+// startAuditWriter starts the single goroutine appending the queued audit entries, for as long as the process runs.
+func (PG *Postgresql) startAuditWriter() {
+	PG.audits = make(chan *models.AuditEntry, auditBacklog)
+	go PG.writeAuditTrail()
+}
+
+// writeAuditTrail appends the queued entries in batches: whatever has queued up while the previous batch was written,
+// up to auditBatchSize entries, goes into one transaction. A batch that fails is retried until it is appended.
+func (PG *Postgresql) writeAuditTrail() {
+	for entry := range PG.audits {
+		batch := []*models.AuditEntry{entry}
+	drain:
+		for len(batch) < auditBatchSize {
+			select {
+			case entry := <-PG.audits:
+				batch = append(batch, entry)
+			default:
+				break drain
+			}
+		}
+
+		for delay := auditRetryDelay; ; delay = min(2*delay, auditMaxRetryDelay) {
+			err := PG.appendAuditEntries(batch)
+			if err == nil {
+				break
+			}
+			log.Error().Err(err).Int("count", len(batch)).Str("requestID", batch[0].RequestID).Dur("retryIn", delay).
+				Msg("Error writing the audit trail, audited requests block once the backlog is full")
+			time.Sleep(delay)
+		}
+	}
+}
+
+// appendAuditEntries chains the entries to the last entry of the audit trail and appends them in one transaction.
+// The advisory lock is taken once per batch and keeps a single chain when several API instances write the trail.
+func (PG *Postgresql) appendAuditEntries(entries []*models.AuditEntry) error {
+	return PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditLockKey).Error; err != nil {
+			return err
+		}
+
+		var last []string
+		if err := tx.Model(&models.AuditEntry{}).Order("id DESC").Limit(1).Pluck("hash", &last).Error; err != nil {
+			return err
+		}
+		prevHash := ""
+		if len(last) > 0 {
+			prevHash = last[0]
+		}
+		chainAuditEntries(prevHash, entries)
+		// A retried batch must not keep the IDs a failed attempt may have assigned.
+		for _, entry := range entries {
+			entry.ID = 0
+		}
+
+		return tx.Create(&entries).Error
+	})
+}
+
+// chainAuditEntries links each entry to the one before it, the first to the entry with prevHash, and sets its hash.
+func chainAuditEntries(prevHash string, entries []*models.AuditEntry) {
+	for _, entry := range entries {
+		entry.PrevHash = prevHash
+		entry.Hash = auditHash(entry)
+		prevHash = entry.Hash
+	}
+}
+
+// auditLinkBroken reports whether the entry does not follow the entry with prevHash or does not match its own hash.
+func auditLinkBroken(prevHash string, entry *models.AuditEntry) bool {
+	return entry.PrevHash != prevHash || auditHash(entry) != entry.Hash
+}
+
+// VerifyAuditTrail walks the audit trail in order, checking that each entry matches its hash and links to the entry before it.
+// Entries are read row by row, so memory use does not grow with the trail.
+func (PG *Postgresql) VerifyAuditTrail() (*models.AuditVerification, error) {
+	data := &models.AuditVerification{Valid: true}
+	errBroken := errors.New("broken audit chain")
+
+	prevHash := ""
+	err := streamRows(PG.DB.Model(&models.AuditEntry{}).Order("id"), func(rows *sql.Rows) error {
+		var entry models.AuditEntry
+		if err := PG.DB.ScanRows(rows, &entry); err != nil {
+			return err
+		}
+		data.Checked++
+		if auditLinkBroken(prevHash, &entry) {
+			data.Valid, data.BrokenAt = false, &entry.ID
+			return errBroken
+		}
+		prevHash = entry.Hash
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBroken) {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// installAuditGuard makes the audit trail append-only in the database. It is idempotent and runs on every start.
+func (PG *Postgresql) installAuditGuard() error {
+	for _, statement := range auditGuard {
+		if err := PG.DB.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditHash returns the hex encoded SHA-256 of the entry's fields and the hash of the previous entry.
+func auditHash(entry *models.AuditEntry) string {
+	fields, _ := json.Marshal([]interface{}{
+		entry.PrevHash,
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		entry.UserID,
+		entry.Username,
+		entry.Role,
+		entry.Action,
+		entry.Method,
+		entry.Endpoint,
+		entry.Status,
+		entry.Outcome,
+		entry.IP,
+		entry.RequestID,
+	})
+	sum := sha256.Sum256(fields)
+	return hex.EncodeToString(sum[:])
+}
+
+// parseAuditTime reads an RFC 3339 time or a YYYY-MM-DD date, reporting whether it was a date.
+func parseAuditTime(value string) (time.Time, bool, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+// truncate cuts the string to at most n characters, so it fits its column.
+func truncate(value string, n int) string {
+	if utf8.RuneCountInString(value) > n {
+		return string([]rune(value)[:n])
+	}
+	return value
+}
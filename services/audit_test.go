@@ -0,0 +1,115 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"vk.com/m/models"
+)
+
+// auditTrail returns three entries chained after prevHash.
+func auditTrail(prevHash string) []*models.AuditEntry {
+	userID := 7
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []*models.AuditEntry{
+		{CreatedAt: created, Action: "login-failed", Username: "admin", Method: "POST", Endpoint: "/login", Status: 401, Outcome: "denied", IP: "10.0.0.1"},
+		{CreatedAt: created.Add(time.Second), Action: "login", UserID: &userID, Username: "admin", Role: "admin", Method: "POST", Endpoint: "/login", Status: 200, Outcome: "success", IP: "10.0.0.1"},
+		{CreatedAt: created.Add(2 * time.Second), Action: "request", UserID: &userID, Username: "admin", Role: "admin", Method: "DELETE", Endpoint: "/v1/movie-delete/3", Status: 200, Outcome: "success", IP: "10.0.0.1", RequestID: "abc"},
+	}
+	chainAuditEntries(prevHash, entries)
+	return entries
+}
+
+// firstBrokenLink walks the entries like VerifyAuditTrail and returns the index of the first broken one, or -1.
+func firstBrokenLink(prevHash string, entries []*models.AuditEntry) int {
+	for i, entry := range entries {
+		if auditLinkBroken(prevHash, entry) {
+			return i
+		}
+		prevHash = entry.Hash
+	}
+	return -1
+}
+
+func TestChainAuditEntries(t *testing.T) {
+	entries := auditTrail("previous")
+
+	if entries[0].PrevHash != "previous" {
+		t.Errorf("first entry links to %q, want the previous hash", entries[0].PrevHash)
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].PrevHash != entries[i-1].Hash {
+			t.Errorf("entry %d links to %q, want the hash of entry %d", i, entries[i].PrevHash, i-1)
+		}
+	}
+	if again := auditTrail("previous"); again[2].Hash != entries[2].Hash {
+		t.Errorf("chaining the same entries twice gives %q and %q, want the same hash", entries[2].Hash, again[2].Hash)
+	}
+	if other := auditTrail(""); other[0].Hash == entries[0].Hash {
+		t.Errorf("entries chained to different previous hashes share the hash %q", other[0].Hash)
+	}
+}
+
+func TestAuditLinkBroken(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func([]*models.AuditEntry) []*models.AuditEntry
+		want   int
+	}{
+		{"intact", func(entries []*models.AuditEntry) []*models.AuditEntry { return entries }, -1},
+		{
+			"time read back in another zone",
+			func(entries []*models.AuditEntry) []*models.AuditEntry {
+				entries[1].CreatedAt = entries[1].CreatedAt.In(time.FixedZone("MSK", 3*60*60))
+				return entries
+			},
+			-1,
+		},
+		{
+			"field changed",
+			func(entries []*models.AuditEntry) []*models.AuditEntry {
+				entries[1].Status = 403
+				return entries
+			},
+			1,
+		},
+		{
+			"field and hash changed",
+			func(entries []*models.AuditEntry) []*models.AuditEntry {
+				entries[1].Status = 403
+				entries[1].Hash = auditHash(entries[1])
+				return entries
+			},
+			2,
+		},
+		{
+			"entry removed",
+			func(entries []*models.AuditEntry) []*models.AuditEntry {
+				return append(entries[:1], entries[2:]...)
+			},
+			1,
+		},
+		{
+			"entries swapped",
+			func(entries []*models.AuditEntry) []*models.AuditEntry {
+				entries[1], entries[2] = entries[2], entries[1]
+				return entries
+			},
+			1,
+		},
+		{
+			"first entry relinked",
+			func(entries []*models.AuditEntry) []*models.AuditEntry {
+				entries[0].PrevHash = ""
+				return entries
+			},
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := firstBrokenLink("previous", tt.tamper(auditTrail("previous"))); got != tt.want {
+			t.Errorf("%s: first broken link = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,830 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+	"vk.com/m/utils"
+)
+
+// AwardAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a new award
+// @Description Adds an awarding body, such as a festival or an academy, with the given name. Award names are unique. Requires 'admin' role.
+// @Tags award
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param award body models.Award true "Award to add, with its Name"
+// @Success 200 {object} models.Award "Successfully added the award"
+// @Failure 400 "Invalid request body or empty name"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "An award with this name already exists"
+// @Failure 500 "Error creating award"
+// @Router /v1/award-add [post]
+func (PG *Postgresql) AwardAdd(w http.ResponseWriter, r *http.Request) (*models.Award, error) {
+
+	log.Info().Msg("AwardAdd called")
+
+	var data models.Award
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID, data.Ceremonies, data.Categories = 0, nil, nil
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		log.Error().Msg("Empty award name")
+		http.Error(w, "Award name is required", http.StatusBadRequest)
+		return nil, errors.New("empty award name")
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating award")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("awardID", data.ID).Msg("Award added successfully")
+	return &data, nil
+}
+
+// AwardEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Renames an award
+// @Description Edits the award with the specified ID based on the given update fields. Requires 'admin' role.
+// @Tags award
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Award ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Award "Successfully updated the award"
+// @Failure 400 "Invalid request body or award ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Award not found"
+// @Failure 409 "An award with this name already exists"
+// @Failure 500 "Failed to save award"
+// @Router /v1/award-edit/{id} [put]
+func (PG *Postgresql) AwardEdit(w http.ResponseWriter, r *http.Request) (*models.Award, error) {
+
+	log.Info().Msg("AwardEdit called")
+
+	var data models.Award
+
+	awardID, err := idFromPath(w, r, "award")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", awardID).Error; err != nil {
+		log.Error().Err(err).Msg("Award not found")
+		http.Error(w, "Award not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	if name, ok := updates["name"].(string); ok && strings.TrimSpace(name) != "" {
+		data.Name = strings.TrimSpace(name)
+	}
+
+	if err := PG.DB.Save(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save award")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("awardID", awardID).Msg("Award updated successfully")
+	return &data, nil
+}
+
+// AwardList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists all awards
+// @Description Retrieves all awards ordered by name, each with its ceremonies, most recent first, and its categories. Available to both 'admin' and 'user' roles.
+// @Tags award
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.Award "Successfully retrieved all awards"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving awards"
+// @Router /v1/award-list [get]
+func (PG *Postgresql) AwardList(w http.ResponseWriter, r *http.Request) (*[]models.Award, error) {
+	log.Info().Msg("AwardList called")
+
+	var data []models.Award
+
+	err := PG.DB.Preload("Ceremonies", func(db *gorm.DB) *gorm.DB {
+		return db.Order("year DESC")
+	}).Preload("Categories", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).Order("name").Find(&data).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving awards")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Successfully retrieved awards")
+	return &data, nil
+}
+
+// AwardDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes an award
+// @Description Deletes the award with the specified ID together with its ceremonies, categories and nominations. Requires 'admin' role.
+// @Tags award
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Award ID"
+// @Success 200 "Successfully deleted the award"
+// @Failure 400 "Invalid award ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Award could not be deleted"
+// @Router /v1/award-delete/{id} [delete]
+func (PG *Postgresql) AwardDelete(w http.ResponseWriter, r *http.Request) (*models.Award, error) {
+
+	log.Info().Msg("AwardDelete called")
+
+	var data models.Award
+
+	awardID, err := idFromPath(w, r, "award")
+	if err != nil {
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		ceremonies := tx.Model(&models.AwardCeremony{}).Select("id").Where("award_id = ?", awardID)
+		if err := tx.Where("ceremony_id IN (?)", ceremonies).Delete(&models.Nomination{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("award_id = ?", awardID).Delete(&models.AwardCeremony{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("award_id = ?", awardID).Delete(&models.AwardCategory{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", awardID).Delete(&models.Award{}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Award could not be deleted")
+		http.Error(w, "Award could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("awardID", awardID).Msg("Award deleted successfully")
+	return &data, nil
+}
+
+// CeremonyAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a ceremony to an award
+// @Description Adds the edition of the award with the given AwardID held in Year, with an optional Name and Date (YYYY-MM-DD). An award holds at most one ceremony a year. Requires 'admin' role.
+// @Tags award
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param ceremony body models.AwardCeremony true "Ceremony to add"
+// @Success 200 {object} models.AwardCeremony "Successfully added the ceremony"
+// @Failure 400 "Invalid request body, year, date or award"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "The award already has a ceremony that year"
+// @Failure 500 "Error creating ceremony"
+// @Router /v1/ceremony-add [post]
+func (PG *Postgresql) CeremonyAdd(w http.ResponseWriter, r *http.Request) (*models.AwardCeremony, error) {
+
+	log.Info().Msg("CeremonyAdd called")
+
+	var data models.AwardCeremony
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID = 0
+	data.Name = strings.TrimSpace(data.Name)
+
+	if data.Year <= 0 {
+		log.Error().Int("year", data.Year).Msg("Invalid ceremony year")
+		http.Error(w, "A valid year is required", http.StatusBadRequest)
+		return nil, errors.New("invalid ceremony year")
+	}
+	if data.Date != "" && !validDate(data.Date) {
+		log.Error().Str("date", data.Date).Msg("Invalid ceremony date")
+		http.Error(w, "Invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return nil, errors.New("invalid ceremony date")
+	}
+
+	if err := PG.DB.First(&models.Award{}, "id = ?", data.AwardID).Error; err != nil {
+		log.Error().Err(err).Msg("Award not found")
+		http.Error(w, "Award not found", http.StatusBadRequest)
+		return nil, err
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating ceremony")
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "The award already has a ceremony that year", http.StatusConflict)
+			return nil, err
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("ceremonyID", data.ID).Msg("Ceremony added successfully")
+	return &data, nil
+}
+
+// CeremonyEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Edits an award ceremony
+// @Description Edits the ceremony with the specified ID based on the given update fields: year, name and date (YYYY-MM-DD, empty to clear). An award holds at most one ceremony a year. Requires 'admin' role.
+// @Tags award
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Ceremony ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.AwardCeremony "Successfully updated the ceremony"
+// @Failure 400 "Invalid request body, ceremony ID, year or date"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Ceremony not found"
+// @Failure 409 "The award already has a ceremony that year"
+// @Failure 500 "Failed to save ceremony"
+// @Router /v1/ceremony-edit/{id} [put]
+func (PG *Postgresql) CeremonyEdit(w http.ResponseWriter, r *http.Request) (*models.AwardCeremony, error) {
+
+	log.Info().Msg("CeremonyEdit called")
+
+	var data models.AwardCeremony
+
+	ceremonyID, err := idFromPath(w, r, "ceremony")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", ceremonyID).Error; err != nil {
+		log.Error().Err(err).Msg("Ceremony not found")
+		http.Error(w, "Ceremony not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "year":
+			year, err := utils.InterfaceToInt(value)
+			if err != nil || year <= 0 {
+				log.Error().Interface("year", value).Msg("Invalid ceremony year")
+				http.Error(w, "A valid year is required", http.StatusBadRequest)
+				return nil, errors.New("invalid ceremony year")
+			}
+			data.Year = year
+		case "name":
+			if name, ok := value.(string); ok {
+				data.Name = strings.TrimSpace(name)
+			}
+		case "date":
+			if date, ok := value.(string); ok {
+				if date != "" && !validDate(date) {
+					log.Error().Str("date", date).Msg("Invalid ceremony date")
+					http.Error(w, "Invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+					return nil, errors.New("invalid ceremony date")
+				}
+				data.Date = date
+			}
+		}
+	}
+
+	if err := PG.DB.Save(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save ceremony")
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "The award already has a ceremony that year", http.StatusConflict)
+			return nil, err
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("ceremonyID", ceremonyID).Msg("Ceremony updated successfully")
+	return &data, nil
+}
+
+// CeremonyDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a ceremony
+// @Description Deletes the award ceremony with the specified ID together with its nominations. Requires 'admin' role.
+// @Tags award
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Ceremony ID"
+// @Success 200 "Successfully deleted the ceremony"
+// @Failure 400 "Invalid ceremony ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Ceremony could not be deleted"
+// @Router /v1/ceremony-delete/{id} [delete]
+func (PG *Postgresql) CeremonyDelete(w http.ResponseWriter, r *http.Request) (*models.AwardCeremony, error) {
+
+	log.Info().Msg("CeremonyDelete called")
+
+	var data models.AwardCeremony
+
+	ceremonyID, err := idFromPath(w, r, "ceremony")
+	if err != nil {
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("ceremony_id = ?", ceremonyID).Delete(&models.Nomination{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", ceremonyID).Delete(&models.AwardCeremony{}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Ceremony could not be deleted")
+		http.Error(w, "Ceremony could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("ceremonyID", ceremonyID).Msg("Ceremony deleted successfully")
+	return &data, nil
+}
+
+// AwardCategoryAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a category to an award
+// @Description Adds a category, such as "Best Picture", to the award with the given AwardID. Category names are unique within an award. Requires 'admin' role.
+// @Tags award
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param category body models.AwardCategory true "Category to add, with AwardID and Name"
+// @Success 200 {object} models.AwardCategory "Successfully added the category"
+// @Failure 400 "Invalid request body, empty name or unknown award"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "The award already has a category with this name"
+// @Failure 500 "Error creating category"
+// @Router /v1/award-category-add [post]
+func (PG *Postgresql) AwardCategoryAdd(w http.ResponseWriter, r *http.Request) (*models.AwardCategory, error) {
+
+	log.Info().Msg("AwardCategoryAdd called")
+
+	var data models.AwardCategory
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID = 0
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		log.Error().Msg("Empty category name")
+		http.Error(w, "Category name is required", http.StatusBadRequest)
+		return nil, errors.New("empty category name")
+	}
+
+	if err := PG.DB.First(&models.Award{}, "id = ?", data.AwardID).Error; err != nil {
+		log.Error().Err(err).Msg("Award not found")
+		http.Error(w, "Award not found", http.StatusBadRequest)
+		return nil, err
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating category")
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "The award already has a category with this name", http.StatusConflict)
+			return nil, err
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("categoryID", data.ID).Msg("Award category added successfully")
+	return &data, nil
+}
+
+// AwardCategoryEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Renames an award category
+// @Description Edits the award category with the specified ID based on the given update fields. Category names are unique within an award. Requires 'admin' role.
+// @Tags award
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Category ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.AwardCategory "Successfully updated the category"
+// @Failure 400 "Invalid request body or category ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Category not found"
+// @Failure 409 "The award already has a category with this name"
+// @Failure 500 "Failed to save category"
+// @Router /v1/award-category-edit/{id} [put]
+func (PG *Postgresql) AwardCategoryEdit(w http.ResponseWriter, r *http.Request) (*models.AwardCategory, error) {
+
+	log.Info().Msg("AwardCategoryEdit called")
+
+	var data models.AwardCategory
+
+	categoryID, err := idFromPath(w, r, "category")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", categoryID).Error; err != nil {
+		log.Error().Err(err).Msg("Category not found")
+		http.Error(w, "Category not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	if name, ok := updates["name"].(string); ok && strings.TrimSpace(name) != "" {
+		data.Name = strings.TrimSpace(name)
+	}
+
+	if err := PG.DB.Save(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save category")
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "The award already has a category with this name", http.StatusConflict)
+			return nil, err
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("categoryID", categoryID).Msg("Award category updated successfully")
+	return &data, nil
+}
+
+// AwardCategoryDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes an award category
+// @Description Deletes the award category with the specified ID together with its nominations. Requires 'admin' role.
+// @Tags award
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Category ID"
+// @Success 200 "Successfully deleted the category"
+// @Failure 400 "Invalid category ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Category could not be deleted"
+// @Router /v1/award-category-delete/{id} [delete]
+func (PG *Postgresql) AwardCategoryDelete(w http.ResponseWriter, r *http.Request) (*models.AwardCategory, error) {
+
+	log.Info().Msg("AwardCategoryDelete called")
+
+	var data models.AwardCategory
+
+	categoryID, err := idFromPath(w, r, "category")
+	if err != nil {
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id = ?", categoryID).Delete(&models.Nomination{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", categoryID).Delete(&models.AwardCategory{}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Category could not be deleted")
+		http.Error(w, "Category could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("categoryID", categoryID).Msg("Award category deleted successfully")
+	return &data, nil
+}
+
+// NominationAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a nomination
+// @Description Records that the movie with the given MovieID, and optionally the person with the given ActorID, was nominated in CategoryID at CeremonyID. Set Won for a win. The category and the ceremony must belong to the same award. Requires 'admin' role.
+// @Tags award
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param nomination body models.Nomination true "Nomination to add"
+// @Success 200 {object} models.Nomination "Successfully added the nomination"
+// @Failure 400 "Invalid request body, unknown ceremony, category, movie or person, or mismatched award"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "The nomination is already recorded"
+// @Failure 500 "Error creating nomination"
+// @Router /v1/nomination-add [post]
+func (PG *Postgresql) NominationAdd(w http.ResponseWriter, r *http.Request) (*models.Nomination, error) {
+
+	log.Info().Msg("NominationAdd called")
+
+	var data models.Nomination
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID = 0
+
+	if err := PG.validateNomination(&data); err != nil {
+		log.Error().Err(err).Msg("Invalid nomination")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating nomination")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("nominationID", data.ID).Msg("Nomination added successfully")
+	return &data, nil
+}
+
+// NominationEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Edits a nomination
+// @Description Edits the nomination with the specified ID based on the given update fields: won, and actorId, the nominated person, which can be set to null for a movie-only nomination. Requires 'admin' role.
+// @Tags award
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Nomination ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Nomination "Successfully updated the nomination"
+// @Failure 400 "Invalid request body, nomination ID, actorId or unknown person"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Nomination not found"
+// @Failure 409 "The nomination is already recorded"
+// @Failure 500 "Failed to save nomination"
+// @Router /v1/nomination-edit/{id} [put]
+func (PG *Postgresql) NominationEdit(w http.ResponseWriter, r *http.Request) (*models.Nomination, error) {
+
+	log.Info().Msg("NominationEdit called")
+
+	var data models.Nomination
+
+	nominationID, err := idFromPath(w, r, "nomination")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", nominationID).Error; err != nil {
+		log.Error().Err(err).Msg("Nomination not found")
+		http.Error(w, "Nomination not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "won":
+			if won, ok := value.(bool); ok {
+				data.Won = won
+			}
+		case "actorId":
+			if value == nil {
+				data.ActorID = nil
+				continue
+			}
+			actorID, err := utils.InterfaceToInt(value)
+			if err != nil {
+				log.Error().Interface("actorId", value).Msg("Invalid actor ID")
+				http.Error(w, "Invalid actorId, expected a person ID or null", http.StatusBadRequest)
+				return nil, fmt.Errorf("invalid actorId %v", value)
+			}
+			data.ActorID = &actorID
+		}
+	}
+
+	if err := PG.validateNomination(&data); err != nil {
+		log.Error().Err(err).Msg("Invalid nomination")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	if err := PG.DB.Save(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save nomination")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("nominationID", nominationID).Msg("Nomination updated successfully")
+	return &data, nil
+}
+
+// NominationDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a nomination
+// @Description Deletes the nomination with the specified ID. Requires 'admin' role.
+// @Tags award
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Nomination ID"
+// @Success 200 "Successfully deleted the nomination"
+// @Failure 400 "Invalid nomination ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Nomination could not be deleted"
+// @Router /v1/nomination-delete/{id} [delete]
+func (PG *Postgresql) NominationDelete(w http.ResponseWriter, r *http.Request) (*models.Nomination, error) {
+
+	log.Info().Msg("NominationDelete called")
+
+	var data models.Nomination
+
+	nominationID, err := idFromPath(w, r, "nomination")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.Where("id = ?", nominationID).Delete(&models.Nomination{}).Error; err != nil {
+		log.Error().Err(err).Msg("Nomination could not be deleted")
+		http.Error(w, "Nomination could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("nominationID", nominationID).Msg("Nomination deleted successfully")
+	return &data, nil
+}
+
+// validateNomination checks that everything the nomination refers to exists
+// and that its category and ceremony belong to the same award. A merged person is replaced by the actor that absorbed them.
+func (PG *Postgresql) validateNomination(nomination *models.Nomination) error {
+	var ceremony models.AwardCeremony
+	if err := PG.DB.First(&ceremony, "id = ?", nomination.CeremonyID).Error; err != nil {
+		return fmt.Errorf("unknown ceremony %d", nomination.CeremonyID)
+	}
+	var category models.AwardCategory
+	if err := PG.DB.First(&category, "id = ?", nomination.CategoryID).Error; err != nil {
+		return fmt.Errorf("unknown category %d", nomination.CategoryID)
+	}
+	if category.AwardID != ceremony.AwardID {
+		return errors.New("the category and the ceremony belong to different awards")
+	}
+	if err := PG.DB.First(&models.Movie{}, "id = ?", nomination.MovieID).Error; err != nil {
+		return fmt.Errorf("unknown movie %d", nomination.MovieID)
+	}
+	if nomination.ActorID != nil {
+		actorID := PG.resolveActorID(*nomination.ActorID)
+		nomination.ActorID = &actorID
+		if err := PG.DB.First(&models.Actor{}, "id = ?", actorID).Error; err != nil {
+			return fmt.Errorf("unknown person %d", *nomination.ActorID)
+		}
+	}
+	return nil
+}
+
+// indexNominations makes a nomination unique per ceremony, category, movie and person.
+// Movie-only nominations have no person, so the index coalesces the null ActorID, which a plain unique index treats as distinct.
+func (PG *Postgresql) indexNominations() error {
+	return PG.DB.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_nomination ON nominations (ceremony_id, category_id, movie_id, COALESCE(actor_id, 0))").Error
+}
+
+// awardFilter narrows a movie query down to the movies that won at least one award when "awardWinner" is true.
+// Returns an error if the parameter is not a boolean.
+func (PG *Postgresql) awardFilter(query *gorm.DB, params url.Values) (*gorm.DB, error) {
+	value := params.Get("awardWinner")
+	if value == "" {
+		return query, nil
+	}
+
+	winnersOnly, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid awardWinner %q", value)
+	}
+	if winnersOnly {
+		query = query.Where("movies.id IN (?)", PG.DB.Model(&models.Nomination{}).Select("movie_id").Where("won"))
+	}
+
+	return query, nil
+}
+
+// awardCredits lists the nominations whose column, "movie_id" or "actor_id", holds one of the IDs,
+// most recent ceremony first, with the award, category, movie and person names resolved.
+func (PG *Postgresql) awardCredits(column string, ids []int) ([]*models.AwardCredit, error) {
+	var credits []*models.AwardCredit
+	if len(ids) == 0 {
+		return credits, nil
+	}
+
+	err := PG.DB.Table("nominations").
+		Select("nominations.id AS nomination_id, awards.name AS award, award_ceremonies.year, award_categories.name AS category, "+
+			"nominations.won, nominations.movie_id, movies.title AS movie_title, nominations.actor_id, COALESCE(actors.name, '') AS person_name").
+		Joins("JOIN award_ceremonies ON award_ceremonies.id = nominations.ceremony_id").
+		Joins("JOIN awards ON awards.id = award_ceremonies.award_id").
+		Joins("JOIN award_categories ON award_categories.id = nominations.category_id").
+		Joins("JOIN movies ON movies.id = nominations.movie_id AND movies.deleted_at IS NULL").
+		Joins("LEFT JOIN actors ON actors.id = nominations.actor_id").
+		Where("nominations."+column+" IN ? AND actors.deleted_at IS NULL", ids).
+		Order("award_ceremonies.year DESC, awards.name, award_categories.name, nominations.id").
+		Scan(&credits).Error
+	return credits, err
+}
+
+// loadMovieAwards sets the nominations and wins of the movie, including those of the people nominated for their work on it.
+func (PG *Postgresql) loadMovieAwards(movie *models.Movie) error {
+	credits, err := PG.awardCredits("movie_id", []int{movie.ID})
+	if err != nil {
+		return err
+	}
+	movie.Awards = credits
+	return nil
+}
+
+// loadActorAwards sets the nominations and wins of each person.
+func (PG *Postgresql) loadActorAwards(actors []models.Actor) error {
+	actorIDs := make([]int, len(actors))
+	for i, actor := range actors {
+		actorIDs[i] = actor.ID
+	}
+
+	credits, err := PG.awardCredits("actor_id", actorIDs)
+	if err != nil {
+		return err
+	}
+
+	byActor := make(map[int][]*models.AwardCredit, len(actors))
+	for _, credit := range credits {
+		byActor[*credit.ActorID] = append(byActor[*credit.ActorID], credit)
+	}
+	for i := range actors {
+		actors[i].Awards = byActor[actors[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// genders lists the accepted actor genders. The one-letter codes of the original schema are still accepted as input.
+var genders = map[string]string{
+	"female":     "female",
+	"male":       "male",
+	"non-binary": "non-binary",
+	"other":      "other",
+	"f":          "female",
+	"m":          "male",
+}
+
+// aliasKinds lists the kinds of actor aliases.
+var aliasKinds = map[string]bool{"stage": true, "birth": true, "alternate": true}
+
+// normalizeGender lower-cases a gender and maps the legacy "M" and "F" codes, reporting whether the gender is accepted.
+// An empty gender leaves it unstated.
+func normalizeGender(gender string) (string, bool) {
+	gender = strings.ToLower(strings.TrimSpace(gender))
+	if gender == "" {
+		return "", true
+	}
+	normalized, ok := genders[gender]
+	return normalized, ok
+}
+
+// migrateActorGenders rewrites the one-letter genders of actors stored before the gender column was widened.
+// A database still on the original schema keeps its CHECK (gender IN ('M', 'F')), which would reject the rewritten
+// genders, so that check is replaced with one accepting the widened genders first. It is idempotent and runs on every start.
+func (PG *Postgresql) migrateActorGenders() error {
+	return PG.DB.Transaction(func(tx *gorm.DB) error {
+		var definition string
+		err := tx.Raw("SELECT pg_get_constraintdef(oid) FROM pg_constraint WHERE conrelid = 'actors'::regclass AND conname = 'actors_gender_check'").
+			Scan(&definition).Error
+		if err != nil {
+			return fmt.Errorf("reading the actor gender check: %w", err)
+		}
+		legacy := strings.Contains(definition, "'M'")
+		if legacy {
+			if err := tx.Exec("ALTER TABLE actors DROP CONSTRAINT actors_gender_check").Error; err != nil {
+				return fmt.Errorf("dropping the one-letter actor gender check: %w", err)
+			}
+		}
+		if err := tx.Exec("UPDATE actors SET gender = CASE gender WHEN 'M' THEN 'male' ELSE 'female' END WHERE gender IN ('M', 'F')").Error; err != nil {
+			return fmt.Errorf("rewriting the one-letter actor genders: %w", err)
+		}
+		if legacy {
+			err := tx.Exec("ALTER TABLE actors ADD CONSTRAINT actors_gender_check CHECK (gender IN ('', 'female', 'male', 'non-binary', 'other'))").Error
+			if err != nil {
+				return fmt.Errorf("adding the widened actor gender check: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// parseDateOfBirth normalizes an actor's date of birth given as YYYY-MM-DD. An empty date stays empty: the date is unknown.
+func parseDateOfBirth(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return "", fmt.Errorf("invalid date of birth %q, expected YYYY-MM-DD", value)
+	}
+	return date.Format("2006-01-02"), nil
+}
+
+// validateLifespan checks that an actor did not die before they were born. Dates that cannot be parsed are not compared.
+func validateLifespan(dateOfBirth, dateOfDeath string) error {
+	if dateOfDeath == "" {
+		return nil
+	}
+	death, err := time.Parse("2006-01-02", dateOfDeath)
+	if err != nil {
+		return fmt.Errorf("invalid date of death %q", dateOfDeath)
+	}
+	if birth, err := time.Parse("2006-01-02", dateOfBirth); err == nil && death.Before(birth) {
+		return fmt.Errorf("date of death %s is before date of birth %s", dateOfDeath, dateOfBirth)
+	}
+	return nil
+}
+
+// validateAliases trims the aliases of an actor and checks their kinds, which default to "alternate".
+func validateAliases(aliases []*models.ActorAlias) error {
+	for _, alias := range aliases {
+		alias.ID, alias.ActorID = 0, 0
+		alias.Name = strings.TrimSpace(alias.Name)
+		if alias.Name == "" {
+			return errors.New("empty alias")
+		}
+		alias.Kind = strings.ToLower(strings.TrimSpace(alias.Kind))
+		if alias.Kind == "" {
+			alias.Kind = "alternate"
+		}
+		if !aliasKinds[alias.Kind] {
+			return fmt.Errorf("invalid alias kind %q, expected stage, birth or alternate", alias.Kind)
+		}
+	}
+	return nil
+}
+
+// parseAliases reads the "aliases" of an actor edit: each item is either a name or an object {name, kind}.
+func parseAliases(values []interface{}) ([]*models.ActorAlias, error) {
+	aliases := make([]*models.ActorAlias, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			aliases = append(aliases, &models.ActorAlias{Name: v})
+		case map[string]interface{}:
+			name, _ := v["name"].(string)
+			kind, _ := v["kind"].(string)
+			aliases = append(aliases, &models.ActorAlias{Name: name, Kind: kind})
+		default:
+			return nil, fmt.Errorf("invalid alias %v", value)
+		}
+	}
+	return aliases, validateAliases(aliases)
+}
+
+// replaceAliases makes the given validated aliases the only aliases of the actor.
+func replaceAliases(tx *gorm.DB, actorID int, aliases []*models.ActorAlias) error {
+	if err := tx.Where("actor_id = ?", actorID).Delete(&models.ActorAlias{}).Error; err != nil {
+		return err
+	}
+	if len(aliases) == 0 {
+		return nil
+	}
+	for _, alias := range aliases {
+		alias.ActorID = actorID
+	}
+	return tx.Create(&aliases).Error
+}
+
+// preloadAliases adds the aliases of the actors to the query.
+func preloadAliases(query *gorm.DB) *gorm.DB {
+	return query.Preload("Aliases", func(db *gorm.DB) *gorm.DB {
+		return db.Order("kind, name")
+	})
+}
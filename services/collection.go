@@ -0,0 +1,346 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+	"vk.com/m/utils"
+)
+
+// CollectionAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a new collection
+// @Description Adds a franchise or collection of movies with the given name and description. Collection names are unique. Movies are added through CollectionEdit. Requires 'admin' role.
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param collection body models.Collection true "Collection to add, with Name and Description"
+// @Success 200 {object} models.Collection "Successfully added the collection"
+// @Failure 400 "Invalid request body or empty name"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "A collection with this name already exists"
+// @Failure 500 "Error creating collection"
+// @Router /v1/collection-add [post]
+func (PG *Postgresql) CollectionAdd(w http.ResponseWriter, r *http.Request) (*models.Collection, error) {
+
+	log.Info().Msg("CollectionAdd called")
+
+	var data models.Collection
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID, data.Entries = 0, nil
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		log.Error().Msg("Empty collection name")
+		http.Error(w, "Collection name is required", http.StatusBadRequest)
+		return nil, errors.New("empty collection name")
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating collection")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("collectionID", data.ID).Msg("Collection added successfully")
+	return &data, nil
+}
+
+// CollectionEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Edits a collection
+// @Description Edits the collection with the specified ID based on the given update fields: name, description, and movies, an array of movie IDs replacing the collection's contents in viewing order. Requires 'admin' role.
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Collection ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Collection "Successfully updated the collection"
+// @Failure 400 "Invalid request body, collection ID or unknown movie IDs"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Collection not found"
+// @Failure 409 "A collection with this name already exists"
+// @Failure 500 "Failed to save collection"
+// @Router /v1/collection-edit/{id} [put]
+func (PG *Postgresql) CollectionEdit(w http.ResponseWriter, r *http.Request) (*models.Collection, error) {
+
+	log.Info().Msg("CollectionEdit called")
+
+	collectionID, err := idFromPath(w, r, "collection")
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Collection
+
+	if err := PG.DB.First(&data, "id = ?", collectionID).Error; err != nil {
+		log.Error().Err(err).Msg("Collection not found")
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "name":
+			if name, ok := value.(string); ok && strings.TrimSpace(name) != "" {
+				data.Name = strings.TrimSpace(name)
+			}
+		case "description":
+			if description, ok := value.(string); ok {
+				data.Description = description
+			}
+		}
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Entries").Save(&data).Error; err != nil {
+			return err
+		}
+		if movies, ok := updates["movies"].([]interface{}); ok {
+			return replaceCollectionEntries(tx, data.ID, utils.InterfacesToInts(movies))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save collection")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	if err := PG.loadCollectionEntries(&data); err != nil {
+		log.Error().Err(err).Msg("Error loading collection entries")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("collectionID", collectionID).Msg("Collection updated successfully")
+	return &data, nil
+}
+
+// CollectionGet godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Shows a collection
+// @Description Retrieves the collection with the specified ID with the full details of its movies, in viewing order. Available to both 'admin' and 'user' roles.
+// @Tags collection
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Collection ID"
+// @Success 200 {object} models.Collection "Successfully retrieved the collection"
+// @Failure 400 "Invalid collection ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Collection not found"
+// @Failure 500 "Error retrieving collection"
+// @Router /v1/collection/{id} [get]
+func (PG *Postgresql) CollectionGet(w http.ResponseWriter, r *http.Request) (*models.Collection, error) {
+	log.Info().Msg("CollectionGet called")
+
+	collectionID, err := idFromPath(w, r, "collection")
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Collection
+
+	if err := PG.DB.First(&data, "id = ?", collectionID).Error; err != nil {
+		log.Error().Err(err).Msg("Collection not found")
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	if err := PG.loadCollectionEntries(&data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving collection")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("collectionID", collectionID).Int("count", len(data.Entries)).Msg("Collection retrieved successfully")
+	return &data, nil
+}
+
+// CollectionList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists all collections
+// @Description Retrieves all collections ordered by name, each with its movies in viewing order. Movies are listed without their cast and crew; use CollectionGet for the full details. Available to both 'admin' and 'user' roles.
+// @Tags collection
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.Collection "Successfully retrieved all collections"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving collections"
+// @Router /v1/collection-list [get]
+func (PG *Postgresql) CollectionList(w http.ResponseWriter, r *http.Request) (*[]models.Collection, error) {
+	log.Info().Msg("CollectionList called")
+
+	var data []models.Collection
+
+	err := PG.DB.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Where("movie_id IN (" + activeMovieIDs + ")").Order("position")
+	}).Preload("Entries.Movie").Order("name").Find(&data).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving collections")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Successfully retrieved collections")
+	return &data, nil
+}
+
+// CollectionDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a collection
+// @Description Deletes the collection with the specified ID. The movies themselves are kept. Requires 'admin' role.
+// @Tags collection
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Collection ID"
+// @Success 200 "Successfully deleted the collection"
+// @Failure 400 "Invalid collection ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Collection not found"
+// @Failure 500 "Collection could not be deleted"
+// @Router /v1/collection-delete/{id} [delete]
+func (PG *Postgresql) CollectionDelete(w http.ResponseWriter, r *http.Request) (*models.Collection, error) {
+
+	log.Info().Msg("CollectionDelete called")
+
+	var data models.Collection
+
+	collectionID, err := idFromPath(w, r, "collection")
+	if err != nil {
+		return nil, err
+	}
+
+	errNotFound := errors.New("collection not found")
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&models.CollectionEntry{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", collectionID).Delete(&models.Collection{})
+		if result.Error == nil && result.RowsAffected == 0 {
+			return errNotFound
+		}
+		return result.Error
+	})
+	if errors.Is(err, errNotFound) {
+		log.Error().Int("collectionID", collectionID).Msg("Collection not found")
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return nil, err
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Collection could not be deleted")
+		http.Error(w, "Collection could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("collectionID", collectionID).Msg("Collection deleted successfully")
+	return &data, nil
+}
+
+// replaceCollectionEntries makes the given movies the contents of the collection, in order.
+// Duplicates after the first occurrence are ignored.
+func replaceCollectionEntries(tx *gorm.DB, collectionID int, movieIDs []int) error {
+	var ids []int
+	for _, id := range movieIDs {
+		if !utils.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	var count int64
+	if err := tx.Model(&models.Movie{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(ids) {
+		return fmt.Errorf("%w: movies %v", errUnknownIDs, ids)
+	}
+
+	if err := tx.Where("collection_id = ?", collectionID).Delete(&models.CollectionEntry{}).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	entries := make([]models.CollectionEntry, len(ids))
+	for i, id := range ids {
+		entries[i] = models.CollectionEntry{CollectionID: collectionID, MovieID: id, Position: i + 1}
+	}
+	return tx.Create(&entries).Error
+}
+
+// loadCollectionEntries fills the entries of the collection, in order, each with the full details of its movie.
+func (PG *Postgresql) loadCollectionEntries(collection *models.Collection) error {
+	var entries []*models.CollectionEntry
+	if err := PG.DB.Where("collection_id = ? AND movie_id IN ("+activeMovieIDs+")", collection.ID).Order("position").Find(&entries).Error; err != nil {
+		return err
+	}
+
+	movieIDs := make([]int, len(entries))
+	for i, entry := range entries {
+		movieIDs[i] = entry.MovieID
+	}
+	movies, err := PG.movieDetails(movieIDs)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entry.Movie = movies[entry.MovieID]
+	}
+
+	collection.Entries = entries
+	return nil
+}
+
+// loadMovieCollections sets the collections the movie belongs to, ordered by name, with its position in each.
+func (PG *Postgresql) loadMovieCollections(movie *models.Movie) error {
+	var placements []*models.CollectionPlacement
+	err := PG.DB.Table("collection_entries").
+		Select("collection_entries.collection_id, collections.name, collection_entries.position, "+
+			"(SELECT COUNT(*) FROM collection_entries AS siblings WHERE siblings.collection_id = collection_entries.collection_id) AS size").
+		Joins("JOIN collections ON collections.id = collection_entries.collection_id").
+		Where("collection_entries.movie_id = ?", movie.ID).
+		Order("collections.name").
+		Scan(&placements).Error
+	if err != nil {
+		return err
+	}
+
+	movie.Collections = placements
+	return nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// coStarMatches lists the supported semantics of a multi-actor search.
+// "all" keeps movies featuring every requested actor, "any" keeps movies featuring at least one of them.
+var coStarMatches = map[string]bool{"all": true, "any": true}
+
+// coStarByIDs builds a subquery selecting the IDs of movies featuring the given actors.
+// The actormovies rows are grouped per movie, so each movie is returned once no matter how many of the actors appear in it.
+// With the "all" semantics only the groups holding every distinct actor ID are kept. IDs of merged actors resolve to the actors they were merged into.
+func (PG *Postgresql) coStarByIDs(actorIDs []int, match string) *gorm.DB {
+	actorIDs = PG.resolveActorIDs(actorIDs)
+	distinctIDs := map[int]bool{}
+	for _, id := range actorIDs {
+		distinctIDs[id] = true
+	}
+
+	subquery := PG.DB.Table("actormovies").
+		Select("actormovies.movie_id").
+		Where("actormovies.actor_id IN ?", actorIDs).
+		Group("actormovies.movie_id")
+
+	if match == "all" {
+		subquery = subquery.Having("COUNT(DISTINCT actormovies.actor_id) = ?", len(distinctIDs))
+	}
+
+	return subquery
+}
+
+// coStarByNames builds a subquery selecting the IDs of movies featuring actors whose names contain the given fragments.
+// Each fragment is matched case-insensitively against the stored names and their translations. With the "all" semantics every fragment must be matched by at least one actor
+// of the movie, which is checked per movie group with bool_or. The fragments are matched independently, so a single actor whose name contains two of them satisfies both.
+func (PG *Postgresql) coStarByNames(fragments []string, match string) *gorm.DB {
+	conditions := make([]string, len(fragments))
+	args := make([]interface{}, len(fragments))
+	for i, fragment := range fragments {
+		conditions[i] = fmt.Sprintf("(%s)", actorNameMatches(fmt.Sprintf("@fragment%d", i)))
+		args[i] = sql.Named(fmt.Sprintf("fragment%d", i), "%"+fragment+"%")
+	}
+
+	subquery := PG.DB.Table("actormovies").
+		Select("actormovies.movie_id").
+		Joins("JOIN actors ON actors.id = actormovies.actor_id AND actors.deleted_at IS NULL").
+		Where(strings.Join(conditions, " OR "), args...).
+		Group("actormovies.movie_id")
+
+	if match == "all" {
+		having := make([]string, len(conditions))
+		for i, condition := range conditions {
+			having[i] = fmt.Sprintf("bool_or(%s)", condition)
+		}
+		subquery = subquery.Having(strings.Join(having, " AND "), args...)
+	}
+
+	return subquery
+}
+
+// actorNameMatches returns the condition matching actors whose stored name, any translation of it or any of their aliases is ILIKE the named parameter.
+func actorNameMatches(param string) string {
+	return fmt.Sprintf("actors.name ILIKE %[1]s OR actors.id IN (SELECT actor_id FROM actor_translations WHERE name ILIKE %[1]s)"+
+		" OR actors.id IN (SELECT actor_id FROM actor_aliases WHERE name ILIKE %[1]s)", param)
+}
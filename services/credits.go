@@ -0,0 +1,208 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gorm.io/gorm"
+	"vk.com/m/models"
+	"vk.com/m/utils"
+)
+
+// creditTypes lists the accepted values of Credit.CreditType.
+var creditTypes = map[string]bool{"lead": true, "supporting": true, "cameo": true, "voice": true}
+
+// castEntry is one element of the "actors" array of MovieEdit or the "movies" array of ActorEdit.
+// Elements are either a bare ID, which only links the two entities, or an object also carrying the credit.
+type castEntry struct {
+	id     int
+	credit map[string]interface{}
+}
+
+// parseCastEntries reads a JSON array mixing bare IDs and credit objects such as
+// {"id": 3, "character": "Stierlitz", "billingOrder": 1, "creditType": "lead"}.
+// Elements that are neither are skipped. Returns an error if a credit object has no ID, an unknown credit type
+// or a billing order that is not a whole number from 0.
+func parseCastEntries(values []interface{}) ([]castEntry, error) {
+	var entries []castEntry
+	for _, value := range values {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			if id, err := utils.InterfaceToInt(value); err == nil {
+				entries = append(entries, castEntry{id: id})
+			}
+			continue
+		}
+
+		id, err := utils.InterfaceToInt(object["id"])
+		if err != nil {
+			return nil, fmt.Errorf("credit without a valid id: %v", object)
+		}
+		if creditType, ok := object["creditType"]; ok {
+			if name, isString := creditType.(string); !isString || !creditTypes[name] {
+				return nil, fmt.Errorf("invalid credit type %v", creditType)
+			}
+		}
+		if billingOrder, ok := object["billingOrder"]; ok {
+			if _, valid := parseBillingOrder(billingOrder); !valid {
+				return nil, fmt.Errorf("invalid billing order %v, expected a whole number from 0", billingOrder)
+			}
+		}
+		entries = append(entries, castEntry{id: id, credit: object})
+	}
+	return entries, nil
+}
+
+// parseBillingOrder reads a billing order, a JSON number or an int set by MovieAdd, reporting whether it is a whole number from 0.
+func parseBillingOrder(value interface{}) (int, bool) {
+	switch order := value.(type) {
+	case float64:
+		return int(order), order >= 0 && order == math.Trunc(order)
+	case int:
+		return order, order >= 0
+	}
+	return 0, false
+}
+
+// castEntryIDs returns the IDs of the entries, in order.
+func castEntryIDs(entries []castEntry) []int {
+	ids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.id)
+	}
+	return ids
+}
+
+// applyCredits stores the credit fields given in the entries on the matching actormovies rows.
+// The link must already exist; rowKey returns the actor and movie IDs of the row an entry refers to.
+// Only the fields present in an entry are updated, so a partial object keeps the rest of the credit.
+func applyCredits(db *gorm.DB, entries []castEntry, rowKey func(entry castEntry) (actorID, movieID int)) error {
+	for _, entry := range entries {
+		if entry.credit == nil {
+			continue
+		}
+
+		updates := map[string]interface{}{}
+		if character, ok := entry.credit["character"].(string); ok {
+			updates["character"] = character
+		}
+		if billingOrder, ok := entry.credit["billingOrder"]; ok {
+			if order, valid := parseBillingOrder(billingOrder); valid {
+				updates["billing_order"] = order
+			}
+		}
+		if creditType, ok := entry.credit["creditType"].(string); ok {
+			updates["credit_type"] = creditType
+		}
+		if len(updates) == 0 {
+			continue
+		}
+
+		actorID, movieID := rowKey(entry)
+		if err := db.Model(&models.ActorMovie{}).Where("actor_id = ? AND movie_id = ?", actorID, movieID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCast replaces the Actors of each movie with its cast in billing order, each actor carrying its Credit.
+// Actors are copied per movie, since an actor appearing in several movies plays a different part in each.
+// Actors with an unknown billing order come last, ordered by ID.
+func (PG *Postgresql) loadCast(movies []models.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	movieIDs := make([]int, len(movies))
+	for i, movie := range movies {
+		movieIDs[i] = movie.ID
+	}
+
+	var links []models.ActorMovie
+	if err := PG.DB.Where("movie_id IN ?", movieIDs).Order("billing_order = 0, billing_order, actor_id").Find(&links).Error; err != nil {
+		return err
+	}
+
+	actorIDs := make([]int, 0, len(links))
+	for _, link := range links {
+		actorIDs = append(actorIDs, link.ActorID)
+	}
+	var actors []models.Actor
+	if err := PG.DB.Where("id IN ?", actorIDs).Find(&actors).Error; err != nil {
+		return err
+	}
+	actorsByID := make(map[int]models.Actor, len(actors))
+	for _, actor := range actors {
+		actorsByID[actor.ID] = actor
+	}
+
+	casts := make(map[int][]*models.Actor, len(movies))
+	for _, link := range links {
+		actor, ok := actorsByID[link.ActorID]
+		if !ok {
+			continue
+		}
+		credit := link.Credit
+		actor.Credit = &credit
+		casts[link.MovieID] = append(casts[link.MovieID], &actor)
+	}
+
+	for i := range movies {
+		movies[i].Actors = casts[movies[i].ID]
+	}
+	return nil
+}
+
+// loadFilmography replaces the Movies of each actor with the movies they appeared in, oldest first, each movie carrying the actor's Credit.
+// Movies are copied per actor for the same reason loadCast copies actors.
+func (PG *Postgresql) loadFilmography(actors []models.Actor) error {
+	if len(actors) == 0 {
+		return nil
+	}
+
+	actorIDs := make([]int, len(actors))
+	for i, actor := range actors {
+		actorIDs[i] = actor.ID
+	}
+
+	var links []models.ActorMovie
+	if err := PG.DB.Where("actor_id IN ?", actorIDs).Find(&links).Error; err != nil {
+		return err
+	}
+
+	movieIDs := make([]int, 0, len(links))
+	for _, link := range links {
+		movieIDs = append(movieIDs, link.MovieID)
+	}
+	var movies []models.Movie
+	if err := PG.DB.Where("id IN ?", movieIDs).Order("release_date, id").Find(&movies).Error; err != nil {
+		return err
+	}
+
+	position := make(map[int]int, len(movies))
+	for i, movie := range movies {
+		position[movie.ID] = i
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return position[links[i].MovieID] < position[links[j].MovieID]
+	})
+
+	filmographies := make(map[int][]*models.Movie, len(actors))
+	for _, link := range links {
+		index, ok := position[link.MovieID]
+		if !ok {
+			continue
+		}
+		movie := movies[index]
+		credit := link.Credit
+		movie.Credit = &credit
+		filmographies[link.ActorID] = append(filmographies[link.ActorID], &movie)
+	}
+
+	for i := range actors {
+		actors[i].Movies = filmographies[actors[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCastEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []interface{}
+		wantIDs []int
+		wantErr bool
+	}{
+		{"bare IDs", []interface{}{1.0, 2.0}, []int{1, 2}, false},
+		{"credit objects", []interface{}{map[string]interface{}{"id": 3.0, "character": "Stierlitz", "billingOrder": 1.0, "creditType": "lead"}}, []int{3}, false},
+		{"billing order zero", []interface{}{map[string]interface{}{"id": 3.0, "billingOrder": 0.0}}, []int{3}, false},
+		{"billing order set by MovieAdd", []interface{}{map[string]interface{}{"id": 3.0, "billingOrder": 2}}, []int{3}, false},
+		{"other elements are skipped", []interface{}{"x", 4.0}, []int{4}, false},
+		{"missing id", []interface{}{map[string]interface{}{"character": "Stierlitz"}}, nil, true},
+		{"unknown credit type", []interface{}{map[string]interface{}{"id": 3.0, "creditType": "extra"}}, nil, true},
+		{"negative billing order", []interface{}{map[string]interface{}{"id": 3.0, "billingOrder": -1.0}}, nil, true},
+		{"fractional billing order", []interface{}{map[string]interface{}{"id": 3.0, "billingOrder": 1.5}}, nil, true},
+		{"non-numeric billing order", []interface{}{map[string]interface{}{"id": 3.0, "billingOrder": "first"}}, nil, true},
+	}
+
+	for _, tt := range tests {
+		entries, err := parseCastEntries(tt.values)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: parseCastEntries error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(castEntryIDs(entries), tt.wantIDs) {
+			t.Errorf("%s: parseCastEntries IDs = %v, want %v", tt.name, castEntryIDs(entries), tt.wantIDs)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// crewJobs lists the accepted values of CrewCredit.Job.
+var crewJobs = map[string]bool{"director": true, "screenwriter": true, "composer": true, "cinematographer": true, "producer": true}
+
+// CrewAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Credits a person on a movie's crew
+// @Description Credits the person with the given actor ID on the movie for a job behind the camera. The job is one of director, screenwriter, composer, cinematographer or producer; a person can hold several jobs on the same movie. Requires 'admin' role.
+// @Tags crew
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param credit body models.CrewCredit true "Crew credit to add, with ActorID, MovieID and Job"
+// @Success 200 {object} models.CrewCredit "Successfully added the crew credit"
+// @Failure 400 "Invalid request body, job, person or movie"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "The person already holds this job on the movie"
+// @Failure 500 "Error creating crew credit"
+// @Router /v1/crew-add [post]
+func (PG *Postgresql) CrewAdd(w http.ResponseWriter, r *http.Request) (*models.CrewCredit, error) {
+
+	log.Info().Msg("CrewAdd called")
+
+	var data models.CrewCredit
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID, data.Person, data.Movie = 0, nil, nil
+
+	if !crewJobs[data.Job] {
+		log.Error().Str("job", data.Job).Msg("Invalid crew job")
+		http.Error(w, "Invalid job", http.StatusBadRequest)
+		return nil, fmt.Errorf("invalid crew job %q", data.Job)
+	}
+
+	data.ActorID = PG.resolveActorID(data.ActorID)
+	var person models.Actor
+	if err := PG.DB.First(&person, "id = ?", data.ActorID).Error; err != nil {
+		log.Error().Err(err).Msg("Person not found")
+		http.Error(w, "Person not found", http.StatusBadRequest)
+		return nil, err
+	}
+	var movie models.Movie
+	if err := PG.DB.First(&movie, "id = ?", data.MovieID).Error; err != nil {
+		log.Error().Err(err).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusBadRequest)
+		return nil, err
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating crew credit")
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "The person already holds this job on the movie", http.StatusConflict)
+			return nil, err
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	data.Person, data.Movie = &person, &movie
+
+	log.Info().Int("creditID", data.ID).Msg("Crew credit added successfully")
+	return &data, nil
+}
+
+// CrewDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Removes a crew credit
+// @Description Deletes the crew credit with the specified ID. The person and the movie are kept. Requires 'admin' role.
+// @Tags crew
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Crew credit ID"
+// @Success 200 "Successfully deleted the crew credit"
+// @Failure 400 "Invalid crew credit ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Crew credit not found"
+// @Failure 500 "Crew credit could not be deleted"
+// @Router /v1/crew-delete/{id} [delete]
+func (PG *Postgresql) CrewDelete(w http.ResponseWriter, r *http.Request) (*models.CrewCredit, error) {
+
+	log.Info().Msg("CrewDelete called")
+
+	var data models.CrewCredit
+
+	creditID, err := idFromPath(w, r, "crew credit")
+	if err != nil {
+		return nil, err
+	}
+
+	result := PG.DB.Where("id = ?", creditID).Delete(&models.CrewCredit{})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Crew credit could not be deleted")
+		http.Error(w, "Crew credit could not be deleted", http.StatusInternalServerError)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		log.Error().Int("creditID", creditID).Msg("Crew credit not found")
+		http.Error(w, "Crew credit not found", http.StatusNotFound)
+		return nil, errors.New("crew credit not found")
+	}
+
+	log.Info().Int("creditID", creditID).Msg("Crew credit deleted successfully")
+	return &data, nil
+}
+
+// preloadCrew loads the crew of the queried movies, grouped by job, together with the people credited.
+func preloadCrew(query *gorm.DB) *gorm.DB {
+	return query.Preload("Crew", func(db *gorm.DB) *gorm.DB {
+		return db.Where("actor_id IN (" + activeActorIDs + ")").Order("job, id")
+	}).Preload("Crew.Person")
+}
+
+// crewFilter narrows a movie query down to the movies whose crew matches the "crew", "job" and "director" parameters.
+// "crew" is a fragment of a crew member's name and "job" restricts the credits considered to one job; either can be used alone.
+// "director" is a shorthand for crew=...&job=director and can be combined with the other two.
+func (PG *Postgresql) crewFilter(query *gorm.DB, params url.Values) (*gorm.DB, error) {
+	name, job := params.Get("crew"), params.Get("job")
+
+	if job != "" && !crewJobs[job] {
+		return nil, fmt.Errorf("invalid job %q", job)
+	}
+	if name != "" || job != "" {
+		query = query.Where("movies.id IN (?)", PG.crewMovies(name, job))
+	}
+
+	if director := params.Get("director"); director != "" {
+		query = query.Where("movies.id IN (?)", PG.crewMovies(director, "director"))
+	}
+
+	return query, nil
+}
+
+// crewMovies builds a subquery selecting the IDs of movies crediting a person whose name, or a translation of it, contains the fragment,
+// matched case-insensitively, for the given job. An empty fragment or job matches any.
+func (PG *Postgresql) crewMovies(fragment, job string) *gorm.DB {
+	subquery := PG.DB.Table("crew_credits").
+		Select("crew_credits.movie_id").
+		Joins("JOIN actors ON actors.id = crew_credits.actor_id AND actors.deleted_at IS NULL")
+
+	if fragment != "" {
+		subquery = subquery.Where(actorNameMatches("@fragment"), sql.Named("fragment", "%"+fragment+"%"))
+	}
+	if job != "" {
+		subquery = subquery.Where("crew_credits.job = ?", job)
+	}
+
+	return subquery
+}
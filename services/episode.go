@@ -0,0 +1,455 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+	"vk.com/m/utils"
+)
+
+// SeasonAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a season to a series
+// @Description Adds a season with the given number and optional title to the series with the given SeriesID. Season numbers are unique within a series. Requires 'admin' role.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param season body models.Season true "Season to add"
+// @Success 200 {object} models.Season "Successfully added the season"
+// @Failure 400 "Invalid request body, season number or series"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "The series already has a season with this number"
+// @Failure 500 "Error creating season"
+// @Router /v1/season-add [post]
+func (PG *Postgresql) SeasonAdd(w http.ResponseWriter, r *http.Request) (*models.Season, error) {
+
+	log.Info().Msg("SeasonAdd called")
+
+	var data models.Season
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.Episodes = nil
+
+	if data.Number < 1 {
+		log.Error().Int("number", data.Number).Msg("Invalid season number")
+		http.Error(w, "Season number must be at least 1", http.StatusBadRequest)
+		return nil, errors.New("invalid season number")
+	}
+
+	if err := PG.DB.First(&models.Series{}, "id = ?", data.SeriesID).Error; err != nil {
+		log.Error().Err(err).Msg("Series not found")
+		http.Error(w, "Series not found", http.StatusBadRequest)
+		return nil, err
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating season")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("seasonID", data.ID).Msg("Season added successfully")
+	return &data, nil
+}
+
+// SeasonEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Edits an existing season
+// @Description Edits the season with the specified ID based on the given update fields, number and title. Season numbers are unique within a series. Requires 'admin' role.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Season ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Season "Successfully updated the season"
+// @Failure 400 "Invalid request body, season ID or season number"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Season not found"
+// @Failure 409 "The series already has a season with this number"
+// @Failure 500 "Failed to save season"
+// @Router /v1/season-edit/{id} [put]
+func (PG *Postgresql) SeasonEdit(w http.ResponseWriter, r *http.Request) (*models.Season, error) {
+
+	log.Info().Msg("SeasonEdit called")
+
+	var data models.Season
+
+	seasonID, err := idFromPath(w, r, "season")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", seasonID).Error; err != nil {
+		log.Error().Err(err).Msg("Season not found")
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "number":
+			number, err := utils.InterfaceToInt(value)
+			if err != nil || number < 1 {
+				log.Error().Interface("number", value).Msg("Invalid season number")
+				http.Error(w, "Season number must be at least 1", http.StatusBadRequest)
+				return nil, errors.New("invalid season number")
+			}
+			data.Number = number
+		case "title":
+			if title, ok := value.(string); ok {
+				data.Title = strings.TrimSpace(title)
+			}
+		}
+	}
+
+	if err := PG.DB.Omit("Episodes").Save(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save season")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("seasonID", seasonID).Msg("Season updated successfully")
+	return &data, nil
+}
+
+// SeasonDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a season
+// @Description Deletes the season with the specified ID together with its episodes and their guest star links. Requires 'admin' role.
+// @Tags series
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Season ID"
+// @Success 200 "Successfully deleted the season"
+// @Failure 400 "Invalid season ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Season not found or could not be deleted"
+// @Router /v1/season-delete/{id} [delete]
+func (PG *Postgresql) SeasonDelete(w http.ResponseWriter, r *http.Request) (*models.Season, error) {
+
+	log.Info().Msg("SeasonDelete called")
+
+	var data models.Season
+
+	seasonID, err := idFromPath(w, r, "season")
+	if err != nil {
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := deleteEpisodes(tx, tx.Model(&models.Episode{}).Select("id").Where("season_id = ?", seasonID)); err != nil {
+			return err
+		}
+		return tx.Where("id = ?", seasonID).Delete(&models.Season{}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Season not found or could not be deleted")
+		http.Error(w, "Season not found or could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("seasonID", seasonID).Msg("Season deleted successfully")
+	return &data, nil
+}
+
+// EpisodeAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds an episode to a season
+// @Description Adds an episode with the given number, title and air date to the season with the given SeasonID. Guest stars are linked by actor ID and must already exist. Requires 'admin' role.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param episode body models.Episode true "Episode to add"
+// @Success 200 {object} models.Episode "Successfully added the episode"
+// @Failure 400 "Invalid request body, episode number, empty title, air date, unknown season or unknown guest star IDs"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "The season already has an episode with this number"
+// @Failure 500 "Error creating episode"
+// @Router /v1/episode-add [post]
+func (PG *Postgresql) EpisodeAdd(w http.ResponseWriter, r *http.Request) (*models.Episode, error) {
+
+	log.Info().Msg("EpisodeAdd called")
+
+	var data models.Episode
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	data.Title = strings.TrimSpace(data.Title)
+	if data.Title == "" || data.Number < 1 {
+		log.Error().Int("number", data.Number).Msg("Invalid episode")
+		http.Error(w, "Episode title is required and number must be at least 1", http.StatusBadRequest)
+		return nil, errors.New("invalid episode")
+	}
+	airDate, err := optionalDate("air date", data.AirDate)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid air date")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.AirDate = airDate
+
+	if err := PG.DB.First(&models.Season{}, "id = ?", data.SeasonID).Error; err != nil {
+		log.Error().Err(err).Msg("Season not found")
+		http.Error(w, "Season not found", http.StatusBadRequest)
+		return nil, err
+	}
+
+	guestStarIDs := PG.resolveActorIDs(actorIDsOf(data.GuestStars))
+	data.GuestStars = nil
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&data).Error; err != nil {
+			return err
+		}
+		_, _, err := syncAssociation[models.Actor](tx, &data, "GuestStars", nil, guestStarIDs)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating episode")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("episodeID", data.ID).Msg("Episode added successfully")
+	return &data, nil
+}
+
+// EpisodeEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Edits an existing episode
+// @Description Edits the episode with the specified ID based on the given update fields such as title, number, airdate and guestStars (an array of actor IDs). Requires 'admin' role.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Episode ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Episode "Successfully updated the episode"
+// @Failure 400 "Invalid request body, episode ID, air date or unknown guest star IDs"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Episode not found"
+// @Failure 409 "The season already has an episode with this number"
+// @Failure 500 "Failed to save episode"
+// @Router /v1/episode-edit/{id} [put]
+func (PG *Postgresql) EpisodeEdit(w http.ResponseWriter, r *http.Request) (*models.Episode, error) {
+
+	log.Info().Msg("EpisodeEdit called")
+
+	var data models.Episode
+
+	episodeID, err := idFromPath(w, r, "episode")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.Preload("GuestStars").First(&data, "id = ?", episodeID).Error; err != nil {
+		log.Error().Err(err).Msg("Episode not found")
+		http.Error(w, "Episode not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "title":
+			if title, ok := value.(string); ok && strings.TrimSpace(title) != "" {
+				data.Title = strings.TrimSpace(title)
+			}
+		case "number":
+			if number, err := utils.InterfaceToInt(value); err == nil && number >= 1 {
+				data.Number = number
+			}
+		case "airdate":
+			if airDate, ok := value.(string); ok {
+				if data.AirDate, err = optionalDate("air date", airDate); err != nil {
+					log.Error().Err(err).Msg("Invalid air date")
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return nil, err
+				}
+			}
+		}
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if guestStars, ok := updates["guestStars"].([]interface{}); ok {
+			guestStarIDs := PG.resolveActorIDs(utils.InterfacesToInts(guestStars))
+			_, _, err := syncAssociation[models.Actor](tx, &data, "GuestStars", actorIDsOf(data.GuestStars), guestStarIDs)
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Omit("GuestStars").Save(&data).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save episode")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	if err := PG.DB.Model(&data).Association("GuestStars").Find(&data.GuestStars); err != nil {
+		log.Error().Err(err).Msg("Error loading guest stars")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("episodeID", episodeID).Msg("Episode updated successfully")
+	return &data, nil
+}
+
+// EpisodeDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes an episode
+// @Description Deletes the episode with the specified ID and its guest star links. Requires 'admin' role.
+// @Tags series
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Episode ID"
+// @Success 200 "Successfully deleted the episode"
+// @Failure 400 "Invalid episode ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Episode not found or could not be deleted"
+// @Router /v1/episode-delete/{id} [delete]
+func (PG *Postgresql) EpisodeDelete(w http.ResponseWriter, r *http.Request) (*models.Episode, error) {
+
+	log.Info().Msg("EpisodeDelete called")
+
+	var data models.Episode
+
+	episodeID, err := idFromPath(w, r, "episode")
+	if err != nil {
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		return deleteEpisodes(tx, []int{episodeID})
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Episode not found or could not be deleted")
+		http.Error(w, "Episode not found or could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("episodeID", episodeID).Msg("Episode deleted successfully")
+	return &data, nil
+}
+
+// deleteEpisodes deletes the given episodes and their guest star links.
+// episodeIDs is either a list of IDs or a subquery selecting them.
+func deleteEpisodes(tx *gorm.DB, episodeIDs interface{}) error {
+	if err := tx.Exec("DELETE FROM episodeactors WHERE episode_id IN (?)", episodeIDs).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN (?)", episodeIDs).Delete(&models.Episode{}).Error
+}
+
+// actorIDsOf returns the IDs of the given actors.
+func actorIDsOf(actors []*models.Actor) []int {
+	ids := make([]int, 0, len(actors))
+	for _, actor := range actors {
+		ids = append(ids, actor.ID)
+	}
+	return ids
+}
+
+// loadSeriesCredits fills the SeriesCredits of each actor with the episodes they guest starred in,
+// grouped by series in title order, and in airing order within a series.
+func (PG *Postgresql) loadSeriesCredits(actors []models.Actor) error {
+	if len(actors) == 0 {
+		return nil
+	}
+
+	actorIDs := make([]int, len(actors))
+	for i, actor := range actors {
+		actorIDs[i] = actor.ID
+	}
+
+	var rows []struct {
+		ActorID       int
+		SeriesID      int
+		SeriesTitle   string
+		SeasonNumber  int
+		EpisodeID     int
+		EpisodeNumber int
+		EpisodeTitle  string
+	}
+	err := PG.DB.Table("episodeactors").
+		Select("episodeactors.actor_id, series.id AS series_id, series.title AS series_title, seasons.number AS season_number, "+
+			"episodes.id AS episode_id, episodes.number AS episode_number, episodes.title AS episode_title").
+		Joins("JOIN episodes ON episodes.id = episodeactors.episode_id").
+		Joins("JOIN seasons ON seasons.id = episodes.season_id").
+		Joins("JOIN series ON series.id = seasons.series_id").
+		Where("episodeactors.actor_id IN ?", actorIDs).
+		Order("series.title, series.id, seasons.number, episodes.number").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	credits := make(map[int][]*models.SeriesCredit, len(actors))
+	for _, row := range rows {
+		actorCredits := credits[row.ActorID]
+		if len(actorCredits) == 0 || actorCredits[len(actorCredits)-1].SeriesID != row.SeriesID {
+			actorCredits = append(actorCredits, &models.SeriesCredit{SeriesID: row.SeriesID, Title: row.SeriesTitle})
+			credits[row.ActorID] = actorCredits
+		}
+		current := actorCredits[len(actorCredits)-1]
+		current.Episodes = append(current.Episodes, models.EpisodeCredit{
+			EpisodeID: row.EpisodeID,
+			Season:    row.SeasonNumber,
+			Number:    row.EpisodeNumber,
+			Title:     row.EpisodeTitle,
+		})
+	}
+
+	for i := range actors {
+		actors[i].SeriesCredits = credits[actors[i].ID]
+	}
+	return nil
+}
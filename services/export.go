@@ -0,0 +1,214 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/graphexport"
+	"vk.com/m/models"
+)
+
+// GraphProjections lists the graphs that can be exported, by the name given as the projection parameter.
+// "bipartite" connects actors to the movies they appeared in, "actors" connects actors who appeared together,
+// weighted by the number of shared movies.
+var GraphProjections = map[string]bool{"bipartite": true, "actors": true}
+
+// movieFilterParams lists the MovieFind parameters that restrict an export to a subset of the catalogue.
+var movieFilterParams = []string{"title", "actor", "actorIds", "decade", "rating", "actorId", "genre", "tag", "crew", "job", "director", "awardWinner", "studio", "country", "language", "certification", "runtimeMin", "runtimeMax"}
+
+// GraphExport godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Exports the actor-movie network
+// @Description Streams the actor/movie bipartite graph, or the weighted actor co-appearance graph, as GraphML, GEXF or DOT. Accepts the MovieFind search parameters to export only part of the catalogue. Available to both 'admin' and 'user' roles.
+// @Tags graph
+// @Produce xml
+// @Produce text/vnd.graphviz
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param format query string false "Output format [graphml|gexf|dot] (default: 'graphml')"
+// @Param projection query string false "Graph to export [bipartite|actors] (default: 'bipartite')"
+// @Param title query string false "Only export movies whose title contains this fragment"
+// @Param actor query []string false "Only export movies featuring actors whose names contain these fragments" collectionFormat(multi)
+// @Param actorIds query string false "Only export movies featuring these comma separated actor IDs"
+// @Param match query string false "Co-star semantics [all|any] (default: 'all')"
+// @Success 200 {string} string "The graph document"
+// @Failure 400 "Invalid format, projection or filter"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error exporting the graph"
+// @Router /v1/graph-export [get]
+func (PG *Postgresql) GraphExport(w http.ResponseWriter, r *http.Request) error {
+	log.Info().Msg("GraphExport called")
+
+	params := r.URL.Query()
+
+	format := params.Get("format")
+	if format == "" {
+		format = "graphml"
+	}
+	projection := params.Get("projection")
+	if projection == "" {
+		projection = "bipartite"
+	}
+
+	spec, ok := graphexport.Formats[format]
+	if !ok || !GraphProjections[projection] {
+		log.Error().Str("format", format).Str("projection", projection).Msg("Invalid export parameters")
+		http.Error(w, "Invalid format or projection", http.StatusBadRequest)
+		return errors.New("invalid format or projection")
+	}
+
+	if _, err := PG.movieFindQuery(params); err != nil {
+		log.Error().Err(err).Msg("Invalid search parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", spec.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="catalogue-%s.%s"`, projection, spec.Extension))
+
+	if err := PG.WriteGraph(w, format, projection, params); err != nil {
+		log.Error().Err(err).Msg("Error exporting the graph")
+		return err
+	}
+
+	log.Info().Str("format", format).Str("projection", projection).Msg("Graph exported successfully")
+	return nil
+}
+
+// WriteGraph streams the actor/movie network in the given format to out.
+// The projection selects the bipartite actor-movie graph or the weighted actor co-appearance graph.
+// params holds MovieFind search parameters; when any of them is set only the matching movies and their cast are exported,
+// otherwise the whole catalogue is, including actors without movies.
+// Nodes and edges are read row by row from the database and written as they arrive, so memory use does not grow with the catalogue.
+func (PG *Postgresql) WriteGraph(out io.Writer, format, projection string, params url.Values) error {
+	if !GraphProjections[projection] {
+		return fmt.Errorf("unsupported projection %q", projection)
+	}
+
+	movies, err := PG.movieFindQuery(params)
+	if err != nil {
+		return err
+	}
+	movieIDs := movies.Select("movies.id")
+
+	actors := PG.DB.Model(&models.Actor{}).Select("id, name").Order("id")
+	if isMovieFiltered(params) {
+		actors = actors.Where("id IN (?)", PG.DB.Table("actormovies").Select("actor_id").Where("movie_id IN (?)", movieIDs))
+	}
+
+	writer, err := graphexport.NewWriter(format, out)
+	if err != nil {
+		return err
+	}
+
+	err = streamRows(actors, func(rows *sql.Rows) error {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		return writer.Node(graphexport.Node{ID: actorNodeID(id), Label: name, Kind: "actor"})
+	})
+	if err != nil {
+		return err
+	}
+
+	if projection == "bipartite" {
+		err = PG.writeBipartiteGraph(writer, movieIDs)
+	} else {
+		err = PG.writeActorGraph(writer, movieIDs)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// writeBipartiteGraph writes the movie nodes followed by one edge per actormovies row.
+func (PG *Postgresql) writeBipartiteGraph(writer graphexport.Writer, movieIDs *gorm.DB) error {
+	movies := PG.DB.Model(&models.Movie{}).Select("id, title").Where("id IN (?)", movieIDs).Order("id")
+	err := streamRows(movies, func(rows *sql.Rows) error {
+		var id int
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			return err
+		}
+		return writer.Node(graphexport.Node{ID: movieNodeID(id), Label: title, Kind: "movie"})
+	})
+	if err != nil {
+		return err
+	}
+
+	links := PG.DB.Table("actormovies").Select("actor_id, movie_id").
+		Where("movie_id IN (?) AND actor_id IN ("+activeActorIDs+")", movieIDs).Order("movie_id, actor_id")
+	return streamRows(links, func(rows *sql.Rows) error {
+		var actorID, movieID int
+		if err := rows.Scan(&actorID, &movieID); err != nil {
+			return err
+		}
+		return writer.Edge(graphexport.Edge{Source: actorNodeID(actorID), Target: movieNodeID(movieID), Weight: 1})
+	})
+}
+
+// writeActorGraph writes one edge per pair of actors who appeared together, weighted by their number of shared movies.
+// Each pair is emitted once, with the lower actor ID as the source.
+func (PG *Postgresql) writeActorGraph(writer graphexport.Writer, movieIDs *gorm.DB) error {
+	pairs := PG.DB.Table("actormovies AS a1").
+		Select("a1.actor_id, a2.actor_id, COUNT(DISTINCT a1.movie_id)").
+		Joins("JOIN actormovies AS a2 ON a2.movie_id = a1.movie_id AND a2.actor_id > a1.actor_id").
+		Where("a1.movie_id IN (?) AND a1.actor_id IN ("+activeActorIDs+") AND a2.actor_id IN ("+activeActorIDs+")", movieIDs).
+		Group("a1.actor_id, a2.actor_id").
+		Order("a1.actor_id, a2.actor_id")
+
+	return streamRows(pairs, func(rows *sql.Rows) error {
+		var sourceID, targetID, weight int
+		if err := rows.Scan(&sourceID, &targetID, &weight); err != nil {
+			return err
+		}
+		return writer.Edge(graphexport.Edge{Source: actorNodeID(sourceID), Target: actorNodeID(targetID), Weight: weight})
+	})
+}
+
+// streamRows runs the query and calls each for every row, closing the cursor when done.
+func streamRows(query *gorm.DB, each func(rows *sql.Rows) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := each(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// isMovieFiltered reports whether any MovieFind search parameter is set.
+func isMovieFiltered(params url.Values) bool {
+	for _, name := range movieFilterParams {
+		if params.Get(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func actorNodeID(id int) string {
+	return fmt.Sprintf("a%d", id)
+}
+
+func movieNodeID(id int) string {
+	return fmt.Sprintf("m%d", id)
+}
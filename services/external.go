@@ -0,0 +1,415 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// externalIDFormat holds the patterns the identifiers of one external database follow for movies and for people.
+type externalIDFormat struct {
+	movie  *regexp.Regexp
+	person *regexp.Regexp
+}
+
+// externalIDFormats lists the external databases identifiers can be recorded for.
+var externalIDFormats = map[string]externalIDFormat{
+	"imdb":      {regexp.MustCompile(`^tt\d{7,8}$`), regexp.MustCompile(`^nm\d{7,8}$`)},
+	"kinopoisk": {regexp.MustCompile(`^[1-9]\d{0,8}$`), regexp.MustCompile(`^[1-9]\d{0,8}$`)},
+	"wikidata":  {regexp.MustCompile(`^Q[1-9]\d*$`), regexp.MustCompile(`^Q[1-9]\d*$`)},
+}
+
+// errExternalIDTaken reports an external identifier that is already recorded for another movie or person.
+var errExternalIDTaken = errors.New("external ID already recorded")
+
+// normalizeExternalID checks the identifier against the format of its source, after lower-casing the source
+// and bringing the value to its canonical case: "tt0111161" for IMDb and "Q172241" for Wikidata.
+func normalizeExternalID(source, value string, person bool) (string, string, error) {
+	source = strings.ToLower(strings.TrimSpace(source))
+	value = strings.TrimSpace(value)
+
+	format, ok := externalIDFormats[source]
+	if !ok {
+		return "", "", fmt.Errorf("unknown external ID source %q, expected imdb, kinopoisk or wikidata", source)
+	}
+	switch source {
+	case "imdb":
+		value = strings.ToLower(value)
+	case "wikidata":
+		value = strings.ToUpper(value)
+	}
+
+	pattern := format.movie
+	if person {
+		pattern = format.person
+	}
+	if !pattern.MatchString(value) {
+		return "", "", fmt.Errorf("invalid %s ID %q", source, value)
+	}
+	return source, value, nil
+}
+
+// validExternalIDs normalizes the external identifiers given with a new movie or person, allowing one per source.
+func validExternalIDs(ids []*models.ExternalID, person bool) error {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		source, value, err := normalizeExternalID(id.Source, id.Value, person)
+		if err != nil {
+			return err
+		}
+		if seen[source] {
+			return fmt.Errorf("more than one %s ID", source)
+		}
+		seen[source] = true
+		id.ID, id.Source, id.Value, id.MovieID, id.ActorID = 0, source, value, nil, nil
+	}
+	return nil
+}
+
+// externalIDOwner describes the movie or person an identifier is recorded for.
+func externalIDOwner(id models.ExternalID) string {
+	if id.MovieID != nil {
+		return fmt.Sprintf("movie %d", *id.MovieID)
+	}
+	return fmt.Sprintf("actor %d", *id.ActorID)
+}
+
+// preloadExternalIDs adds the external identifiers of the movies or people to the query.
+func preloadExternalIDs(query *gorm.DB) *gorm.DB {
+	return query.Preload("ExternalIDs", func(db *gorm.DB) *gorm.DB {
+		return db.Order("source")
+	})
+}
+
+// MovieExternalIDSet godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Records an external ID of a movie
+// @Description Records the identifier of the movie with the specified ID in an external database, replacing its previous identifier from the same source. Sources are imdb (tt0111161), kinopoisk (326) and wikidata (Q172241). Setting an identifier the movie already has is a no-op. Requires 'admin' role.
+// @Tags external
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Param externalId body models.ExternalID true "Source and identifier"
+// @Success 200 {object} models.ExternalID "Successfully recorded the identifier"
+// @Failure 400 "Invalid request body, movie ID, source or identifier format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Movie not found"
+// @Failure 409 "The identifier is already recorded for another movie or person"
+// @Failure 500 "Error saving identifier"
+// @Router /v1/movie-external-id/{id} [put]
+func (PG *Postgresql) MovieExternalIDSet(w http.ResponseWriter, r *http.Request) (*models.ExternalID, error) {
+	log.Info().Msg("MovieExternalIDSet called")
+	return PG.setExternalID(w, r, "movie")
+}
+
+// ActorExternalIDSet godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Records an external ID of an actor
+// @Description Records the identifier of the actor with the specified ID in an external database, replacing their previous identifier from the same source. Sources are imdb (nm0000209), kinopoisk (7987) and wikidata (Q48337). Setting an identifier the actor already has is a no-op. Requires 'admin' role.
+// @Tags external
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Param externalId body models.ExternalID true "Source and identifier"
+// @Success 200 {object} models.ExternalID "Successfully recorded the identifier"
+// @Failure 400 "Invalid request body, actor ID, source or identifier format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Actor not found"
+// @Failure 409 "The identifier is already recorded for another movie or person"
+// @Failure 500 "Error saving identifier"
+// @Router /v1/actor-external-id/{id} [put]
+func (PG *Postgresql) ActorExternalIDSet(w http.ResponseWriter, r *http.Request) (*models.ExternalID, error) {
+	log.Info().Msg("ActorExternalIDSet called")
+	return PG.setExternalID(w, r, "actor")
+}
+
+// setExternalID records the identifier in the request body for the movie or actor in the path.
+func (PG *Postgresql) setExternalID(w http.ResponseWriter, r *http.Request, entity string) (*models.ExternalID, error) {
+	ownerID, err := idFromPath(w, r, entity)
+	if err != nil {
+		return nil, err
+	}
+	if entity == "actor" {
+		ownerID = PG.resolveActorID(ownerID)
+	}
+
+	var data models.ExternalID
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	data.Source, data.Value, err = normalizeExternalID(data.Source, data.Value, entity == "actor")
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid external ID")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	var owner interface{} = &models.Movie{}
+	column := "movie_id"
+	data.MovieID = &ownerID
+	if entity == "actor" {
+		owner, column = &models.Actor{}, "actor_id"
+		data.MovieID, data.ActorID = nil, &ownerID
+	}
+
+	if err := PG.DB.Select("id").First(owner, "id = ?", ownerID).Error; err != nil {
+		log.Error().Err(err).Msgf("%s not found", entity)
+		http.Error(w, strings.ToUpper(entity[:1])+entity[1:]+" not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(column+" = ? AND source = ?", ownerID, data.Source).Delete(&models.ExternalID{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&data).Error
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		message := data.Source + " " + data.Value + " is already recorded for another movie or person"
+		var existing models.ExternalID
+		if PG.DB.Where("source = ? AND value = ?", data.Source, data.Value).First(&existing).Error == nil {
+			message = data.Source + " " + data.Value + " already belongs to " + externalIDOwner(existing)
+		}
+		log.Error().Str("source", data.Source).Str("value", data.Value).Msg("External ID already recorded for another entity")
+		http.Error(w, message, http.StatusConflict)
+		return nil, fmt.Errorf("%w: %s", errExternalIDTaken, message)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Error saving external ID")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("id", ownerID).Str("source", data.Source).Str("value", data.Value).Msgf("External ID of %s saved successfully", entity)
+	return &data, nil
+}
+
+// MovieExternalIDDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Removes an external ID of a movie
+// @Description Removes the identifier of the movie with the specified ID in the given external database. Requires 'admin' role.
+// @Tags external
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Param source query string true "External database [imdb|kinopoisk|wikidata]"
+// @Success 200 "Successfully removed the identifier"
+// @Failure 400 "Invalid movie ID or source"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "The movie has no identifier from this source"
+// @Failure 500 "Identifier could not be removed"
+// @Router /v1/movie-external-id-delete/{id} [delete]
+func (PG *Postgresql) MovieExternalIDDelete(w http.ResponseWriter, r *http.Request) (*models.ExternalID, error) {
+	log.Info().Msg("MovieExternalIDDelete called")
+	return PG.deleteExternalID(w, r, "movie")
+}
+
+// ActorExternalIDDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Removes an external ID of an actor
+// @Description Removes the identifier of the actor with the specified ID in the given external database. Requires 'admin' role.
+// @Tags external
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Param source query string true "External database [imdb|kinopoisk|wikidata]"
+// @Success 200 "Successfully removed the identifier"
+// @Failure 400 "Invalid actor ID or source"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "The actor has no identifier from this source"
+// @Failure 500 "Identifier could not be removed"
+// @Router /v1/actor-external-id-delete/{id} [delete]
+func (PG *Postgresql) ActorExternalIDDelete(w http.ResponseWriter, r *http.Request) (*models.ExternalID, error) {
+	log.Info().Msg("ActorExternalIDDelete called")
+	return PG.deleteExternalID(w, r, "actor")
+}
+
+// deleteExternalID removes the identifier from the source in the query string of the movie or actor in the path.
+func (PG *Postgresql) deleteExternalID(w http.ResponseWriter, r *http.Request, entity string) (*models.ExternalID, error) {
+	var data models.ExternalID
+
+	ownerID, err := idFromPath(w, r, entity)
+	if err != nil {
+		return nil, err
+	}
+	if entity == "actor" {
+		ownerID = PG.resolveActorID(ownerID)
+	}
+
+	source := strings.ToLower(r.URL.Query().Get("source"))
+	if _, ok := externalIDFormats[source]; !ok {
+		log.Error().Str("source", source).Msg("Unknown external ID source")
+		http.Error(w, "Unknown external ID source", http.StatusBadRequest)
+		return nil, errors.New("unknown external ID source")
+	}
+
+	result := PG.DB.Where(entity+"_id = ? AND source = ?", ownerID, source).Delete(&models.ExternalID{})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("External ID could not be removed")
+		http.Error(w, "External ID could not be removed", http.StatusInternalServerError)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		log.Error().Int("id", ownerID).Str("source", source).Msg("External ID not found")
+		http.Error(w, "The "+entity+" has no identifier from this source", http.StatusNotFound)
+		return nil, errors.New("external ID not found")
+	}
+
+	log.Info().Int("id", ownerID).Str("source", source).Msgf("External ID of %s removed successfully", entity)
+	return &data, nil
+}
+
+// lookupExternalID finds the ID of the movie or actor recorded for the "source" and "id" query parameters.
+// It answers the request with 400 Bad Request for a malformed identifier and 404 Not Found for an unknown one.
+func (PG *Postgresql) lookupExternalID(w http.ResponseWriter, r *http.Request, entity string) (int, error) {
+	source, value, err := normalizeExternalID(r.URL.Query().Get("source"), r.URL.Query().Get("id"), entity == "actor")
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid external ID")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return 0, err
+	}
+
+	var ids []int
+	err = PG.DB.Model(&models.ExternalID{}).Where("source = ? AND value = ? AND "+entity+"_id IS NOT NULL", source, value).
+		Pluck(entity+"_id", &ids).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error looking up external ID")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return 0, err
+	}
+	if len(ids) == 0 {
+		log.Error().Str("source", source).Str("value", value).Msgf("No %s with this external ID", entity)
+		http.Error(w, "No "+entity+" with this "+source+" ID", http.StatusNotFound)
+		return 0, errors.New("unknown external ID")
+	}
+	return ids[0], nil
+}
+
+// MovieLookup godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Finds a movie by external ID
+// @Description Retrieves the movie recorded under the given identifier of an external database, e.g. its IMDb ID, with its cast, crew, genres, tags, production details and external IDs. The response is localized for the Accept-Language header. Available to both 'admin' and 'user' roles.
+// @Tags external
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param Accept-Language header string false "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'"
+// @Param source query string true "External database [imdb|kinopoisk|wikidata]"
+// @Param id query string true "Identifier in the external database, e.g. 'tt0111161'"
+// @Success 200 {object} models.Movie "Successfully found the movie"
+// @Failure 400 "Invalid source or identifier format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "No movie with this identifier"
+// @Failure 500 "Error retrieving movie"
+// @Router /v1/movie-lookup [get]
+func (PG *Postgresql) MovieLookup(w http.ResponseWriter, r *http.Request) (*models.Movie, error) {
+	log.Info().Msg("MovieLookup called")
+
+	movieID, err := PG.lookupExternalID(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := PG.movieDetails([]int{movieID})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving movie")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	data, ok := movies[movieID]
+	if !ok {
+		log.Error().Int("movieID", movieID).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusNotFound)
+		return nil, errors.New("movie not found")
+	}
+
+	log.Info().Int("movieID", movieID).Msg("Movie found by external ID")
+	return data, nil
+}
+
+// ActorLookup godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Finds an actor by external ID
+// @Description Retrieves the actor recorded under the given identifier of an external database, e.g. their IMDb ID, with their filmography, crew credits, guest appearances, award nominations, aliases and external IDs. The response is localized for the Accept-Language header. Available to both 'admin' and 'user' roles.
+// @Tags external
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param Accept-Language header string false "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'"
+// @Param source query string true "External database [imdb|kinopoisk|wikidata]"
+// @Param id query string true "Identifier in the external database, e.g. 'nm0000209'"
+// @Success 200 {object} models.Actor "Successfully found the actor"
+// @Failure 400 "Invalid source or identifier format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "No actor with this identifier"
+// @Failure 500 "Error retrieving actor"
+// @Router /v1/actor-lookup [get]
+func (PG *Postgresql) ActorLookup(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
+	log.Info().Msg("ActorLookup called")
+
+	actorID, err := PG.lookupExternalID(w, r, "actor")
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.Actor
+	if err := preloadAliases(preloadExternalIDs(PG.DB)).Preload("CrewCredits", func(db *gorm.DB) *gorm.DB {
+		return db.Where("movie_id IN (" + activeMovieIDs + ")").Order("job, id")
+	}).Preload("CrewCredits.Movie").Where("id = ?", actorID).Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving actor")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if len(data) == 0 {
+		log.Error().Int("actorID", actorID).Msg("Actor not found")
+		http.Error(w, "Actor not found", http.StatusNotFound)
+		return nil, errors.New("actor not found")
+	}
+
+	if err := PG.loadFilmography(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving filmography")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if err := PG.loadSeriesCredits(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving series credits")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if err := PG.loadActorAwards(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving award nominations")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("actorID", actorID).Msg("Actor found by external ID")
+	return &data[0], nil
+}
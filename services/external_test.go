@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"vk.com/m/models"
+)
+
+func TestNormalizeExternalID(t *testing.T) {
+	tests := []struct {
+		source, value string
+		person        bool
+		wantSource    string
+		wantValue     string
+		wantErr       bool
+	}{
+		{"imdb", "tt0111161", false, "imdb", "tt0111161", false},
+		{" IMDb ", " TT0111161 ", false, "imdb", "tt0111161", false},
+		{"imdb", "nm0000209", true, "imdb", "nm0000209", false},
+		{"imdb", "nm0000209", false, "", "", true},
+		{"imdb", "tt0111161", true, "", "", true},
+		{"imdb", "tt01", false, "", "", true},
+		{"kinopoisk", "326", false, "kinopoisk", "326", false},
+		{"kinopoisk", "0326", false, "", "", true},
+		{"wikidata", "q172241", false, "wikidata", "Q172241", false},
+		{"wikidata", "Q0", false, "", "", true},
+		{"tmdb", "278", false, "", "", true},
+	}
+
+	for _, tt := range tests {
+		source, value, err := normalizeExternalID(tt.source, tt.value, tt.person)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("normalizeExternalID(%q, %q, %v) error = %v, want error %v", tt.source, tt.value, tt.person, err, tt.wantErr)
+			continue
+		}
+		if source != tt.wantSource || value != tt.wantValue {
+			t.Errorf("normalizeExternalID(%q, %q, %v) = %q, %q, want %q, %q", tt.source, tt.value, tt.person, source, value, tt.wantSource, tt.wantValue)
+		}
+	}
+}
+
+func TestValidExternalIDs(t *testing.T) {
+	movieID := 7
+	ids := []*models.ExternalID{
+		{ID: 3, Source: "IMDB", Value: "tt0111161", MovieID: &movieID},
+		{Source: "wikidata", Value: "q172241"},
+	}
+	if err := validExternalIDs(ids, false); err != nil {
+		t.Fatalf("validExternalIDs returned error: %v", err)
+	}
+	if got := *ids[0]; got.ID != 0 || got.Source != "imdb" || got.MovieID != nil || ids[1].Value != "Q172241" {
+		t.Errorf("validExternalIDs left %+v, %+v, want normalized identifiers without IDs or owners", got, *ids[1])
+	}
+
+	twice := []*models.ExternalID{{Source: "imdb", Value: "tt0111161"}, {Source: "imdb", Value: "tt0068646"}}
+	if err := validExternalIDs(twice, false); err == nil {
+		t.Error("validExternalIDs accepted two IMDb IDs")
+	}
+}
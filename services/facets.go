@@ -0,0 +1,175 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// requestedFacets parses the comma separated "facets" query parameter.
+// Unknown facet names are ignored. Returns nil when no facets were requested.
+func requestedFacets(r *http.Request) []string {
+	param := r.URL.Query().Get("facets")
+	if param == "" {
+		return nil
+	}
+
+	var facets []string
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "decade", "rating", "actor", "genre", "tag":
+			facets = append(facets, name)
+		default:
+			log.Warn().Str("facet", name).Msg("Unknown facet requested")
+		}
+	}
+
+	return facets
+}
+
+// applyDrillDown narrows the movie query by the facet values selected by the client.
+// It understands the "decade", "rating", "actorId", "genre" and "tag" query parameters, each holding a value previously returned in a facet bucket.
+// Genre and tag names are matched case-insensitively and may be repeated; a movie must then carry every one of them.
+// Returns an error if one of the values cannot be parsed.
+func (PG *Postgresql) applyDrillDown(query *gorm.DB, params url.Values) (*gorm.DB, error) {
+	if decadeStr := params.Get("decade"); decadeStr != "" {
+		decade, err := parseDecade(decadeStr)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("movies.release_date >= ? AND movies.release_date < ?",
+			fmt.Sprintf("%04d-01-01", decade), fmt.Sprintf("%04d-01-01", decade+10))
+	}
+
+	if bandStr := params.Get("rating"); bandStr != "" {
+		band, err := parseRatingBand(bandStr)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("movies.rating >= ? AND movies.rating < ?", band, band+1)
+	}
+
+	if actorIDStr := params.Get("actorId"); actorIDStr != "" {
+		actorID, err := strconv.Atoi(actorIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid actor ID %q", actorIDStr)
+		}
+		actorID = PG.resolveActorID(actorID)
+		query = query.Where("movies.id IN (?)",
+			PG.DB.Table("actormovies").Select("movie_id").Where("actor_id = ?", actorID))
+	}
+
+	for _, genre := range params["genre"] {
+		query = query.Where("movies.id IN (?)",
+			PG.DB.Table("moviegenres").Select("moviegenres.movie_id").
+				Joins("JOIN genres ON genres.id = moviegenres.genre_id").
+				Where("LOWER(genres.name) = LOWER(?)", genre))
+	}
+
+	for _, tag := range params["tag"] {
+		query = query.Where("movies.id IN (?)",
+			PG.DB.Table("movietags").Select("movietags.movie_id").
+				Joins("JOIN tags ON tags.id = movietags.tag_id").
+				Where("LOWER(tags.name) = LOWER(?)", tag))
+	}
+
+	return query, nil
+}
+
+// parseDecade reads a decade drill-down value, a year from 0 to 9990 that is a multiple of ten.
+func parseDecade(value string) (int, error) {
+	decade, err := strconv.Atoi(value)
+	if err != nil || decade < 0 || decade > 9990 || decade%10 != 0 {
+		return 0, fmt.Errorf("invalid decade %q", value)
+	}
+	return decade, nil
+}
+
+// parseRatingBand reads a rating band drill-down value, the whole part of a rating from 0 to 10.
+func parseRatingBand(value string) (int, error) {
+	band, err := strconv.Atoi(value)
+	if err != nil || band < 0 || band > 10 {
+		return 0, fmt.Errorf("invalid rating band %q", value)
+	}
+	return band, nil
+}
+
+// facetQueries select the value and movie count of every bucket of a facet, over the movies whose IDs are bound to the placeholder.
+var facetQueries = map[string]string{
+	"decade": `SELECT (CAST(LEFT(release_date, 4) AS int) / 10 * 10)::text AS value, COUNT(*) AS count
+		FROM movies WHERE id IN (?) AND release_date ~ '^[0-9]{4}' GROUP BY 1`,
+	"rating": `SELECT FLOOR(COALESCE(rating, 0))::int::text AS value, COUNT(*) AS count
+		FROM movies WHERE id IN (?) GROUP BY 1`,
+	"actor": `SELECT actors.id::text AS value, actors.name AS label, COUNT(DISTINCT actormovies.movie_id) AS count
+		FROM actormovies JOIN actors ON actors.id = actormovies.actor_id AND actors.deleted_at IS NULL
+		WHERE actormovies.movie_id IN (?) GROUP BY actors.id, actors.name`,
+	"genre": `SELECT genres.name AS value, genres.name AS label, COUNT(DISTINCT moviegenres.movie_id) AS count
+		FROM moviegenres JOIN genres ON genres.id = moviegenres.genre_id
+		WHERE moviegenres.movie_id IN (?) GROUP BY genres.name`,
+	"tag": `SELECT tags.name AS value, tags.name AS label, COUNT(DISTINCT movietags.movie_id) AS count
+		FROM movietags JOIN tags ON tags.id = movietags.tag_id
+		WHERE movietags.movie_id IN (?) GROUP BY tags.name`,
+}
+
+// computeFacets aggregates the requested facets in the database over the movies matched by query, with one GROUP BY query per facet.
+// The query is the one the movies of the response are fetched with, so counts always match the returned movies.
+func (PG *Postgresql) computeFacets(query *gorm.DB, names []string) (*models.MovieFacets, error) {
+	facets := &models.MovieFacets{}
+	movieIDs := query.Session(&gorm.Session{}).Select("movies.id")
+
+	for _, name := range names {
+		var buckets []models.FacetCount
+		if err := PG.DB.Raw(facetQueries[name], movieIDs).Scan(&buckets).Error; err != nil {
+			return nil, err
+		}
+		for i := range buckets {
+			buckets[i].Label = facetLabel(name, buckets[i])
+		}
+		sortFacetCounts(buckets)
+
+		switch name {
+		case "decade":
+			facets.Decade = buckets
+		case "rating":
+			facets.Rating = buckets
+		case "actor":
+			facets.Actor = buckets
+		case "genre":
+			facets.Genre = buckets
+		case "tag":
+			facets.Tag = buckets
+		}
+	}
+
+	return facets, nil
+}
+
+// facetLabel returns the human readable label of a bucket: "1990s" for a decade, "8.0-8.9" for a rating band,
+// and the label read from the database for the other facets.
+func facetLabel(name string, bucket models.FacetCount) string {
+	switch name {
+	case "decade":
+		return bucket.Value + "s"
+	case "rating":
+		return fmt.Sprintf("%s.0-%s.9", bucket.Value, bucket.Value)
+	}
+	return bucket.Label
+}
+
+// sortFacetCounts orders the buckets by descending count, then by value for a stable output.
+func sortFacetCounts(buckets []models.FacetCount) {
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Count != buckets[j].Count {
+			return buckets[i].Count > buckets[j].Count
+		}
+		return buckets[i].Value < buckets[j].Value
+	})
+}
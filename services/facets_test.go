@@ -0,0 +1,111 @@
+package services
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"vk.com/m/models"
+)
+
+func TestRequestedFacets(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"facets=decade", []string{"decade"}},
+		{"facets=decade,%20genre,tag", []string{"decade", "genre", "tag"}},
+		{"facets=decade,unknown,actor", []string{"decade", "actor"}},
+		{"facets=unknown", nil},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/v1/movie-list?"+tt.query, nil)
+		if got := requestedFacets(r); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("requestedFacets(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseDecade(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"1990", 1990, false},
+		{"0", 0, false},
+		{"9990", 9990, false},
+		{"1995", 0, true},
+		{"-10", 0, true},
+		{"10000", 0, true},
+		{"nineties", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDecade(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseDecade(%q) = %d, %v, want %d, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseRatingBand(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"8", 8, false},
+		{"10", 10, false},
+		{"-1", 0, true},
+		{"11", 0, true},
+		{"8.5", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseRatingBand(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseRatingBand(%q) = %d, %v, want %d, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFacetLabel(t *testing.T) {
+	tests := []struct {
+		name   string
+		bucket models.FacetCount
+		want   string
+	}{
+		{"decade", models.FacetCount{Value: "1990"}, "1990s"},
+		{"rating", models.FacetCount{Value: "8"}, "8.0-8.9"},
+		{"actor", models.FacetCount{Value: "42", Label: "Keanu Reeves"}, "Keanu Reeves"},
+		{"genre", models.FacetCount{Value: "Drama", Label: "Drama"}, "Drama"},
+	}
+
+	for _, tt := range tests {
+		if got := facetLabel(tt.name, tt.bucket); got != tt.want {
+			t.Errorf("facetLabel(%q, %+v) = %q, want %q", tt.name, tt.bucket, got, tt.want)
+		}
+	}
+}
+
+func TestSortFacetCounts(t *testing.T) {
+	buckets := []models.FacetCount{
+		{Value: "Drama", Count: 2},
+		{Value: "Action", Count: 5},
+		{Value: "Comedy", Count: 2},
+		{Value: "Horror", Count: 1},
+	}
+	sortFacetCounts(buckets)
+
+	want := []string{"Action", "Comedy", "Drama", "Horror"}
+	for i, bucket := range buckets {
+		if bucket.Value != want[i] {
+			t.Fatalf("sortFacetCounts order = %v, want %v", buckets, want)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+	"vk.com/m/models"
+	"vk.com/m/notify"
+)
+
+const (
+	// notifyTimeout bounds the delivery of a single notification through the configured notifier.
+	notifyTimeout = 30 * time.Second
+	// notifyWorkers is the number of notifications delivered at the same time.
+	notifyWorkers = 4
+	// notifyBacklog is the number of notifications waiting for delivery beyond which new ones are dropped.
+	notifyBacklog = 1000
+)
+
+// ActorFollow godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Follows an actor
+// @Description Makes the authenticated user follow the actor with the specified ID. The user is then notified whenever the actor is added to a movie. Following an actor twice has no effect. Available to both 'admin' and 'user' roles.
+// @Tags follow
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Success 200 {object} models.ActorFollow "Successfully followed the actor"
+// @Failure 400 "Invalid actor ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Actor not found"
+// @Failure 500 "Error following actor"
+// @Router /v1/actor-follow/{id} [post]
+func (PG *Postgresql) ActorFollow(w http.ResponseWriter, r *http.Request) (*models.ActorFollow, error) {
+
+	log.Info().Msg("ActorFollow called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var actor models.Actor
+	if err := PG.DB.First(&actor, "id = ?", actorID).Error; err != nil {
+		log.Error().Err(err).Msg("Actor not found")
+		http.Error(w, "Actor not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	data := models.ActorFollow{UserID: claims.UserID, ActorID: actorID}
+	if err := PG.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error following actor")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	data.Actor = &actor
+
+	log.Info().Int("actorID", actorID).Msg("Actor followed")
+	return &data, nil
+}
+
+// ActorUnfollow godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Unfollows an actor
+// @Description Makes the authenticated user stop following the actor with the specified ID. Available to both 'admin' and 'user' roles.
+// @Tags follow
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Success 200 "Successfully unfollowed the actor"
+// @Failure 400 "Invalid actor ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 500 "Error unfollowing actor"
+// @Router /v1/actor-unfollow/{id} [delete]
+func (PG *Postgresql) ActorUnfollow(w http.ResponseWriter, r *http.Request) (*models.ActorFollow, error) {
+
+	log.Info().Msg("ActorUnfollow called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.ActorFollow
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.Where("user_id = ? AND actor_id = ?", claims.UserID, actorID).Delete(&models.ActorFollow{}).Error; err != nil {
+		log.Error().Err(err).Msg("Error unfollowing actor")
+		http.Error(w, "Error unfollowing actor", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("actorID", actorID).Msg("Actor unfollowed")
+	return &data, nil
+}
+
+// ActorFollowList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the followed actors
+// @Description Retrieves the actors the authenticated user follows, most recently followed first. Available to both 'admin' and 'user' roles.
+// @Tags follow
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.ActorFollow "Successfully retrieved the followed actors"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 500 "Error retrieving followed actors"
+// @Router /v1/actor-followed [get]
+func (PG *Postgresql) ActorFollowList(w http.ResponseWriter, r *http.Request) (*[]models.ActorFollow, error) {
+	log.Info().Msg("ActorFollowList called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.ActorFollow
+
+	if err := PG.DB.Preload("Actor").Where("user_id = ? AND actor_id IN ("+activeActorIDs+")", claims.UserID).Order("created_at DESC, actor_id").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving followed actors")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Followed actors retrieved successfully")
+	return &data, nil
+}
+
+// NotificationList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists one's notifications
+// @Description Retrieves the authenticated user's notification feed, newest first. Available to both 'admin' and 'user' roles.
+// @Tags follow
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param unread query bool false "Only return unread notifications"
+// @Param limit query int false "Maximum number of notifications to return (default: 50)"
+// @Success 200 {array} models.Notification "Successfully retrieved the notifications"
+// @Failure 400 "Invalid limit"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 500 "Error retrieving notifications"
+// @Router /v1/notification-list [get]
+func (PG *Postgresql) NotificationList(w http.ResponseWriter, r *http.Request) (*[]models.Notification, error) {
+	log.Info().Msg("NotificationList called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := intQuery(w, r, "limit", 50)
+	if err != nil {
+		return nil, err
+	}
+
+	query := PG.DB.Where("user_id = ?", claims.UserID)
+	if r.URL.Query().Get("unread") == "true" {
+		query = query.Where("NOT read")
+	}
+
+	var data []models.Notification
+
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving notifications")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Notifications retrieved successfully")
+	return &data, nil
+}
+
+// NotificationRead godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Marks a notification as read
+// @Description Marks the authenticated user's notification with the specified ID as read. Available to both 'admin' and 'user' roles.
+// @Tags follow
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Notification ID"
+// @Success 200 {object} models.Notification "Successfully marked the notification as read"
+// @Failure 400 "Invalid notification ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Notification not found"
+// @Failure 500 "Error updating notification"
+// @Router /v1/notification-read/{id} [put]
+func (PG *Postgresql) NotificationRead(w http.ResponseWriter, r *http.Request) (*models.Notification, error) {
+
+	log.Info().Msg("NotificationRead called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationID, err := idFromPath(w, r, "notification")
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Notification
+
+	if err := PG.DB.First(&data, "id = ? AND user_id = ?", notificationID, claims.UserID).Error; err != nil {
+		log.Error().Err(err).Msg("Notification not found")
+		http.Error(w, "Notification not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	if err := PG.DB.Model(&data).Update("read", true).Error; err != nil {
+		log.Error().Err(err).Msg("Error updating notification")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("notificationID", notificationID).Msg("Notification marked as read")
+	return &data, nil
+}
+
+// NotificationReadAll godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Marks all notifications as read
+// @Description Marks every unread notification of the authenticated user as read. Available to both 'admin' and 'user' roles.
+// @Tags follow
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 "Successfully marked the notifications as read"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 500 "Error updating notifications"
+// @Router /v1/notification-read-all [put]
+func (PG *Postgresql) NotificationReadAll(w http.ResponseWriter, r *http.Request) (*models.Notification, error) {
+
+	log.Info().Msg("NotificationReadAll called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Notification
+
+	if err := PG.DB.Model(&models.Notification{}).Where("user_id = ? AND NOT read", claims.UserID).Update("read", true).Error; err != nil {
+		log.Error().Err(err).Msg("Error updating notifications")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("userID", claims.UserID).Msg("Notifications marked as read")
+	return &data, nil
+}
+
+// notifyFollowers tells the followers of the given actors that they were added to the movie.
+// A notification is stored for each follower and actor, then handed to the configured notifier in the background.
+// Like the similarity refresh, failures are logged and do not abort the edit that triggered them.
+func (PG *Postgresql) notifyFollowers(movieID int, actorIDs []int) {
+	if len(actorIDs) == 0 {
+		return
+	}
+
+	var follows []models.ActorFollow
+	if err := PG.DB.Preload("Actor").Where("actor_id IN ?", actorIDs).Find(&follows).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to find the followers of the new cast members")
+		return
+	}
+	if len(follows) == 0 {
+		return
+	}
+
+	var movie models.Movie
+	if err := PG.DB.Select("id, title").First(&movie, "id = ?", movieID).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to load the movie to notify about")
+		return
+	}
+
+	notifications := make([]models.Notification, 0, len(follows))
+	for _, follow := range follows {
+		if follow.Actor == nil {
+			continue
+		}
+		notifications = append(notifications, models.Notification{
+			UserID:  follow.UserID,
+			ActorID: follow.ActorID,
+			MovieID: movieID,
+			Message: fmt.Sprintf("%s was added to the cast of %s", follow.Actor.Name, movie.Title),
+		})
+	}
+	if err := PG.DB.Create(&notifications).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to store notifications")
+		return
+	}
+
+	if _, disabled := PG.Notifier.(notify.Nop); disabled || PG.Notifier == nil {
+		return
+	}
+
+	userIDs := make([]int, 0, len(notifications))
+	for _, notification := range notifications {
+		userIDs = append(userIDs, notification.UserID)
+	}
+	var users []models.User
+	if err := PG.DB.Select("id, email").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to load the recipients of the notifications")
+		return
+	}
+	emails := make(map[int]string, len(users))
+	for _, user := range users {
+		emails[user.ID] = user.Email
+	}
+
+	for _, notification := range notifications {
+		message := notify.Message{
+			UserID:  notification.UserID,
+			Email:   emails[notification.UserID],
+			Subject: notification.Message,
+			Body:    notification.Message + ".",
+			ActorID: notification.ActorID,
+			MovieID: notification.MovieID,
+		}
+		if err := PG.Notifier.Notify(context.Background(), message); err != nil {
+			log.Error().Err(err).Int("userID", message.UserID).Msg("Failed to queue notification")
+		}
+	}
+}
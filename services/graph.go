@@ -0,0 +1,293 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"vk.com/m/models"
+)
+
+const (
+	// collaboratorsLimit caps the number of collaborators ActorCollaborators returns.
+	collaboratorsLimit = 100
+	// pathMaxDepth caps the maxDepth of ActorPath; every step of the search widens it to all co-stars of the frontier.
+	pathMaxDepth = 10
+)
+
+// coAppearance is an edge of the actor collaboration graph: two actors who appeared in the same movie.
+type coAppearance struct {
+	FromID  int
+	MovieID int
+	ToID    int
+}
+
+// pathLink records how the bidirectional search reached an actor: from which actor, through which movie, and at what depth.
+type pathLink struct {
+	actorID int
+	movieID int
+	depth   int
+}
+
+// ActorCollaborators godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists an actor's frequent collaborators
+// @Description Retrieves the actors who appeared in movies together with the actor with the specified ID, ranked by the number of shared movies. Available to both 'admin' and 'user' roles.
+// @Tags actor
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Param limit query int false "Maximum number of collaborators to return, at most 100 (default: 10)"
+// @Success 200 {array} models.Collaborator "Successfully retrieved the collaborators"
+// @Failure 400 "Invalid actor ID or limit"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving collaborators"
+// @Router /v1/actor-collaborators/{id} [get]
+func (PG *Postgresql) ActorCollaborators(w http.ResponseWriter, r *http.Request) (*[]models.Collaborator, error) {
+	log.Info().Msg("ActorCollaborators called")
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := boundedIntQuery(w, r, "limit", 10, 1, collaboratorsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.Collaborator
+
+	err = PG.DB.Table("actormovies AS a1").
+		Select("actors.id AS actor_id, actors.name, COUNT(DISTINCT a2.movie_id) AS shared_movies").
+		Joins("JOIN actormovies AS a2 ON a2.movie_id = a1.movie_id AND a2.actor_id <> a1.actor_id").
+		Joins("JOIN actors ON actors.id = a2.actor_id AND actors.deleted_at IS NULL").
+		Where("a1.actor_id = ? AND a1.movie_id IN ("+activeMovieIDs+")", actorID).
+		Group("actors.id, actors.name").
+		Order("shared_movies DESC, actors.id").
+		Limit(limit).
+		Scan(&data).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving collaborators")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("actorID", actorID).Int("count", len(data)).Msg("Collaborators retrieved successfully")
+	return &data, nil
+}
+
+// ActorPath godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Finds the shortest connection between two actors
+// @Description Finds the shortest chain of shared movies connecting two actors, searching from both ends at once. The path alternates actor and movie nodes. Available to both 'admin' and 'user' roles.
+// @Tags actor
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param from query int true "ID of the first actor"
+// @Param to query int true "ID of the second actor"
+// @Param maxDepth query int false "Maximum number of movies on the path, at most 10 (default: 6)"
+// @Success 200 {object} models.ActorPath "Successfully found a path"
+// @Failure 400 "Invalid actor IDs or depth"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "No path within the depth limit"
+// @Failure 500 "Error searching for a path"
+// @Router /v1/actor-path [get]
+func (PG *Postgresql) ActorPath(w http.ResponseWriter, r *http.Request) (*models.ActorPath, error) {
+	log.Info().Msg("ActorPath called")
+
+	fromID, err := intQuery(w, r, "from", 0)
+	if err != nil {
+		return nil, err
+	}
+	toID, err := intQuery(w, r, "to", 0)
+	if err != nil {
+		return nil, err
+	}
+	maxDepth, err := boundedIntQuery(w, r, "maxDepth", 6, 0, pathMaxDepth)
+	if err != nil {
+		return nil, err
+	}
+	fromID, toID = PG.resolveActorID(fromID), PG.resolveActorID(toID)
+	if fromID == 0 || toID == 0 {
+		log.Error().Int("from", fromID).Int("to", toID).Msg("Invalid path parameters")
+		http.Error(w, "Both from and to actor IDs are required", http.StatusBadRequest)
+		return nil, errors.New("invalid path parameters")
+	}
+
+	var count int64
+	if err := PG.DB.Model(&models.Actor{}).Where("id IN ?", []int{fromID, toID}).Count(&count).Error; err != nil {
+		log.Error().Err(err).Msg("Error looking up actors")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if (fromID == toID && count != 1) || (fromID != toID && count != 2) {
+		log.Error().Int("from", fromID).Int("to", toID).Msg("Actor not found")
+		http.Error(w, "Actor not found", http.StatusNotFound)
+		return nil, errors.New("actor not found")
+	}
+
+	links, err := shortestPath(PG.coAppearances, fromID, toID, maxDepth)
+	if err != nil {
+		log.Error().Err(err).Msg("Error searching for a path")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if links == nil {
+		log.Info().Int("from", fromID).Int("to", toID).Int("maxDepth", maxDepth).Msg("No path found")
+		http.Error(w, "No path within the depth limit", http.StatusNotFound)
+		return nil, errors.New("no path within the depth limit")
+	}
+
+	data, err := PG.describePath(fromID, links)
+	if err != nil {
+		log.Error().Err(err).Msg("Error loading path nodes")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("from", fromID).Int("to", toID).Int("degrees", data.Degrees).Msg("Path found")
+	return data, nil
+}
+
+// coAppearances loads every edge leaving the given actors: for each of their movies, one edge per other cast member.
+func (PG *Postgresql) coAppearances(actorIDs []int) ([]coAppearance, error) {
+	var edges []coAppearance
+
+	err := PG.DB.Table("actormovies AS a1").
+		Select("a1.actor_id AS from_id, a1.movie_id, a2.actor_id AS to_id").
+		Joins("JOIN actormovies AS a2 ON a2.movie_id = a1.movie_id AND a2.actor_id <> a1.actor_id").
+		Where("a1.actor_id IN ? AND a1.movie_id IN ("+activeMovieIDs+") AND a2.actor_id IN ("+activeActorIDs+")", actorIDs).
+		Order("a1.movie_id, a2.actor_id").
+		Scan(&edges).Error
+
+	return edges, err
+}
+
+// shortestPath runs a bidirectional breadth-first search over the collaboration graph.
+// Each round expands the smaller of the two frontiers by one whole level, loading its edges with a single call to load,
+// so the number of queries is bounded by maxDepth. When the frontiers meet, the meeting actor with the lowest
+// combined depth is chosen, which guarantees the path is a shortest one.
+// Returns the sequence of (actor, movie) hops from fromID to toID, or nil when no path of at most maxDepth movies exists.
+func shortestPath(load func(actorIDs []int) ([]coAppearance, error), fromID, toID, maxDepth int) ([]pathLink, error) {
+	if fromID == toID {
+		return []pathLink{}, nil
+	}
+
+	forward := map[int]pathLink{fromID: {actorID: fromID}}
+	backward := map[int]pathLink{toID: {actorID: toID}}
+	forwardFrontier := []int{fromID}
+	backwardFrontier := []int{toID}
+
+	for depth := 0; depth < maxDepth; depth++ {
+		visited, other, frontier := forward, backward, &forwardFrontier
+		if len(backwardFrontier) < len(forwardFrontier) {
+			visited, other, frontier = backward, forward, &backwardFrontier
+		}
+
+		edges, err := load(*frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		meet, best := 0, -1
+		var next []int
+		for _, edge := range edges {
+			if _, seen := visited[edge.ToID]; seen {
+				continue
+			}
+			visited[edge.ToID] = pathLink{actorID: edge.FromID, movieID: edge.MovieID, depth: visited[edge.FromID].depth + 1}
+			next = append(next, edge.ToID)
+
+			if link, ok := other[edge.ToID]; ok {
+				if total := visited[edge.ToID].depth + link.depth; best == -1 || total < best {
+					meet, best = edge.ToID, total
+				}
+			}
+		}
+
+		if best != -1 {
+			return joinPath(forward, backward, fromID, meet), nil
+		}
+		if len(next) == 0 {
+			return nil, nil
+		}
+		*frontier = next
+	}
+
+	return nil, nil
+}
+
+// joinPath stitches the two halves of a bidirectional search into a single list of hops.
+// Each hop holds an actor and the movie leading from it to the next actor; the last hop has no movie.
+func joinPath(forward, backward map[int]pathLink, fromID, meet int) []pathLink {
+	var head []pathLink
+	for actorID := meet; actorID != fromID; actorID = forward[actorID].actorID {
+		link := forward[actorID]
+		head = append([]pathLink{{actorID: link.actorID, movieID: link.movieID}}, head...)
+	}
+
+	path := head
+	for actorID := meet; ; {
+		link := backward[actorID]
+		if link.movieID == 0 {
+			path = append(path, pathLink{actorID: actorID})
+			break
+		}
+		path = append(path, pathLink{actorID: actorID, movieID: link.movieID})
+		actorID = link.actorID
+	}
+
+	return path
+}
+
+// describePath loads the names and titles of the actors and movies on a path and lays them out as alternating nodes.
+func (PG *Postgresql) describePath(fromID int, links []pathLink) (*models.ActorPath, error) {
+	if len(links) == 0 {
+		links = []pathLink{{actorID: fromID}}
+	}
+
+	var actorIDs, movieIDs []int
+	for _, link := range links {
+		actorIDs = append(actorIDs, link.actorID)
+		if link.movieID != 0 {
+			movieIDs = append(movieIDs, link.movieID)
+		}
+	}
+
+	var actors []models.Actor
+	if err := PG.DB.Where("id IN ?", actorIDs).Find(&actors).Error; err != nil {
+		return nil, err
+	}
+	actorNames := map[int]string{}
+	for _, actor := range actors {
+		actorNames[actor.ID] = actor.Name
+	}
+
+	movieTitles := map[int]string{}
+	if len(movieIDs) > 0 {
+		var movies []models.Movie
+		if err := PG.DB.Where("id IN ?", movieIDs).Find(&movies).Error; err != nil {
+			return nil, err
+		}
+		for _, movie := range movies {
+			movieTitles[movie.ID] = movie.Title
+		}
+	}
+
+	data := &models.ActorPath{Degrees: len(movieIDs)}
+	for _, link := range links {
+		data.Nodes = append(data.Nodes, models.PathNode{Type: "actor", ID: link.actorID, Name: actorNames[link.actorID]})
+		if link.movieID != 0 {
+			data.Nodes = append(data.Nodes, models.PathNode{Type: "movie", ID: link.movieID, Name: movieTitles[link.movieID]})
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"sort"
+	"testing"
+)
+
+// castGraph is an in-memory collaboration graph: the cast of every movie by movie ID.
+type castGraph map[int][]int
+
+// load returns the co-appearance edges leaving the given actors, in the order coAppearances returns them.
+func (g castGraph) load(actorIDs []int) ([]coAppearance, error) {
+	var edges []coAppearance
+	for movieID, cast := range g {
+		for _, from := range cast {
+			if !containsInt(actorIDs, from) {
+				continue
+			}
+			for _, to := range cast {
+				if to != from {
+					edges = append(edges, coAppearance{FromID: from, MovieID: movieID, ToID: to})
+				}
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].MovieID != edges[j].MovieID {
+			return edges[i].MovieID < edges[j].MovieID
+		}
+		return edges[i].ToID < edges[j].ToID
+	})
+	return edges, nil
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestShortestPath(t *testing.T) {
+	graph := castGraph{
+		10: {1, 2},
+		20: {2, 3},
+		30: {3, 4},
+		40: {4, 5},
+		50: {1, 6},
+		60: {6, 5},
+		70: {8, 9},
+	}
+
+	tests := []struct {
+		name     string
+		from, to int
+		maxDepth int
+		hops     int // number of movies on the path, -1 when no path is expected
+	}{
+		{"same actor", 1, 1, 6, 0},
+		{"co-stars", 1, 2, 6, 1},
+		{"two hops", 1, 3, 6, 2},
+		{"shorter branch wins", 1, 5, 6, 2},
+		{"disconnected", 1, 8, 6, -1},
+		{"beyond max depth", 1, 4, 2, -1},
+		{"within max depth", 1, 4, 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := shortestPath(graph.load, tt.from, tt.to, tt.maxDepth)
+			if err != nil {
+				t.Fatalf("shortestPath returned error: %v", err)
+			}
+			if tt.hops == -1 {
+				if path != nil {
+					t.Fatalf("shortestPath = %v, want no path", path)
+				}
+				return
+			}
+			if tt.hops == 0 {
+				if len(path) != 0 {
+					t.Fatalf("shortestPath = %v, want empty path", path)
+				}
+				return
+			}
+
+			if len(path) != tt.hops+1 {
+				t.Fatalf("shortestPath = %v, want %d hops", path, tt.hops)
+			}
+			if path[0].actorID != tt.from || path[len(path)-1].actorID != tt.to || path[len(path)-1].movieID != 0 {
+				t.Fatalf("shortestPath = %v, want a path from %d to %d", path, tt.from, tt.to)
+			}
+			for i := 0; i < len(path)-1; i++ {
+				cast := graph[path[i].movieID]
+				if !containsInt(cast, path[i].actorID) || !containsInt(cast, path[i+1].actorID) {
+					t.Fatalf("shortestPath = %v, hop %d does not follow movie %d", path, i, path[i].movieID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"vk.com/m/imaging"
+	"vk.com/m/models"
+)
+
+// maxImageSize is the largest image upload accepted, in bytes.
+const maxImageSize = 10 << 20
+
+// MoviePosterUpload godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Uploads a movie poster
+// @Description Uploads the poster of the movie with the specified ID as the "image" field of a multipart form, replacing any previous one. JPEG, PNG and GIF images up to 10 MB are accepted; the format is detected from the content. Small, medium and large JPEG thumbnails are generated. Requires 'admin' role.
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Param image formData file true "Poster image"
+// @Success 200 {object} models.Movie "Successfully uploaded the poster"
+// @Failure 400 "Invalid movie ID, form or image"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Movie not found"
+// @Failure 413 "Image larger than 10 MB"
+// @Failure 415 "Unsupported image format"
+// @Failure 500 "Error storing the poster"
+// @Router /v1/movie-poster/{id} [post]
+func (PG *Postgresql) MoviePosterUpload(w http.ResponseWriter, r *http.Request) (*models.Movie, error) {
+	log.Info().Msg("MoviePosterUpload called")
+
+	var data models.Movie
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", movieID).Error; err != nil {
+		log.Error().Err(err).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	key, err := PG.storeImageUpload(w, r, fmt.Sprintf("movies/%d/poster", movieID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.Model(&data).Update("poster_key", key).Error; err != nil {
+		log.Error().Err(err).Msg("Error saving the poster")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		PG.deleteImage(key)
+		return nil, err
+	}
+
+	PG.deleteImage(data.PosterKey)
+	data.PosterKey, data.Poster = key, models.NewImageURLs(key)
+
+	log.Info().Int("movieID", movieID).Str("key", key).Msg("Poster uploaded successfully")
+	return &data, nil
+}
+
+// MoviePosterDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Removes a movie poster
+// @Description Removes the poster and thumbnails of the movie with the specified ID. Requires 'admin' role.
+// @Tags media
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Success 200 {object} models.Movie "Successfully removed the poster"
+// @Failure 400 "Invalid movie ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Movie not found"
+// @Failure 500 "Error removing the poster"
+// @Router /v1/movie-poster-delete/{id} [delete]
+func (PG *Postgresql) MoviePosterDelete(w http.ResponseWriter, r *http.Request) (*models.Movie, error) {
+	log.Info().Msg("MoviePosterDelete called")
+
+	var data models.Movie
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", movieID).Error; err != nil {
+		log.Error().Err(err).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	if err := PG.DB.Model(&data).Update("poster_key", "").Error; err != nil {
+		log.Error().Err(err).Msg("Error removing the poster")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	PG.deleteImage(data.PosterKey)
+	data.PosterKey, data.Poster = "", nil
+
+	log.Info().Int("movieID", movieID).Msg("Poster removed successfully")
+	return &data, nil
+}
+
+// ActorPhotoUpload godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Uploads an actor photo
+// @Description Uploads the photo of the actor with the specified ID as the "image" field of a multipart form, replacing any previous one. JPEG, PNG and GIF images up to 10 MB are accepted; the format is detected from the content. Small, medium and large JPEG thumbnails are generated. Requires 'admin' role.
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Param image formData file true "Actor photo"
+// @Success 200 {object} models.Actor "Successfully uploaded the photo"
+// @Failure 400 "Invalid actor ID, form or image"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Actor not found"
+// @Failure 413 "Image larger than 10 MB"
+// @Failure 415 "Unsupported image format"
+// @Failure 500 "Error storing the photo"
+// @Router /v1/actor-photo/{id} [post]
+func (PG *Postgresql) ActorPhotoUpload(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
+	log.Info().Msg("ActorPhotoUpload called")
+
+	var data models.Actor
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", actorID).Error; err != nil {
+		log.Error().Err(err).Msg("Actor not found")
+		http.Error(w, "Actor not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	key, err := PG.storeImageUpload(w, r, fmt.Sprintf("actors/%d/photo", actorID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.Model(&data).Update("photo_key", key).Error; err != nil {
+		log.Error().Err(err).Msg("Error saving the photo")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		PG.deleteImage(key)
+		return nil, err
+	}
+
+	PG.deleteImage(data.PhotoKey)
+	data.PhotoKey, data.Photo = key, models.NewImageURLs(key)
+
+	log.Info().Int("actorID", actorID).Str("key", key).Msg("Photo uploaded successfully")
+	return &data, nil
+}
+
+// ActorPhotoDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Removes an actor photo
+// @Description Removes the photo and thumbnails of the actor with the specified ID. Requires 'admin' role.
+// @Tags media
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Success 200 {object} models.Actor "Successfully removed the photo"
+// @Failure 400 "Invalid actor ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Actor not found"
+// @Failure 500 "Error removing the photo"
+// @Router /v1/actor-photo-delete/{id} [delete]
+func (PG *Postgresql) ActorPhotoDelete(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
+	log.Info().Msg("ActorPhotoDelete called")
+
+	var data models.Actor
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", actorID).Error; err != nil {
+		log.Error().Err(err).Msg("Actor not found")
+		http.Error(w, "Actor not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	if err := PG.DB.Model(&data).Update("photo_key", "").Error; err != nil {
+		log.Error().Err(err).Msg("Error removing the photo")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	PG.deleteImage(data.PhotoKey)
+	data.PhotoKey, data.Photo = "", nil
+
+	log.Info().Int("actorID", actorID).Msg("Photo removed successfully")
+	return &data, nil
+}
+
+// storeImageUpload reads the "image" field of a multipart upload, validates it and stores it with its thumbnails.
+// The blob key is the prefix followed by a random suffix and the extension of the detected format, so a replaced image
+// never shares its URL with the new one. On failure it answers the request itself, like idFromPath.
+func (PG *Postgresql) storeImageUpload(w http.ResponseWriter, r *http.Request, prefix string) (string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImageSize+1<<20)
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Error().Err(err).Msg("Image too large")
+			http.Error(w, "Image larger than 10 MB", http.StatusRequestEntityTooLarge)
+			return "", err
+		}
+		log.Error().Err(err).Msg("Missing image field")
+		http.Error(w, "The image must be sent in the \"image\" field of a multipart form", http.StatusBadRequest)
+		return "", err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxImageSize+1))
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading the image")
+		http.Error(w, "Error reading the image", http.StatusBadRequest)
+		return "", err
+	}
+	if len(content) > maxImageSize {
+		log.Error().Int("size", len(content)).Msg("Image too large")
+		http.Error(w, "Image larger than 10 MB", http.StatusRequestEntityTooLarge)
+		return "", errors.New("image too large")
+	}
+
+	img, contentType, err := imaging.Decode(content)
+	if errors.Is(err, imaging.ErrUnsupportedFormat) {
+		log.Error().Err(err).Msg("Unsupported image format")
+		http.Error(w, "Only JPEG, PNG and GIF images are accepted", http.StatusUnsupportedMediaType)
+		return "", err
+	} else if err != nil {
+		log.Error().Err(err).Msg("Invalid image")
+		http.Error(w, "Invalid image: "+err.Error(), http.StatusBadRequest)
+		return "", err
+	}
+
+	suffix, err := randomSuffix()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to read random bytes")
+		http.Error(w, "Error storing the image", http.StatusInternalServerError)
+		return "", err
+	}
+
+	key := fmt.Sprintf("%s-%s.%s", prefix, suffix, imaging.Extensions[contentType])
+	if err := PG.storeImage(r.Context(), key, content, contentType, img); err != nil {
+		log.Error().Err(err).Msg("Error storing the image")
+		http.Error(w, "Error storing the image", http.StatusInternalServerError)
+		return "", err
+	}
+
+	return key, nil
+}
+
+// storeImage puts the original image and each of its thumbnails in the blob store.
+// If any of them fails, the ones already stored are removed again.
+func (PG *Postgresql) storeImage(ctx context.Context, key string, content []byte, contentType string, img image.Image) error {
+	if err := PG.Blobs.Put(ctx, key, bytes.NewReader(content), contentType); err != nil {
+		return err
+	}
+
+	for _, size := range models.ThumbnailSizes {
+		var thumbnail bytes.Buffer
+		err := imaging.EncodeJPEG(&thumbnail, imaging.Thumbnail(img, size.Width))
+		if err == nil {
+			err = PG.Blobs.Put(ctx, models.ThumbnailKey(key, size.Name), &thumbnail, "image/jpeg")
+		}
+		if err != nil {
+			PG.deleteImage(key)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteImage removes an image and its thumbnails from the blob store.
+// Failures only leave unreferenced files behind, so they are logged rather than reported.
+func (PG *Postgresql) deleteImage(key string) {
+	if key == "" {
+		return
+	}
+
+	keys := []string{key}
+	for _, size := range models.ThumbnailSizes {
+		keys = append(keys, models.ThumbnailKey(key, size.Name))
+	}
+	for _, blob := range keys {
+		if err := PG.Blobs.Delete(context.Background(), blob); err != nil {
+			log.Error().Err(err).Str("key", blob).Msg("Failed to delete image")
+		}
+	}
+}
+
+// randomSuffix returns 12 random hex digits.
+func randomSuffix() (string, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(suffix), nil
+}
@@ -0,0 +1,542 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"vk.com/m/models"
+)
+
+// Weights of the name and birth date similarity in the duplicate score. Two actors with the same name and no known
+// birth dates score 0.9, just above the default threshold, while namesakes born on different days score 0.8.
+const (
+	duplicateNameWeight  = 0.8
+	duplicateBirthWeight = 0.2
+)
+
+// duplicatePageSize is how many candidate pairs scanDuplicates scores at a time.
+const duplicatePageSize = 500
+
+// duplicatePairsQuery lists the pairs of actors, lower ID first, that share the first three letters of a word of their names or
+// aliases. Words are split at spaces and punctuation after lower-casing and folding ё into е, like normalizeName.
+// A non-zero @actor restricts the pairs to those including that actor. Trashed actors are left out.
+const duplicatePairsQuery = `WITH names AS (
+	SELECT id AS actor_id, name FROM actors WHERE deleted_at IS NULL
+	UNION ALL
+	SELECT actor_aliases.actor_id, actor_aliases.name FROM actor_aliases JOIN actors ON actors.id = actor_aliases.actor_id WHERE actors.deleted_at IS NULL
+), blocks AS (
+	SELECT DISTINCT actor_id, left(word, 3) AS prefix
+	FROM names, regexp_split_to_table(translate(lower(name), 'ё', 'е'), '[[:space:][:punct:]]+') AS word
+	WHERE word <> ''
+)
+SELECT DISTINCT a.actor_id, b.actor_id
+FROM blocks AS a JOIN blocks AS b ON a.prefix = b.prefix AND a.actor_id < b.actor_id
+WHERE @actor = 0 OR @actor IN (a.actor_id, b.actor_id)
+ORDER BY 1, 2`
+
+// sameNomination matches a kept nomination to a moved one for the same ceremony, category and movie.
+const sameNomination = "kept.ceremony_id = moved.ceremony_id AND kept.category_id = moved.category_id AND kept.movie_id = moved.movie_id"
+
+// mergeStatements move everything linked to the duplicate actor over to the survivor. Links the survivor already has,
+// such as a movie both were credited in, keep the survivor's row and the duplicate's is dropped; a nomination both held
+// stays a win if either of them won it.
+var mergeStatements = []string{
+	"UPDATE actormovies SET actor_id = @survivor WHERE actor_id = @duplicate AND movie_id NOT IN (SELECT movie_id FROM actormovies WHERE actor_id = @survivor)",
+	"DELETE FROM actormovies WHERE actor_id = @duplicate",
+	"UPDATE episodeactors SET actor_id = @survivor WHERE actor_id = @duplicate AND episode_id NOT IN (SELECT episode_id FROM episodeactors WHERE actor_id = @survivor)",
+	"DELETE FROM episodeactors WHERE actor_id = @duplicate",
+	"UPDATE crew_credits SET actor_id = @survivor WHERE actor_id = @duplicate AND NOT EXISTS " +
+		"(SELECT 1 FROM crew_credits AS kept WHERE kept.actor_id = @survivor AND kept.movie_id = crew_credits.movie_id AND kept.job = crew_credits.job)",
+	"DELETE FROM crew_credits WHERE actor_id = @duplicate",
+	"UPDATE actor_follows SET actor_id = @survivor WHERE actor_id = @duplicate AND user_id NOT IN (SELECT user_id FROM actor_follows WHERE actor_id = @survivor)",
+	"DELETE FROM actor_follows WHERE actor_id = @duplicate",
+	"UPDATE actor_translations SET actor_id = @survivor WHERE actor_id = @duplicate AND language NOT IN (SELECT language FROM actor_translations WHERE actor_id = @survivor)",
+	"DELETE FROM actor_translations WHERE actor_id = @duplicate",
+	"UPDATE external_ids SET actor_id = @survivor WHERE actor_id = @duplicate AND source NOT IN (SELECT source FROM external_ids WHERE actor_id = @survivor)",
+	"DELETE FROM external_ids WHERE actor_id = @duplicate",
+	"UPDATE nominations AS kept SET won = true WHERE kept.actor_id = @survivor AND NOT kept.won AND EXISTS " +
+		"(SELECT 1 FROM nominations AS moved WHERE moved.actor_id = @duplicate AND moved.won AND " + sameNomination + ")",
+	"DELETE FROM nominations AS moved WHERE moved.actor_id = @duplicate AND EXISTS " +
+		"(SELECT 1 FROM nominations AS kept WHERE kept.actor_id = @survivor AND " + sameNomination + ")",
+	"UPDATE nominations SET actor_id = @survivor WHERE actor_id = @duplicate",
+	"UPDATE notifications SET actor_id = @survivor WHERE actor_id = @duplicate",
+	"UPDATE actor_aliases SET actor_id = @survivor WHERE actor_id = @duplicate",
+	"UPDATE actor_redirects SET to_id = @survivor WHERE to_id = @duplicate",
+}
+
+// ActorDuplicates godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Finds likely duplicate actors
+// @Description Suggests pairs of actors that are probably the same person, best matches first. Names and aliases are compared after normalizing case, punctuation, the letter ё and word order, so "Mikhalkov, Nikita" matches "Nikita Mikhalkov"; matching dates of birth raise the score and different ones lower it. Requires 'admin' role.
+// @Tags actor
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param actorId query int false "Only look for duplicates of the actor with this ID"
+// @Param minScore query int false "Minimum score in percent (default: 85)"
+// @Param limit query int false "Maximum number of pairs to return (default: 50)"
+// @Success 200 {array} models.DuplicateCandidate "Successfully retrieved the candidates"
+// @Failure 400 "Invalid actor ID, score or limit"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Actor not found"
+// @Failure 500 "Error retrieving actors"
+// @Router /v1/actor-duplicates [get]
+func (PG *Postgresql) ActorDuplicates(w http.ResponseWriter, r *http.Request) (*[]models.DuplicateCandidate, error) {
+	log.Info().Msg("ActorDuplicates called")
+
+	actorID, err := intQuery(w, r, "actorId", 0)
+	if err != nil {
+		return nil, err
+	}
+	minScore, err := intQuery(w, r, "minScore", 85)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := intQuery(w, r, "limit", 50)
+	if err != nil {
+		return nil, err
+	}
+	if minScore < 0 || minScore > 100 || limit < 1 {
+		log.Error().Int("minScore", minScore).Int("limit", limit).Msg("Invalid duplicate search parameters")
+		http.Error(w, "Invalid minScore or limit", http.StatusBadRequest)
+		return nil, errors.New("invalid duplicate search parameters")
+	}
+
+	if actorID > 0 {
+		actorID = PG.resolveActorID(actorID)
+		var count int64
+		if err := PG.DB.Model(&models.Actor{}).Where("id = ?", actorID).Count(&count).Error; err != nil || count == 0 {
+			log.Error().Err(err).Int("actorID", actorID).Msg("Actor not found")
+			http.Error(w, "Actor not found", http.StatusNotFound)
+			return nil, errors.New("actor not found")
+		}
+	}
+
+	data := []models.DuplicateCandidate{}
+	err = PG.scanDuplicates(actorID, float64(minScore)/100, func(candidates []models.DuplicateCandidate) {
+		data = append(data, candidates...)
+		sortDuplicates(data)
+		if len(data) > limit {
+			data = data[:limit]
+		}
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving actors")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Duplicate candidates retrieved successfully")
+	return &data, nil
+}
+
+// ActorMerge godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Merges a duplicate actor into another
+// @Description Moves the movies, episodes, crew credits, followers, translations, aliases, nominations and external IDs of the duplicate actor over to the actor with the specified ID in a single transaction, then removes the duplicate. Details the survivor lacks, such as the date of birth, biography or photo, are taken from the duplicate and its name is kept as an alias. Where both had the same link, such as a credit in the same movie, the survivor's is kept. The duplicate's ID keeps resolving to the survivor, and the merge is recorded in the history of both. Requires 'admin' role.
+// @Tags actor
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "ID of the actor to keep"
+// @Param duplicate query int true "ID of the duplicate actor to merge into it"
+// @Success 200 {object} models.Actor "Successfully merged the actors"
+// @Failure 400 "Invalid actor ID, missing duplicate or an actor merged into itself"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Actor not found"
+// @Failure 500 "Error merging the actors"
+// @Router /v1/actor-merge/{id} [put]
+func (PG *Postgresql) ActorMerge(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
+	log.Info().Msg("ActorMerge called")
+
+	survivorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+	duplicateID, err := intQuery(w, r, "duplicate", 0)
+	if err != nil {
+		return nil, err
+	}
+	if duplicateID <= 0 || duplicateID == survivorID {
+		log.Error().Int("survivorID", survivorID).Int("duplicateID", duplicateID).Msg("Invalid duplicate")
+		http.Error(w, "Missing duplicate or an actor merged into itself", http.StatusBadRequest)
+		return nil, errors.New("invalid duplicate")
+	}
+
+	for _, id := range []int{survivorID, duplicateID} {
+		var count int64
+		if err := PG.DB.Model(&models.Actor{}).Where("id = ?", id).Count(&count).Error; err != nil || count == 0 {
+			log.Error().Err(err).Int("actorID", id).Msg("Actor not found")
+			http.Error(w, fmt.Sprintf("Actor %d not found", id), http.StatusNotFound)
+			return nil, fmt.Errorf("actor %d not found", id)
+		}
+	}
+
+	var movieIDs []int
+	if err := PG.DB.Table("actormovies").Where("actor_id = ?", duplicateID).Pluck("movie_id", &movieIDs).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to load the duplicate's movies")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	unusedPhoto, err := PG.mergeActors(r, survivorID, duplicateID)
+	if err != nil {
+		log.Error().Err(err).Msg("Error merging the actors")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	PG.deleteImage(unusedPhoto)
+	PG.refreshAfterCastChange([]int{duplicateID}, movieIDs...)
+
+	var data models.Actor
+	if err := preloadAliases(PG.DB).First(&data, "id = ?", survivorID).Error; err != nil {
+		log.Error().Err(err).Msg("Error loading the merged actor")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	actors := []models.Actor{data}
+	if err := PG.loadFilmography(actors); err != nil {
+		log.Error().Err(err).Msg("Error loading filmography")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("survivorID", survivorID).Int("duplicateID", duplicateID).Msg("Actors merged successfully")
+	return &actors[0], nil
+}
+
+// mergeActors moves everything linked to the duplicate over to the survivor, fills in the details the survivor lacks,
+// keeps the duplicate's name as an alias, leaves a redirect and removes the duplicate, all in one transaction,
+// which also records the merge in the history of both actors.
+// Returns the key of the duplicate's photo when the survivor kept its own, so the caller can delete it once committed.
+func (PG *Postgresql) mergeActors(r *http.Request, survivorID, duplicateID int) (string, error) {
+	var unusedPhoto string
+	err := PG.DB.Transaction(func(tx *gorm.DB) error {
+		var survivor, duplicate models.Actor
+		locked := tx.Clauses(clause.Locking{Strength: "UPDATE"})
+		if err := locked.First(&survivor, "id = ?", survivorID).Error; err != nil {
+			return err
+		}
+		if err := locked.First(&duplicate, "id = ?", duplicateID).Error; err != nil {
+			return err
+		}
+		survivorBefore, err := actorSnapshot(tx, survivorID)
+		if err != nil {
+			return err
+		}
+		duplicateBefore, err := actorSnapshot(tx, duplicateID)
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		fillBlank := func(column, current, fallback string) {
+			if current == "" && fallback != "" {
+				updates[column] = fallback
+			}
+		}
+		fillBlank("gender", survivor.Gender, duplicate.Gender)
+		fillBlank("date_of_birth", survivor.DateOfBirth, duplicate.DateOfBirth)
+		fillBlank("date_of_death", survivor.DateOfDeath, duplicate.DateOfDeath)
+		fillBlank("place_of_birth", survivor.PlaceOfBirth, duplicate.PlaceOfBirth)
+		fillBlank("biography", survivor.Biography, duplicate.Biography)
+		fillBlank("photo_key", survivor.PhotoKey, duplicate.PhotoKey)
+		if _, taken := updates["photo_key"]; !taken {
+			unusedPhoto = duplicate.PhotoKey
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&models.Actor{}).Where("id = ?", survivorID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		names := map[string]interface{}{"survivor": survivorID, "duplicate": duplicateID}
+		for _, statement := range mergeStatements {
+			if err := tx.Exec(statement, names).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(&models.ActorAlias{ActorID: survivorID, Name: duplicate.Name, Kind: "alternate"}).Error; err != nil {
+			return err
+		}
+		// Aliases both actors had, and the survivor's own name, are kept once.
+		err = tx.Exec("DELETE FROM actor_aliases AS moved WHERE moved.actor_id = @survivor AND (lower(moved.name) = lower(@name) OR EXISTS "+
+			"(SELECT 1 FROM actor_aliases AS kept WHERE kept.actor_id = @survivor AND lower(kept.name) = lower(moved.name) AND kept.id < moved.id))",
+			map[string]interface{}{"survivor": survivorID, "name": survivor.Name}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Create(&models.ActorRedirect{FromID: duplicateID, ToID: survivorID}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("id = ?", duplicateID).Delete(&models.Actor{}).Error; err != nil {
+			return err
+		}
+
+		duplicateRevision := &models.Revision{EntityType: "actor", EntityID: duplicateID, Action: "merge", MergedWith: &survivorID}
+		if _, err := recordRevision(tx, r, duplicateRevision, duplicateBefore, nil); err != nil {
+			return err
+		}
+		survivorAfter, err := actorSnapshot(tx, survivorID)
+		if err != nil {
+			return err
+		}
+		survivorRevision := &models.Revision{EntityType: "actor", EntityID: survivorID, Action: "merge", MergedWith: &duplicateID}
+		_, err = recordRevision(tx, r, survivorRevision, survivorBefore, survivorAfter)
+		return err
+	})
+	return unusedPhoto, err
+}
+
+// resolveActorID follows the redirect left by a merge, returning the ID of the actor that absorbed the given one,
+// or the ID itself when it was never merged.
+func (PG *Postgresql) resolveActorID(actorID int) int {
+	var redirect models.ActorRedirect
+	if err := PG.DB.Where("from_id = ?", actorID).Limit(1).Find(&redirect).Error; err != nil || redirect.ToID == 0 {
+		return actorID
+	}
+	return redirect.ToID
+}
+
+// resolveActorIDs follows the merge redirects of all the given IDs.
+func (PG *Postgresql) resolveActorIDs(actorIDs []int) []int {
+	var redirects []models.ActorRedirect
+	if len(actorIDs) == 0 || PG.DB.Where("from_id IN ?", actorIDs).Find(&redirects).Error != nil {
+		return actorIDs
+	}
+	targets := make(map[int]int, len(redirects))
+	for _, redirect := range redirects {
+		targets[redirect.FromID] = redirect.ToID
+	}
+	resolved := make([]int, len(actorIDs))
+	for i, id := range actorIDs {
+		resolved[i] = id
+		if target, ok := targets[id]; ok {
+			resolved[i] = target
+		}
+	}
+	return resolved
+}
+
+// resolveCastEntries follows the merge redirects of the actors of the cast entries, so credits given under the ID of a merged
+// actor go to the actor that absorbed it. A nil cast, which leaves the cast unchanged, stays nil.
+func (PG *Postgresql) resolveCastEntries(entries []castEntry) []castEntry {
+	if entries == nil {
+		return nil
+	}
+	ids := PG.resolveActorIDs(castEntryIDs(entries))
+	resolved := make([]castEntry, len(entries))
+	for i, entry := range entries {
+		resolved[i] = castEntry{id: ids[i], credit: entry.credit}
+	}
+	return resolved
+}
+
+// actorIDFromPath reads the actor ID at the end of the URL path like idFromPath, following the redirect of a merged actor.
+func (PG *Postgresql) actorIDFromPath(w http.ResponseWriter, r *http.Request) (int, error) {
+	actorID, err := idFromPath(w, r, "actor")
+	if err != nil {
+		return 0, err
+	}
+	return PG.resolveActorID(actorID), nil
+}
+
+// scanDuplicates streams the pairs of actors sharing the first three letters of a name or alias word from the database
+// and scores them duplicatePageSize pairs at a time, passing the candidates of each page scoring at least minScore to each.
+// Only the actors of the page are loaded, so neither memory nor the comparisons grow with the square of the actors.
+// When actorID is set only the pairs including that actor are considered.
+func (PG *Postgresql) scanDuplicates(actorID int, minScore float64, each func([]models.DuplicateCandidate)) error {
+	pairs := make([][2]int, 0, duplicatePageSize)
+	score := func() error {
+		if len(pairs) == 0 {
+			return nil
+		}
+		ids := make([]int, 0, 2*len(pairs))
+		for _, pair := range pairs {
+			ids = append(ids, pair[0], pair[1])
+		}
+		var actors []models.Actor
+		if err := preloadAliases(PG.DB).Where("id IN ?", ids).Find(&actors).Error; err != nil {
+			return err
+		}
+		byID := make(map[int]*models.Actor, len(actors))
+		for i := range actors {
+			byID[actors[i].ID] = &actors[i]
+		}
+		each(findDuplicates(byID, pairs, minScore))
+		pairs = pairs[:0]
+		return nil
+	}
+
+	err := streamRows(PG.DB.Raw(duplicatePairsQuery, map[string]interface{}{"actor": actorID}), func(rows *sql.Rows) error {
+		var pair [2]int
+		if err := rows.Scan(&pair[0], &pair[1]); err != nil {
+			return err
+		}
+		pairs = append(pairs, pair)
+		if len(pairs) < duplicatePageSize {
+			return nil
+		}
+		return score()
+	})
+	if err != nil {
+		return err
+	}
+	return score()
+}
+
+// findDuplicates scores the given pairs of actor IDs and returns those scoring at least minScore, best first.
+// Pairs with an actor missing from actors, such as one trashed since the pairs were read, are skipped.
+func findDuplicates(actors map[int]*models.Actor, pairs [][2]int, minScore float64) []models.DuplicateCandidate {
+	names := make(map[int][]string, len(actors))
+	for id, actor := range actors {
+		names[id] = append(names[id], normalizeName(actor.Name))
+		for _, alias := range actor.Aliases {
+			names[id] = append(names[id], normalizeName(alias.Name))
+		}
+	}
+
+	var candidates []models.DuplicateCandidate
+	for _, pair := range pairs {
+		a, b := actors[pair[0]], actors[pair[1]]
+		if a == nil || b == nil {
+			continue
+		}
+		score, reasons := duplicateScore(a, b, names[a.ID], names[b.ID])
+		if score >= minScore {
+			candidates = append(candidates, models.DuplicateCandidate{Actor: a, Duplicate: b, Score: score, Reasons: reasons})
+		}
+	}
+
+	sortDuplicates(candidates)
+	return candidates
+}
+
+// sortDuplicates orders the candidates best first, then by the IDs of the actors.
+func sortDuplicates(candidates []models.DuplicateCandidate) {
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].Score != candidates[b].Score {
+			return candidates[a].Score > candidates[b].Score
+		}
+		if candidates[a].Actor.ID != candidates[b].Actor.ID {
+			return candidates[a].Actor.ID < candidates[b].Actor.ID
+		}
+		return candidates[a].Duplicate.ID < candidates[b].Duplicate.ID
+	})
+}
+
+// duplicateScore rates how likely two actors are the same person from the best match between their names and aliases
+// and from their dates of birth, explaining the score.
+func duplicateScore(a, b *models.Actor, namesA, namesB []string) (float64, []string) {
+	nameScore, aliasMatch := 0.0, false
+	for i, nameA := range namesA {
+		for j, nameB := range namesB {
+			if similarity := nameSimilarity(nameA, nameB); similarity > nameScore {
+				nameScore, aliasMatch = similarity, i > 0 || j > 0
+			}
+		}
+	}
+
+	var reasons []string
+	switch {
+	case nameScore == 1 && aliasMatch:
+		reasons = append(reasons, "alias matches")
+	case nameScore == 1:
+		reasons = append(reasons, "same name")
+	default:
+		reasons = append(reasons, fmt.Sprintf("similar name (%.2f)", nameScore))
+	}
+
+	birthScore := 0.5
+	birthA, errA := time.Parse("2006-01-02", a.DateOfBirth)
+	birthB, errB := time.Parse("2006-01-02", b.DateOfBirth)
+	switch {
+	case errA != nil || errB != nil:
+		reasons = append(reasons, "date of birth unknown")
+	case birthA.Equal(birthB):
+		birthScore = 1
+		reasons = append(reasons, "same date of birth")
+	case birthA.Year() == birthB.Year():
+		birthScore = 0.75
+		reasons = append(reasons, "same year of birth")
+	default:
+		birthScore = 0
+		reasons = append(reasons, "different dates of birth")
+	}
+
+	score := duplicateNameWeight*nameScore + duplicateBirthWeight*birthScore
+	return math.Round(score*100) / 100, reasons
+}
+
+// normalizeName lower-cases a name, folds ё into е, turns punctuation into spaces and sorts the words,
+// so spelling variants and "Surname, Name" orderings compare equal.
+func normalizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		switch {
+		case r == 'ё':
+			return 'е'
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return r
+		default:
+			return ' '
+		}
+	}, name)
+	words := strings.Fields(name)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// nameSimilarity returns one minus the edit distance of the normalized names relative to the longer one.
+func nameSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	runesA, runesB := []rune(a), []rune(b)
+	longest := len(runesA)
+	if len(runesB) > longest {
+		longest = len(runesB)
+	}
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(runesA, runesB))/float64(longest)
+}
+
+// levenshtein returns the number of single letter insertions, deletions and substitutions turning a into b.
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"vk.com/m/models"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"kitten", "kitten", 0},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"михалков", "михалкова", 1},
+		{"андрей", "андрэй", 1},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := levenshtein([]rune(tt.b), []rune(tt.a)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Nikita Mikhalkov", "mikhalkov nikita"},
+		{"Mikhalkov, Nikita", "mikhalkov nikita"},
+		{"  Фёдор   Бондарчук ", "бондарчук федор"},
+		{"Jean-Paul Belmondo", "belmondo jean paul"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeName(tt.name); got != tt.want {
+			t.Errorf("normalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"mikhalkov nikita", "mikhalkov nikita", 1},
+		{"", "", 1},
+		{"abcd", "abce", 0.75},
+		{"abcd", "wxyz", 0},
+	}
+
+	for _, tt := range tests {
+		if got := nameSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("nameSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	actors := map[int]*models.Actor{
+		1: {ID: 1, Name: "Nikita Mikhalkov", DateOfBirth: "1945-10-21"},
+		2: {ID: 2, Name: "Mikhalkov, Nikita", DateOfBirth: "1945-10-21"},
+		3: {ID: 3, Name: "Nikita Mikhalkov"},
+		4: {ID: 4, Name: "Nikita Mikhalkov", DateOfBirth: "1990-01-01"},
+		5: {ID: 5, Name: "N. Mikhalkov", Aliases: []*models.ActorAlias{{Name: "Никита Михалков"}}},
+		6: {ID: 6, Name: "Никита Михалков"},
+		7: {ID: 7, Name: "Oleg Tabakov"},
+	}
+	pairs := [][2]int{{1, 2}, {1, 3}, {1, 4}, {1, 7}, {5, 6}, {6, 9}}
+
+	got := findDuplicates(actors, pairs, 0.85)
+
+	type match struct {
+		actor, duplicate int
+		score            float64
+		reasons          []string
+	}
+	want := []match{
+		{1, 2, 1, []string{"same name", "same date of birth"}},
+		{1, 3, 0.9, []string{"same name", "date of birth unknown"}},
+		{5, 6, 0.9, []string{"alias matches", "date of birth unknown"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("findDuplicates returned %d candidates, want %d: %+v", len(got), len(want), got)
+	}
+	for i, candidate := range got {
+		w := want[i]
+		if candidate.Actor.ID != w.actor || candidate.Duplicate.ID != w.duplicate || math.Abs(candidate.Score-w.score) > 1e-9 || !reflect.DeepEqual(candidate.Reasons, w.reasons) {
+			t.Errorf("candidate %d = %d, %d scoring %v %v, want %d, %d scoring %v %v",
+				i, candidate.Actor.ID, candidate.Duplicate.ID, candidate.Score, candidate.Reasons, w.actor, w.duplicate, w.score, w.reasons)
+		}
+	}
+
+	if all := findDuplicates(actors, pairs, 0); len(all) != 5 {
+		t.Errorf("findDuplicates without a threshold returned %d candidates, want the 5 pairs of known actors", len(all))
+	}
+}
@@ -1,13 +1,18 @@
 package services
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 	"vk.com/m/models"
 	"vk.com/m/utils"
 )
@@ -17,16 +22,17 @@ import (
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
 // @Summary Adds a new movie
-// @Description Adds a new movie with the given details including title, description, release date, and rating. Requires 'admin' role.
+// @Description Adds a new movie with the given details including title, description, release date, and rating. Each actor may carry a Credit with the character, billing order and credit type. Genres, tags and studios are linked by ID and must already exist. Countries of origin and spoken languages are given by their ISO 3166-1 and ISO 639-1 codes, and certifications as age ratings per country code. ExternalIDs record the movie's identifiers in IMDb, Kinopoisk or Wikidata; adding a movie under an identifier that is already recorded is refused, so ingestion jobs can look movies up by it instead of duplicating them. Requires 'admin' role.
 // @Tags movie
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer [JWT token]"
 // @Param movie body models.Movie true "Movie to add"
 // @Success 200 {object} models.Movie "Successfully added the movie"
-// @Failure 400 "Invalid request body"
+// @Failure 400 "Invalid request body, runtime, country or language code, certification, external ID or unknown genre, tag or studio IDs"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "One of the external IDs is already recorded"
 // @Failure 500 "Error creating movie"
 // @Router /v1/movie-add [post]
 func (PG *Postgresql) MovieAdd(w http.ResponseWriter, r *http.Request) (*models.Movie, error) {
@@ -43,13 +49,91 @@ func (PG *Postgresql) MovieAdd(w http.ResponseWriter, r *http.Request) (*models.
 
 	formattedDate := utils.FormatTime(data.ReleaseDate)
 	data.ReleaseDate = formattedDate
+	data.CommunityRating, data.VoteCount = 0, 0
 
-	if err := PG.DB.Create(&data).Error; err != nil {
+	genreIDs, tagIDs, studioIDs := genreIDsOf(data.Genres), tagIDsOf(data.Tags), studioIDsOf(data.Studios)
+	requestedCountries, requestedLanguages := countryCodesOf(data.Countries), languageCodesOf(data.SpokenLanguages)
+	ratings := certificationRatings(data.Certifications)
+	data.Genres, data.Tags, data.Studios, data.Countries, data.SpokenLanguages, data.Certifications = nil, nil, nil, nil, nil, nil
+
+	countries, err := countryCodes(requestedCountries)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid country")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	languages, err := languageCodes(requestedLanguages)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid language")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	certifications, err := parseCertifications(ratings)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid certification")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	if data.Runtime < 0 {
+		log.Error().Int("runtime", data.Runtime).Msg("Invalid runtime")
+		http.Error(w, "Runtime must not be negative", http.StatusBadRequest)
+		return nil, errors.New("negative runtime")
+	}
+	if err := validExternalIDs(data.ExternalIDs, false); err != nil {
+		log.Error().Err(err).Msg("Invalid external ID")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for i, actorID := range PG.resolveActorIDs(actorIDsOf(data.Actors)) {
+		data.Actors[i].ID = actorID
+	}
+	credits, err := parseCastEntries(actorCredits(data.Actors))
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid credit")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		// Crew credits are added through CrewAdd, which validates the job.
+		if err := tx.Omit("Crew").Create(&data).Error; err != nil {
+			return err
+		}
+		err := applyCredits(tx, credits, func(entry castEntry) (int, int) { return entry.id, data.ID })
+		if err != nil {
+			return err
+		}
+		if _, _, err := syncAssociation[models.Genre](tx, &data, "Genres", nil, genreIDs); err != nil {
+			return err
+		}
+		if _, _, err := syncAssociation[models.Tag](tx, &data, "Tags", nil, tagIDs); err != nil {
+			return err
+		}
+		if _, _, err := syncAssociation[models.Studio](tx, &data, "Studios", nil, studioIDs); err != nil {
+			return err
+		}
+		if err := replaceCountries(tx, &data, countries); err != nil {
+			return err
+		}
+		if err := replaceSpokenLanguages(tx, &data, languages); err != nil {
+			return err
+		}
+		if err := replaceCertifications(tx, data.ID, certifications); err != nil {
+			return err
+		}
+		_, err = recordMovieRevision(tx, r, data.ID, "create", nil)
+		return err
+	})
+	if err != nil {
 		log.Error().Err(err).Msg("Error creating actor")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), associationStatus(err))
 		return nil, err
 	}
 
+	PG.refreshAfterCastChange(nil, data.ID)
+	PG.notifyFollowers(data.ID, actorIDsOf(data.Actors))
+
 	return &data, nil
 }
 
@@ -58,7 +142,7 @@ func (PG *Postgresql) MovieAdd(w http.ResponseWriter, r *http.Request) (*models.
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
 // @Summary Edits an existing movie
-// @Description Edits a movie with the specified ID based on the given update fields such as title, description, release date, rating, and associated actors. Requires 'admin' role.
+// @Description Edits a movie with the specified ID based on the given update fields such as title, description, release date (null to clear), rating, runtime, and associated actors, genres, tags and studios (arrays of IDs). Countries and languages are arrays of ISO 3166-1 and ISO 639-1 codes and certifications an object of age ratings keyed by country code, e.g. {"RU": "16+"}; each replaces the current values. Actors may also be given as objects {id, character, billingOrder, creditType} to set their credit; creditType is one of lead, supporting, cameo or voice. Requires 'admin' role.
 // @Tags movie
 // @Accept json
 // @Produce json
@@ -66,7 +150,7 @@ func (PG *Postgresql) MovieAdd(w http.ResponseWriter, r *http.Request) (*models.
 // @Param id path int true "Movie ID"
 // @Param updates body map[string]interface{} true "Fields to update"
 // @Success 200 {object} models.Movie "Successfully updated the movie"
-// @Failure 400 "Invalid request body or movie ID"
+// @Failure 400 "Invalid request body, movie ID, credit, runtime, country or language code, certification or unknown actor, genre, tag or studio IDs"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
 // @Failure 404 "Movie not found"
@@ -76,8 +160,6 @@ func (PG *Postgresql) MovieEdit(w http.ResponseWriter, r *http.Request) (*models
 
 	log.Info().Msg("MovieEdit called")
 
-	var data models.Movie
-
 	pathParts := strings.Split(r.URL.Path, "/")
 	if len(pathParts) < 4 {
 		log.Error().Msg("Invalid URL format")
@@ -92,13 +174,6 @@ func (PG *Postgresql) MovieEdit(w http.ResponseWriter, r *http.Request) (*models
 		return nil, err
 	}
 
-	log.Debug().Int("movieID", movieID).Msg("Fetching movie from database")
-	if err := PG.DB.Preload("Actors").First(&data, "id = ?", movieID).Error; err != nil {
-		log.Error().Err(err).Msg("Movie not found")
-		http.Error(w, "Movie not found", http.StatusNotFound)
-		return nil, err
-	}
-
 	var updates map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
 		log.Error().Err(err).Msg("Error decoding updates")
@@ -106,14 +181,97 @@ func (PG *Postgresql) MovieEdit(w http.ResponseWriter, r *http.Request) (*models
 		return nil, err
 	}
 
-	log.Debug().Interface("updates", updates).Msg("Applying updates to movie")
-	if err := PG.DB.First(&data, "id = ?", movieID).Error; err != nil {
-		log.Error().Err(err).Msg("Movie not found for updating")
-		http.Error(w, "Movie not found", http.StatusNotFound)
+	data, _, err := PG.editMovie(w, r, movieID, updates, &models.Revision{Action: "edit"}, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	for field, value := range updates {
+	log.Info().Int("movieID", movieID).Msg("Movie successfully updated")
+	return data, nil
+}
+
+// movieEdit holds the MovieEdit update fields, parsed and validated before anything is written.
+// The association fields are nil when the update leaves them unchanged.
+type movieEdit struct {
+	updates        map[string]interface{}
+	cast           []castEntry
+	genreIDs       []int
+	tagIDs         []int
+	studioIDs      []int
+	countries      []string
+	languages      []string
+	certifications []*models.Certification
+}
+
+// parseMovieEdit validates the MovieEdit update fields. Every error it returns is the client's mistake.
+func parseMovieEdit(updates map[string]interface{}) (*movieEdit, error) {
+	edit := &movieEdit{updates: updates}
+
+	if value, ok := updates["runtime"]; ok {
+		if runtime, ok := value.(float64); !ok || runtime < 0 || runtime != math.Trunc(runtime) {
+			return nil, fmt.Errorf("invalid runtime %v, expected a whole number of minutes from 0", value)
+		}
+	}
+
+	if values, ok := updates["actors"].([]interface{}); ok {
+		cast, err := parseCastEntries(values)
+		if err != nil {
+			return nil, err
+		}
+		edit.cast = append([]castEntry{}, cast...)
+	}
+	if values, ok := updates["genres"].([]interface{}); ok {
+		edit.genreIDs = utils.InterfacesToInts(values)
+	}
+	if values, ok := updates["tags"].([]interface{}); ok {
+		edit.tagIDs = utils.InterfacesToInts(values)
+	}
+	if values, ok := updates["studios"].([]interface{}); ok {
+		edit.studioIDs = utils.InterfacesToInts(values)
+	}
+	if values, ok := updates["countries"].([]interface{}); ok {
+		countries, err := countryCodes(interfacesToStrings(values))
+		if err != nil {
+			return nil, err
+		}
+		edit.countries = append([]string{}, countries...)
+	}
+	if values, ok := updates["languages"].([]interface{}); ok {
+		languages, err := languageCodes(interfacesToStrings(values))
+		if err != nil {
+			return nil, err
+		}
+		edit.languages = append([]string{}, languages...)
+	}
+	if values, ok := updates["certifications"].(map[string]interface{}); ok {
+		ratings := make(map[string]string, len(values))
+		for code, rating := range values {
+			ratings[code], _ = rating.(string)
+		}
+		certifications, err := parseCertifications(ratings)
+		if err != nil {
+			return nil, err
+		}
+		edit.certifications = append([]*models.Certification{}, certifications...)
+	}
+
+	return edit, nil
+}
+
+// movieEditResult records what an edit changed, for the work done once it is committed.
+type movieEditResult struct {
+	formerActorIDs []int
+	addedActorIDs  []int
+	castChanged    bool
+	genresChanged  []int
+	tagsChanged    []int
+}
+
+// applyMovieEdit writes the edit to the movie, loaded with its actors, genres, tags and studios, within the transaction.
+func applyMovieEdit(tx *gorm.DB, data *models.Movie, edit *movieEdit) (*movieEditResult, error) {
+	result := &movieEditResult{}
+
+	for field, value := range edit.updates {
 		switch field {
 		case "title":
 			if title, ok := value.(string); ok {
@@ -124,7 +282,9 @@ func (PG *Postgresql) MovieEdit(w http.ResponseWriter, r *http.Request) (*models
 				data.Description = description
 			}
 		case "releasedate":
-			if releasedatestr, ok := value.(string); ok {
+			if value == nil {
+				data.ReleaseDate = ""
+			} else if releasedatestr, ok := value.(string); ok {
 				formattedReleaseDate := utils.FormatTime(releasedatestr)
 				data.ReleaseDate = formattedReleaseDate
 			}
@@ -132,52 +292,158 @@ func (PG *Postgresql) MovieEdit(w http.ResponseWriter, r *http.Request) (*models
 			if rating, ok := value.(float64); ok {
 				data.Rating = rating
 			}
+		case "runtime":
+			if runtime, ok := value.(float64); ok {
+				data.Runtime = int(runtime)
+			}
 		}
 	}
 
-	if actorIDsInterface, ok := updates["actors"].([]interface{}); ok {
-		var actorsToAdd []models.Actor
-		var currentActorIDs []int
-
-		for _, m := range data.Actors {
-			currentActorIDs = append(currentActorIDs, m.ID)
+	if edit.cast != nil {
+		added, removed, err := syncAssociation[models.Actor](tx, data, "Actors", actorIDsOf(data.Actors), castEntryIDs(edit.cast))
+		if err != nil {
+			return nil, err
 		}
-
-		for _, idInterface := range actorIDsInterface {
-			idInt, err := utils.InterfaceToInt(idInterface)
-			if err == nil && !utils.Contains(currentActorIDs, idInt) {
-				actorsToAdd = append(actorsToAdd, models.Actor{ID: idInt})
-			}
+		if err := applyCredits(tx, edit.cast, func(entry castEntry) (int, int) { return entry.id, data.ID }); err != nil {
+			return nil, err
 		}
-
-		var actorsToRemove []models.Actor
-		for _, currentID := range currentActorIDs {
-			if !utils.ContainsInterfaceAsInt(actorIDsInterface, currentID) {
-				actorsToRemove = append(actorsToRemove, models.Actor{ID: currentID})
-			}
+		result.castChanged = len(added) > 0 || len(removed) > 0
+		result.formerActorIDs, result.addedActorIDs = removed, added
+	}
+	if edit.genreIDs != nil {
+		added, removed, err := syncAssociation[models.Genre](tx, data, "Genres", genreIDsOf(data.Genres), edit.genreIDs)
+		if err != nil {
+			return nil, err
+		}
+		result.genresChanged = append(added, removed...)
+	}
+	if edit.tagIDs != nil {
+		added, removed, err := syncAssociation[models.Tag](tx, data, "Tags", tagIDsOf(data.Tags), edit.tagIDs)
+		if err != nil {
+			return nil, err
+		}
+		result.tagsChanged = append(added, removed...)
+	}
+	if edit.studioIDs != nil {
+		if _, _, err := syncAssociation[models.Studio](tx, data, "Studios", studioIDsOf(data.Studios), edit.studioIDs); err != nil {
+			return nil, err
 		}
+	}
+	if edit.countries != nil {
+		if err := replaceCountries(tx, data, edit.countries); err != nil {
+			return nil, err
+		}
+	}
+	if edit.languages != nil {
+		if err := replaceSpokenLanguages(tx, data, edit.languages); err != nil {
+			return nil, err
+		}
+	}
+	if edit.certifications != nil {
+		if err := replaceCertifications(tx, data.ID, edit.certifications); err != nil {
+			return nil, err
+		}
+	}
+
+	// The community rating and poster are maintained by their own endpoints and must not be overwritten with the values loaded above.
+	if err := tx.Omit("CommunityRating", "VoteCount", "PosterKey", "Certifications").Save(data).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// editMovie applies the MovieEdit update fields to the movie and returns it with its cast, crew and production details,
+// and the revision recorded for the edit, nil when it changed nothing.
+// Every field is validated first, then all of them are written in a single transaction together with the revision,
+// so a failed edit changes nothing and every edit is in the history.
+// It writes the error response itself; revert and proposal approval reuse it with their own revision action,
+// and approval with a hook claiming the proposal in the same transaction. hook may be nil.
+func (PG *Postgresql) editMovie(w http.ResponseWriter, r *http.Request, movieID int, updates map[string]interface{}, revision *models.Revision, hook editHook) (*models.Movie, *models.Revision, error) {
+	edit, err := parseMovieEdit(updates)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid movie update")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, nil, err
+	}
+	edit.cast = PG.resolveCastEntries(edit.cast)
 
-		if len(actorsToAdd) > 0 {
-			if err := PG.DB.Model(&data).Association("Actors").Append(actorsToAdd); err != nil {
-				log.Error().Err(err).Msg("Failed to add actors")
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+	var data models.Movie
+	var result *movieEditResult
+	var recorded *models.Revision
+	errNotFound := errors.New("movie not found")
+	log.Debug().Interface("updates", updates).Int("movieID", movieID).Msg("Applying updates to movie")
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		apply := func() (*models.Revision, error) {
+			err := tx.Preload("Actors").Preload("Genres").Preload("Tags").Preload("Studios").First(&data, "id = ?", movieID).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errNotFound
+			}
+			if err != nil {
 				return nil, err
 			}
+			before, err := movieSnapshot(tx, movieID)
+			if err != nil {
+				return nil, err
+			}
+			if result, err = applyMovieEdit(tx, &data, edit); err != nil {
+				return nil, err
+			}
+			after, err := movieSnapshot(tx, movieID)
+			if err != nil {
+				return nil, err
+			}
+			revision.EntityType, revision.EntityID = "movie", movieID
+			recorded, err = recordRevision(tx, r, revision, before, after)
+			return recorded, err
 		}
-		if len(actorsToRemove) > 0 {
-			log.Debug().Interface("actorsToRemove", actorsToAdd).Msg("Removing actors to movie")
-			PG.DB.Model(&data).Association("Actors").Delete(actorsToRemove)
-		}
+		return hook.run(tx, apply)
+	})
+	if errors.Is(err, errNotFound) {
+		log.Error().Err(err).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusNotFound)
+		return nil, nil, err
 	}
-
-	if err := PG.DB.Save(&data).Error; err != nil {
+	if errors.Is(err, errProposalReviewed) {
+		log.Error().Err(err).Msg("Proposal already reviewed")
+		http.Error(w, "Proposal already reviewed", http.StatusConflict)
+		return nil, nil, err
+	}
+	if err != nil {
 		log.Error().Err(err).Msg("Error saving movie")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, nil, err
+	}
+
+	if result.castChanged {
+		PG.refreshAfterCastChange(result.formerActorIDs, movieID)
+	}
+	PG.notifyFollowers(movieID, result.addedActorIDs)
+	if len(result.genresChanged) > 0 || len(result.tagsChanged) > 0 {
+		PG.refreshAfterTaxonomyChange(movieID, genreIDsOf(data.Genres), tagIDsOf(data.Tags), result.genresChanged, result.tagsChanged)
+	}
+
+	movies := []models.Movie{data}
+	if err := PG.loadCast(movies); err != nil {
+		log.Error().Err(err).Msg("Error loading cast")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return nil, err
+		return nil, nil, err
 	}
+	data = movies[0]
 
-	log.Info().Int("movieID", movieID).Msg("Movie successfully updated")
-	return &data, nil
+	if err := PG.DB.Preload("Person").Where("movie_id = ? AND actor_id IN ("+activeActorIDs+")", movieID).Order("job, id").Find(&data.Crew).Error; err != nil {
+		log.Error().Err(err).Msg("Error loading crew")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil, err
+	}
+
+	if err := PG.loadProduction(&data); err != nil {
+		log.Error().Err(err).Msg("Error loading production details")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil, err
+	}
+
+	return &data, recorded, nil
 }
 
 // MovieList godoc
@@ -185,17 +451,29 @@ func (PG *Postgresql) MovieEdit(w http.ResponseWriter, r *http.Request) (*models
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
 // @Summary Lists all movies
-// @Description Retrieves a list of all movies, including their titles, descriptions, release dates, ratings, and associated actors with sorting. Available to both 'admin' and 'user' roles.
+// @Description Retrieves a list of all movies, including their titles, descriptions, release dates, ratings, and associated actors in billing order with their credits, with sorting. Available to both 'admin' and 'user' roles.
 // @Tags movie
 // @Produce json
 // @Param Authorization header string true "Bearer [JWT token]"
-// @Param sort query string false "Sort by [title|rating|releasedate], prepend '-' for descending order (default: '-rating')"
-// @Success 200 {array} models.Movie "Successfully retrieved all movies"
+// @Param Accept-Language header string false "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'"
+// @Param sort query string false "Sort by [title|rating|releasedate|communityrating], prepend '-' for descending order (default: '-rating')"
+// @Param director query string false "Fragment of the name of the movie's director"
+// @Param crew query string false "Fragment of the name of a crew member"
+// @Param job query string false "Crew job the crew search is restricted to [director|screenwriter|composer|cinematographer|producer]"
+// @Param facets query string false "Comma separated facets to aggregate [decade|rating|actor|genre|tag]; when set the response is an object with movies and facets"
+// @Param decade query int false "Drill down to a release decade, e.g. 1990"
+// @Param rating query int false "Drill down to a rating band, e.g. 8 for 8.0-8.9"
+// @Param actorId query int false "Drill down to movies featuring the actor with this ID"
+// @Param genre query []string false "Only movies of this genre; repeat the parameter to require several genres" collectionFormat(multi)
+// @Param tag query []string false "Only movies with this tag; repeat the parameter to require several tags" collectionFormat(multi)
+// @Param awardWinner query bool false "Only movies that won at least one award"
+// @Success 200 {array} models.Movie "Successfully retrieved all movies (a models.MovieResults object when facets are requested)"
+// @Failure 400 "Invalid drill-down value or awardWinner flag"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
 // @Failure 500 "Error retrieving movie list"
 // @Router /v1/movie-list [get]
-func (PG *Postgresql) MovieList(w http.ResponseWriter, r *http.Request) (*[]models.Movie, error) {
+func (PG *Postgresql) MovieList(w http.ResponseWriter, r *http.Request) (*models.MovieResults, error) {
 	log.Info().Msg("MovieList called")
 
 	var data []models.Movie
@@ -205,12 +483,14 @@ func (PG *Postgresql) MovieList(w http.ResponseWriter, r *http.Request) (*[]mode
 	if sortParam != "" {
 		// Map query parameters to database columns
 		sortFields := map[string]string{
-			"title":        "title",
-			"-title":       "title DESC",
-			"rating":       "rating",
-			"-rating":      "rating DESC",
-			"releasedate":  "release_date",
-			"-releasedate": "release_date DESC",
+			"title":            "title",
+			"-title":           "title DESC",
+			"rating":           "rating",
+			"-rating":          "rating DESC",
+			"releasedate":      "release_date",
+			"-releasedate":     "release_date DESC",
+			"communityrating":  "community_rating, vote_count",
+			"-communityrating": "community_rating DESC, vote_count DESC",
 		}
 
 		if val, ok := sortFields[sortParam]; ok {
@@ -218,76 +498,191 @@ func (PG *Postgresql) MovieList(w http.ResponseWriter, r *http.Request) (*[]mode
 		}
 	}
 
-	if err := PG.DB.Preload("Actors").Order(sortOrder).Find(&data).Error; err != nil {
+	query, err := PG.awardFilter(PG.DB.Model(&models.Movie{}), r.URL.Query())
+	if err == nil {
+		query, err = PG.applyDrillDown(query, r.URL.Query())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid drill-down value")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	query = query.Session(&gorm.Session{})
+	if err := preloadExternalIDs(preloadProduction(preloadCrew(query))).Preload("Genres").Preload("Tags").Order(sortOrder).Find(&data).Error; err != nil {
 		log.Error().Err(err).Msg("Error retrieving movie list")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return nil, err
 	}
 
+	if err := PG.loadCast(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving movie casts")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	results := models.MovieResults{Movies: data}
+	if facets := requestedFacets(r); facets != nil {
+		if results.Facets, err = PG.computeFacets(query, facets); err != nil {
+			log.Error().Err(err).Msg("Error computing facets")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return nil, err
+		}
+	}
+
 	log.Info().Int("movies_count", len(data)).Msg("Movies retrieved successfully")
-	return &data, nil
+	return &results, nil
 }
 
 // MovieFind godoc
 //
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
-// @Summary Searches for movies by title or actor name
-// @Description Searches for movies by a fragment of the title or by a fragment of an actor's name. Available to both 'admin' and 'user' roles.
+// @Summary Searches for movies by title, actor or crew member
+// @Description Searches for movies by a fragment of the title or by a fragment of an actor's name. Several actors can be given by ID or name fragment to find movies featuring all or any of them. Movies can also be searched by crew, e.g. the movies directed by someone. Titles and names are matched in every language they are translated into, and the results are localized for the Accept-Language header. Available to both 'admin' and 'user' roles.
 // @Tags movie
 // @Produce json
 // @Param Authorization header string true "Bearer [JWT token]"
+// @Param Accept-Language header string false "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'"
 // @Param title query string false "Fragment of the movie title"
-// @Param actor query string false "Fragment of the actor's name"
-// @Success 200 {array} models.Movie "Successfully found movies"
+// @Param actor query []string false "Fragment of an actor's name; repeat the parameter to search for several co-stars" collectionFormat(multi)
+// @Param actorIds query string false "Comma separated actor IDs to search for co-stars"
+// @Param match query string false "Co-star semantics [all|any]: movies featuring all or any of the actors (default: 'all')"
+// @Param director query string false "Fragment of the name of the movie's director"
+// @Param crew query string false "Fragment of the name of a crew member"
+// @Param job query string false "Crew job the crew search is restricted to [director|screenwriter|composer|cinematographer|producer]"
+// @Param facets query string false "Comma separated facets to aggregate [decade|rating|actor|genre|tag]; when set the response is an object with movies and facets"
+// @Param decade query int false "Drill down to a release decade, e.g. 1990"
+// @Param rating query int false "Drill down to a rating band, e.g. 8 for 8.0-8.9"
+// @Param actorId query int false "Drill down to movies featuring the actor with this ID"
+// @Param genre query []string false "Only movies of this genre; repeat the parameter to require several genres" collectionFormat(multi)
+// @Param tag query []string false "Only movies with this tag; repeat the parameter to require several tags" collectionFormat(multi)
+// @Param awardWinner query bool false "Only movies that won at least one award"
+// @Param studio query string false "Fragment of the name of one of the movie's studios"
+// @Param country query []string false "ISO 3166-1 code of a country of origin; repeat the parameter to require several countries" collectionFormat(multi)
+// @Param language query []string false "ISO 639-1 code of a spoken language; repeat the parameter to require several languages" collectionFormat(multi)
+// @Param certification query string false "Age rating in a country as COUNTRY:RATING, e.g. 'RU:16+'"
+// @Param runtimeMin query int false "Minimum running time in minutes"
+// @Param runtimeMax query int false "Maximum running time in minutes"
+// @Success 200 {array} models.Movie "Successfully found movies (a models.MovieResults object when facets are requested)"
+// @Failure 400 "Invalid actor IDs, match, job, awardWinner flag, production filter or drill-down value"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
 // @Failure 500 "Error retrieving movie list"
 // @Router /v1/movie-find [get]
-func (PG *Postgresql) MovieFind(w http.ResponseWriter, r *http.Request) (*[]models.Movie, error) {
+func (PG *Postgresql) MovieFind(w http.ResponseWriter, r *http.Request) (*models.MovieResults, error) {
 
 	log.Info().Msg("MovieFind called")
 
 	var movies []models.Movie
-	title := r.URL.Query().Get("title")
-	actor := r.URL.Query().Get("actor")
-
-	query := PG.DB.Model(&models.Movie{})
 
-	if title != "" {
-		query = query.Where("title ILIKE ?", "%"+title+"%")
+	query, err := PG.movieFindQuery(r.URL.Query())
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid search parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
 	}
 
-	if actor != "" {
-		query = query.Joins("JOIN actormovies ON actormovies.movie_id = movies.id").
-			Joins("JOIN actors ON actors.id = actormovies.actor_id").
-			Where("actors.name ILIKE ?", "%"+actor+"%")
+	query = query.Session(&gorm.Session{})
+	if err := preloadExternalIDs(preloadProduction(preloadCrew(query))).Preload("Genres").Preload("Tags").Find(&movies).Error; err != nil {
+		log.Error().Err(err).Msg("Error searching for movies")
+		http.Error(w, "Error searching for movies", http.StatusInternalServerError)
+		return nil, err
 	}
 
-	if err := query.Preload("Actors").Find(&movies).Error; err != nil {
-		log.Error().Err(err).Msg("Error searching for movies")
+	if err := PG.loadCast(movies); err != nil {
+		log.Error().Err(err).Msg("Error retrieving movie casts")
 		http.Error(w, "Error searching for movies", http.StatusInternalServerError)
 		return nil, err
 	}
 
-	return &movies, nil
+	results := models.MovieResults{Movies: movies}
+	if facets := requestedFacets(r); facets != nil {
+		if results.Facets, err = PG.computeFacets(query, facets); err != nil {
+			log.Error().Err(err).Msg("Error computing facets")
+			http.Error(w, "Error searching for movies", http.StatusInternalServerError)
+			return nil, err
+		}
+	}
+
+	return &results, nil
+}
+
+// MovieGet godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Shows a movie
+// @Description Retrieves the movie with the specified ID with its cast, crew, genres and tags, the titles related to it such as sequels, remakes or alternate cuts, the collections it belongs to and its award nominations and wins. The response is localized for the Accept-Language header. Available to both 'admin' and 'user' roles.
+// @Tags movie
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param Accept-Language header string false "Preferred languages of the titles, descriptions and names, e.g. 'en-GB,en;q=0.8'"
+// @Param id path int true "Movie ID"
+// @Success 200 {object} models.Movie "Successfully retrieved the movie"
+// @Failure 400 "Invalid movie ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Movie not found"
+// @Failure 500 "Error retrieving movie"
+// @Router /v1/movie/{id} [get]
+func (PG *Postgresql) MovieGet(w http.ResponseWriter, r *http.Request) (*models.Movie, error) {
+	log.Info().Msg("MovieGet called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := PG.movieDetails([]int{movieID})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving movie")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	data, ok := movies[movieID]
+	if !ok {
+		log.Error().Int("movieID", movieID).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusNotFound)
+		return nil, errors.New("movie not found")
+	}
+
+	if err := PG.loadMovieRelations(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving related movies")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if err := PG.loadMovieCollections(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving movie collections")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if err := PG.loadMovieAwards(data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving movie awards")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("movieID", movieID).Msg("Movie retrieved successfully")
+	return data, nil
 }
 
 // MovieDelete godoc
 //
 // @Security ApiKeyAuth
 // @SecurityRequirement ApiKeyAuth
-// @Summary Deletes a movie
-// @Description Deletes the movie with the specified ID, including removing all associations with actors. Requires 'admin' role.
+// @Summary Moves a movie to the trash
+// @Description Moves the movie with the specified ID to the trash. The movie is hidden from lists, searches, watchlists and recommendations but keeps its cast, crew, reviews and other links until restored or purged, after which everything is removed for good. Requires 'admin' role.
 // @Tags movie
 // @Produce json
 // @Param Authorization header string true "Bearer [JWT token]"
 // @Param id path int true "Movie ID"
-// @Success 200 "Successfully deleted the movie"
+// @Success 200 "Successfully moved the movie to the trash"
 // @Failure 400 "Invalid movie ID or URL format"
 // @Failure 401 "Unauthorized or Invalid token"
 // @Failure 403 "Forbidden - Role not allowed"
-// @Failure 500 "Movie not found or could not be deleted"
+// @Failure 404 "Movie not found"
+// @Failure 500 "Movie could not be deleted"
 // @Router /v1/movie-delete/{id} [delete]
 func (PG *Postgresql) MovieDelete(w http.ResponseWriter, r *http.Request) (*models.Movie, error) {
 
@@ -309,19 +704,147 @@ func (PG *Postgresql) MovieDelete(w http.ResponseWriter, r *http.Request) (*mode
 		return nil, err
 	}
 
-	if err := PG.DB.Exec("DELETE FROM actormovies WHERE movie_id = ?", movieID).Error; err != nil {
-		log.Error().Err(err).Msg("Failed to delete associated records from the join table")
-		http.Error(w, "Failed to delete associated records from the join table", http.StatusInternalServerError)
+	var castIDs []int
+	if err := PG.DB.Table("actormovies").Where("movie_id = ?", movieID).Pluck("actor_id", &castIDs).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to load the movie's cast")
+		http.Error(w, "Failed to load the movie's cast", http.StatusInternalServerError)
 		return nil, err
 	}
 
-	if err := PG.DB.Where("id = ?", movieID).Delete(&models.Movie{}).Error; err != nil {
-		log.Error().Err(err).Msg("Movie not found or could not be deleted")
-		http.Error(w, "Movie not found or could not be deleted", http.StatusInternalServerError)
+	errNotFound := errors.New("movie not found")
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", movieID).Delete(&models.Movie{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errNotFound
+		}
+		if err := tx.Exec("DELETE FROM movie_similarities WHERE movie_id = ? OR similar_movie_id = ?", movieID, movieID).Error; err != nil {
+			return err
+		}
+		_, err := recordRevision(tx, r, &models.Revision{EntityType: "movie", EntityID: movieID, Action: "delete"}, nil, nil)
+		return err
+	})
+	if errors.Is(err, errNotFound) {
+		log.Error().Int("movieID", movieID).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusNotFound)
+		return nil, err
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Movie could not be deleted")
+		http.Error(w, "Movie could not be deleted", http.StatusInternalServerError)
 		return nil, err
 	}
 
+	PG.refreshAfterCastChange(castIDs)
+
 	log.Info().Int("movieID", movieID).Msg("Movie deleted successfully")
 	return &data, nil
 
 }
+
+// movieFindQuery builds the movie query described by the MovieFind search parameters.
+// It applies the title and actor name fragments, the multi-actor co-star search and the facet drill-downs,
+// so other endpoints and commands can accept the same filters as MovieFind.
+// Returns an error if one of the parameters is malformed.
+func (PG *Postgresql) movieFindQuery(params url.Values) (*gorm.DB, error) {
+	title := params.Get("title")
+
+	var actors []string
+	for _, actor := range params["actor"] {
+		if actor != "" {
+			actors = append(actors, actor)
+		}
+	}
+
+	actorIDs, err := utils.ParseIDList(params.Get("actorIds"))
+	if err != nil {
+		return nil, err
+	}
+
+	match := params.Get("match")
+	if match == "" {
+		match = "all"
+	}
+	if !coStarMatches[match] {
+		return nil, fmt.Errorf("invalid match %q", match)
+	}
+
+	query := PG.DB.Model(&models.Movie{})
+
+	if title != "" {
+		query = query.Where("movies.title ILIKE @title OR movies.id IN (SELECT movie_id FROM movie_translations WHERE title ILIKE @title)",
+			sql.Named("title", "%"+title+"%"))
+	}
+
+	// With the "any" semantics a movie featuring one of the actors given by name or one of those given by ID is enough.
+	switch {
+	case len(actors) > 0 && len(actorIDs) > 0 && match == "any":
+		query = query.Where("movies.id IN (?) OR movies.id IN (?)", PG.coStarByNames(actors, match), PG.coStarByIDs(actorIDs, match))
+	case len(actors) > 0 && len(actorIDs) > 0:
+		query = query.Where("movies.id IN (?) AND movies.id IN (?)", PG.coStarByNames(actors, match), PG.coStarByIDs(actorIDs, match))
+	case len(actors) > 0:
+		query = query.Where("movies.id IN (?)", PG.coStarByNames(actors, match))
+	case len(actorIDs) > 0:
+		query = query.Where("movies.id IN (?)", PG.coStarByIDs(actorIDs, match))
+	}
+
+	query, err = PG.crewFilter(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err = PG.awardFilter(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err = PG.productionFilter(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return PG.applyDrillDown(query, params)
+}
+
+// genreIDsOf returns the IDs of the given genres.
+func genreIDsOf(genres []*models.Genre) []int {
+	ids := make([]int, 0, len(genres))
+	for _, genre := range genres {
+		ids = append(ids, genre.ID)
+	}
+	return ids
+}
+
+// tagIDsOf returns the IDs of the given tags.
+func tagIDsOf(tags []*models.Tag) []int {
+	ids := make([]int, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	return ids
+}
+
+// actorCredits returns the credits sent along with the actors of a new movie as credit objects for parseCastEntries.
+// Only the fields that were set are included, so the column defaults apply to the others.
+func actorCredits(actors []*models.Actor) []interface{} {
+	var credits []interface{}
+	for _, actor := range actors {
+		if actor.Credit == nil {
+			continue
+		}
+		credit := map[string]interface{}{"id": actor.ID}
+		if actor.Credit.Character != "" {
+			credit["character"] = actor.Credit.Character
+		}
+		if actor.Credit.BillingOrder != 0 {
+			credit["billingOrder"] = actor.Credit.BillingOrder
+		}
+		if actor.Credit.CreditType != "" {
+			credit["creditType"] = actor.Credit.CreditType
+		}
+		credits = append(credits, credit)
+	}
+	return credits
+}
@@ -3,21 +3,35 @@ package services
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"vk.com/m/blobstore"
 	"vk.com/m/models"
+	"vk.com/m/notify"
 	"vk.com/m/utils"
 )
 
 type Postgresql struct {
 	DB *gorm.DB
+	// Notifier delivers the notifications of the follow feed outside of the API, through a notify.Queue. It is a notify.Nop when delivery is disabled.
+	Notifier notify.Notifier
+	// Blobs stores uploaded images and their thumbnails.
+	Blobs blobstore.Store
+	// Language is the lower-cased tag of the language movie titles and actor names are stored in, the fallback of every translation.
+	Language string
+	// TrashRetention is how long trashed movies and actors are kept before the purge job removes them for good.
+	TrashRetention time.Duration
+	// audits queues the entries Audit hands to the audit trail writer.
+	audits chan *models.AuditEntry
 }
 
 // NewPostgreSQL creates and returns a new Postgresql instance
 // This function initializes a PostgreSQL database connection using the DSN environment variable
-// It sets the search path to 'vk' and automatically migrates the database schemas for Actor and Movie models
+// It sets the search path to 'vk' and automatically migrates the database schemas for the Actor and Movie models and the tables built around them
+// On first start it also creates the default admin and user accounts; the notifier and blob store are configured from the environment
 // Returns a pointer to a Postgresql struct or an error if the connection or migration fails
 func NewPostgreSQL(ctx context.Context) (*Postgresql, error) {
 
@@ -25,7 +39,8 @@ func NewPostgreSQL(ctx context.Context) (*Postgresql, error) {
 
 	DSN := os.Getenv("DSN")
 
-	conn, err := gorm.Open(postgres.Open(DSN), &gorm.Config{})
+	// TranslateError turns unique violations into gorm.ErrDuplicatedKey, which the endpoints answer with 409 Conflict.
+	conn, err := gorm.Open(postgres.Open(DSN), &gorm.Config{TranslateError: true})
 
 	if err != nil {
 		log.Fatal().Interface("unable to create postgresql connection pool: %v", err).Msg("")
@@ -35,12 +50,67 @@ func NewPostgreSQL(ctx context.Context) (*Postgresql, error) {
 
 	conn.Exec("SET search_path TO vk")
 
-	err = conn.AutoMigrate(&models.Actor{}, &models.Movie{})
+	if err := conn.SetupJoinTable(&models.Movie{}, "Actors", &models.ActorMovie{}); err != nil {
+		log.Fatal().Interface("unable to set up the actormovies join table: %v", err).Msg("")
+	}
+	if err := conn.SetupJoinTable(&models.Actor{}, "Movies", &models.ActorMovie{}); err != nil {
+		log.Fatal().Interface("unable to set up the actormovies join table: %v", err).Msg("")
+	}
+
+	err = conn.AutoMigrate(&models.Actor{}, &models.Movie{}, &models.Genre{}, &models.Tag{}, &models.MovieSimilarity{}, &models.SimilarityRefresh{}, &models.CrewCredit{},
+		&models.Series{}, &models.Season{}, &models.Episode{}, &models.User{}, &models.Review{},
+		&models.Watchlist{}, &models.WatchlistEntry{}, &models.WatchedMovie{},
+		&models.ActorFollow{}, &models.Notification{}, &models.MovieTranslation{}, &models.ActorTranslation{},
+		&models.Collection{}, &models.CollectionEntry{}, &models.MovieRelation{},
+		&models.Award{}, &models.AwardCeremony{}, &models.AwardCategory{}, &models.Nomination{},
+		&models.Studio{}, &models.Country{}, &models.Language{}, &models.Certification{},
+		&models.ExternalID{}, &models.ActorAlias{}, &models.Revision{}, &models.RevisionChange{}, &models.AuditEntry{}, &models.ActorRedirect{}, &models.EditProposal{})
 	if err != nil {
 		log.Fatal().Interface("unable to automigrate: %v", err).Msg("")
 	}
 
-	return &Postgresql{DB: conn}, nil
+	notifier, err := notify.FromEnv()
+	if err != nil {
+		log.Fatal().Interface("unable to configure the notifier: %v", err).Msg("")
+	}
+	if _, disabled := notifier.(notify.Nop); !disabled {
+		notifier = notify.NewQueue(notifier, notifyWorkers, notifyBacklog, notifyTimeout)
+	}
+
+	blobs, err := blobstore.FromEnv()
+	if err != nil {
+		log.Fatal().Interface("unable to configure the blob store: %v", err).Msg("")
+	}
+	models.MediaURL = blobs.URL
+
+	PG := &Postgresql{DB: conn, Notifier: notifier, Blobs: blobs, Language: catalogueLanguage(), TrashRetention: trashRetention()}
+
+	if err := PG.seedUsers(); err != nil {
+		log.Fatal().Interface("unable to create the default users: %v", err).Msg("")
+	}
+
+	if err := PG.migrateActorGenders(); err != nil {
+		log.Fatal().Interface("unable to migrate the actor genders: %v", err).Msg("")
+	}
+
+	if err := PG.seedISOCodes(); err != nil {
+		log.Fatal().Interface("unable to load the ISO country and language codes: %v", err).Msg("")
+	}
+
+	if err := PG.indexNominations(); err != nil {
+		log.Fatal().Interface("unable to index the nominations, remove duplicate nominations first: %v", err).Msg("")
+	}
+
+	if err := PG.installAuditGuard(); err != nil {
+		log.Fatal().Interface("unable to make the audit log append-only: %v", err).Msg("")
+	}
+	PG.startAuditWriter()
+
+	if err := PG.installRevisionGuard(); err != nil {
+		log.Fatal().Interface("unable to make the revision history append-only: %v", err).Msg("")
+	}
+
+	return PG, nil
 }
 
 // Ping checks the connection to the PostgreSQL database
@@ -0,0 +1,490 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"vk.com/m/iso"
+	"vk.com/m/models"
+)
+
+// maxCertificationLength is the longest age rating accepted, matching the size of the rating column.
+const maxCertificationLength = 10
+
+// StudioAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a new studio
+// @Description Adds a new production company with the given name. Studio names are unique. Requires 'admin' role.
+// @Tags studio
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param studio body models.Studio true "Studio to add"
+// @Success 200 {object} models.Studio "Successfully added the studio"
+// @Failure 400 "Invalid request body or empty name"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "A studio with this name already exists"
+// @Failure 500 "Error creating studio"
+// @Router /v1/studio-add [post]
+func (PG *Postgresql) StudioAdd(w http.ResponseWriter, r *http.Request) (*models.Studio, error) {
+
+	log.Info().Msg("StudioAdd called")
+
+	var data models.Studio
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		log.Error().Msg("Empty studio name")
+		http.Error(w, "Studio name is required", http.StatusBadRequest)
+		return nil, errors.New("empty studio name")
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating studio")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("studioID", data.ID).Msg("Studio added successfully")
+	return &data, nil
+}
+
+// StudioEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Renames a studio
+// @Description Edits the studio with the specified ID based on the given update fields. Requires 'admin' role.
+// @Tags studio
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Studio ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Studio "Successfully updated the studio"
+// @Failure 400 "Invalid request body or studio ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Studio not found"
+// @Failure 409 "A studio with this name already exists"
+// @Failure 500 "Failed to save studio"
+// @Router /v1/studio-edit/{id} [put]
+func (PG *Postgresql) StudioEdit(w http.ResponseWriter, r *http.Request) (*models.Studio, error) {
+
+	log.Info().Msg("StudioEdit called")
+
+	var data models.Studio
+
+	studioID, err := idFromPath(w, r, "studio")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", studioID).Error; err != nil {
+		log.Error().Err(err).Msg("Studio not found")
+		http.Error(w, "Studio not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	if name, ok := updates["name"].(string); ok && strings.TrimSpace(name) != "" {
+		data.Name = strings.TrimSpace(name)
+	}
+
+	if err := PG.DB.Save(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save studio")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Int("studioID", studioID).Msg("Studio updated successfully")
+	return &data, nil
+}
+
+// StudioList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists all studios
+// @Description Retrieves all production companies ordered by name. Available to both 'admin' and 'user' roles.
+// @Tags studio
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.Studio "Successfully retrieved all studios"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving studios"
+// @Router /v1/studio-list [get]
+func (PG *Postgresql) StudioList(w http.ResponseWriter, r *http.Request) (*[]models.Studio, error) {
+	log.Info().Msg("StudioList called")
+
+	var data []models.Studio
+
+	if err := PG.DB.Order("name").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving studios")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Successfully retrieved studios")
+	return &data, nil
+}
+
+// StudioDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a studio
+// @Description Deletes the studio with the specified ID and unlinks it from all movies. Requires 'admin' role.
+// @Tags studio
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Studio ID"
+// @Success 200 "Successfully deleted the studio"
+// @Failure 400 "Invalid studio ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Studio not found or could not be deleted"
+// @Router /v1/studio-delete/{id} [delete]
+func (PG *Postgresql) StudioDelete(w http.ResponseWriter, r *http.Request) (*models.Studio, error) {
+
+	log.Info().Msg("StudioDelete called")
+
+	var data models.Studio
+
+	studioID, err := idFromPath(w, r, "studio")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.Exec("DELETE FROM moviestudios WHERE studio_id = ?", studioID).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to delete associated records from the join table")
+		http.Error(w, "Failed to delete associated records from the join table", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	if err := PG.DB.Where("id = ?", studioID).Delete(&models.Studio{}).Error; err != nil {
+		log.Error().Err(err).Msg("Studio not found or could not be deleted")
+		http.Error(w, "Studio not found or could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("studioID", studioID).Msg("Studio deleted successfully")
+	return &data, nil
+}
+
+// CountryList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the countries
+// @Description Retrieves the ISO 3166-1 countries movies can be linked to, ordered by code. Available to both 'admin' and 'user' roles.
+// @Tags studio
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.Country "Successfully retrieved the countries"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving countries"
+// @Router /v1/country-list [get]
+func (PG *Postgresql) CountryList(w http.ResponseWriter, r *http.Request) (*[]models.Country, error) {
+	log.Info().Msg("CountryList called")
+
+	var data []models.Country
+
+	if err := PG.DB.Order("code").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving countries")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Successfully retrieved countries")
+	return &data, nil
+}
+
+// LanguageList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the languages
+// @Description Retrieves the ISO 639-1 languages movies can be linked to, ordered by code. Available to both 'admin' and 'user' roles.
+// @Tags studio
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.Language "Successfully retrieved the languages"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving languages"
+// @Router /v1/language-list [get]
+func (PG *Postgresql) LanguageList(w http.ResponseWriter, r *http.Request) (*[]models.Language, error) {
+	log.Info().Msg("LanguageList called")
+
+	var data []models.Language
+
+	if err := PG.DB.Order("code").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving languages")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Successfully retrieved languages")
+	return &data, nil
+}
+
+// seedISOCodes fills the countries and languages tables from the ISO code lists, refreshing the names of existing rows.
+func (PG *Postgresql) seedISOCodes() error {
+	countries := make([]models.Country, 0, len(iso.Countries))
+	for code, name := range iso.Countries {
+		countries = append(countries, models.Country{Code: code, Name: name})
+	}
+	languages := make([]models.Language, 0, len(iso.Languages))
+	for code, name := range iso.Languages {
+		languages = append(languages, models.Language{Code: code, Name: name})
+	}
+
+	upsert := clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoUpdates: clause.AssignmentColumns([]string{"name"})}
+	if err := PG.DB.Clauses(upsert).Create(&countries).Error; err != nil {
+		return err
+	}
+	return PG.DB.Clauses(upsert).Create(&languages).Error
+}
+
+// countryCodes validates the given ISO 3166-1 codes and returns them upper-cased, without duplicates.
+func countryCodes(codes []string) ([]string, error) {
+	var valid []string
+	for _, code := range codes {
+		normalized, _, ok := iso.Country(code)
+		if !ok {
+			return nil, fmt.Errorf("unknown ISO 3166-1 country code %q", code)
+		}
+		if !containsString(valid, normalized) {
+			valid = append(valid, normalized)
+		}
+	}
+	return valid, nil
+}
+
+// languageCodes validates the given ISO 639-1 codes and returns them lower-cased, without duplicates.
+func languageCodes(codes []string) ([]string, error) {
+	var valid []string
+	for _, code := range codes {
+		normalized, _, ok := iso.Language(code)
+		if !ok {
+			return nil, fmt.Errorf("unknown ISO 639-1 language code %q", code)
+		}
+		if !containsString(valid, normalized) {
+			valid = append(valid, normalized)
+		}
+	}
+	return valid, nil
+}
+
+// parseCertifications validates age ratings keyed by ISO 3166-1 country code, such as {"RU": "16+", "US": "PG-13"}.
+func parseCertifications(ratings map[string]string) ([]*models.Certification, error) {
+	certifications := make([]*models.Certification, 0, len(ratings))
+	for code, rating := range ratings {
+		normalized, _, ok := iso.Country(code)
+		if !ok {
+			return nil, fmt.Errorf("unknown ISO 3166-1 country code %q", code)
+		}
+		rating = strings.TrimSpace(rating)
+		if rating == "" || len(rating) > maxCertificationLength {
+			return nil, fmt.Errorf("invalid certification %q for %s", rating, normalized)
+		}
+		certifications = append(certifications, &models.Certification{CountryCode: normalized, Rating: rating})
+	}
+	return certifications, nil
+}
+
+// certificationRatings turns the certifications of a decoded movie into ratings keyed by country code, for parseCertifications.
+func certificationRatings(certifications []*models.Certification) map[string]string {
+	ratings := make(map[string]string, len(certifications))
+	for _, certification := range certifications {
+		ratings[certification.CountryCode] = certification.Rating
+	}
+	return ratings
+}
+
+// replaceCountries makes the given validated codes the countries of origin of the movie.
+func replaceCountries(tx *gorm.DB, movie *models.Movie, codes []string) error {
+	countries := []*models.Country{}
+	if len(codes) > 0 {
+		if err := tx.Where("code IN ?", codes).Find(&countries).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(movie).Association("Countries").Replace(countries)
+}
+
+// replaceSpokenLanguages makes the given validated codes the spoken languages of the movie.
+func replaceSpokenLanguages(tx *gorm.DB, movie *models.Movie, codes []string) error {
+	languages := []*models.Language{}
+	if len(codes) > 0 {
+		if err := tx.Where("code IN ?", codes).Find(&languages).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(movie).Association("SpokenLanguages").Replace(languages)
+}
+
+// replaceCertifications makes the given validated certifications the age ratings of the movie.
+func replaceCertifications(tx *gorm.DB, movieID int, certifications []*models.Certification) error {
+	if err := tx.Where("movie_id = ?", movieID).Delete(&models.Certification{}).Error; err != nil {
+		return err
+	}
+	if len(certifications) == 0 {
+		return nil
+	}
+	for _, certification := range certifications {
+		certification.MovieID = movieID
+	}
+	return tx.Create(&certifications).Error
+}
+
+// preloadProduction adds the studios, countries, spoken languages and certifications of the movies to the query.
+func preloadProduction(query *gorm.DB) *gorm.DB {
+	return query.Preload("Studios", func(db *gorm.DB) *gorm.DB {
+		return db.Order("studios.name")
+	}).Preload("Countries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("countries.code")
+	}).Preload("SpokenLanguages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("languages.code")
+	}).Preload("Certifications", func(db *gorm.DB) *gorm.DB {
+		return db.Order("country_code")
+	})
+}
+
+// loadProduction reloads the production metadata of the movie after it was edited.
+func (PG *Postgresql) loadProduction(movie *models.Movie) error {
+	var loaded models.Movie
+	if err := preloadProduction(PG.DB).First(&loaded, "id = ?", movie.ID).Error; err != nil {
+		return err
+	}
+	movie.Studios, movie.Countries, movie.SpokenLanguages, movie.Certifications =
+		loaded.Studios, loaded.Countries, loaded.SpokenLanguages, loaded.Certifications
+	return nil
+}
+
+// productionFilter narrows a movie query down by production metadata.
+// "studio" is a fragment of the name of one of the studios. "country" and "language" are ISO codes and may be repeated;
+// a movie must then match every one of them. "certification" is a country code and a rating separated by a colon, e.g. "RU:16+".
+// "runtimeMin" and "runtimeMax" bound the running time in minutes. Returns an error if a value cannot be parsed.
+func (PG *Postgresql) productionFilter(query *gorm.DB, params url.Values) (*gorm.DB, error) {
+	if studio := params.Get("studio"); studio != "" {
+		query = query.Where("movies.id IN (?)",
+			PG.DB.Table("moviestudios").Select("moviestudios.movie_id").
+				Joins("JOIN studios ON studios.id = moviestudios.studio_id").
+				Where("studios.name ILIKE ?", "%"+studio+"%"))
+	}
+
+	countries, err := countryCodes(params["country"])
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range countries {
+		query = query.Where("movies.id IN (?)", PG.DB.Table("moviecountries").Select("movie_id").Where("country_code = ?", code))
+	}
+
+	languages, err := languageCodes(params["language"])
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range languages {
+		query = query.Where("movies.id IN (?)", PG.DB.Table("movielanguages").Select("movie_id").Where("language_code = ?", code))
+	}
+
+	if certification := params.Get("certification"); certification != "" {
+		code, rating, ok := strings.Cut(certification, ":")
+		normalized, _, known := iso.Country(code)
+		if !ok || !known || rating == "" {
+			return nil, fmt.Errorf("invalid certification %q, expected COUNTRY:RATING", certification)
+		}
+		query = query.Where("movies.id IN (?)",
+			PG.DB.Model(&models.Certification{}).Select("movie_id").Where("country_code = ? AND rating = ?", normalized, rating))
+	}
+
+	for param, condition := range map[string]string{"runtimeMin": "movies.runtime >= ?", "runtimeMax": "movies.runtime <= ?"} {
+		value := params.Get(param)
+		if value == "" {
+			continue
+		}
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 {
+			return nil, fmt.Errorf("invalid %s %q", param, value)
+		}
+		query = query.Where(condition, minutes)
+	}
+
+	return query, nil
+}
+
+// studioIDsOf returns the IDs of the given studios.
+func studioIDsOf(studios []*models.Studio) []int {
+	ids := make([]int, 0, len(studios))
+	for _, studio := range studios {
+		ids = append(ids, studio.ID)
+	}
+	return ids
+}
+
+// countryCodesOf returns the codes of the given countries, as sent by the client.
+func countryCodesOf(countries []*models.Country) []string {
+	codes := make([]string, 0, len(countries))
+	for _, country := range countries {
+		codes = append(codes, country.Code)
+	}
+	return codes
+}
+
+// languageCodesOf returns the codes of the given languages, as sent by the client.
+func languageCodesOf(languages []*models.Language) []string {
+	codes := make([]string, 0, len(languages))
+	for _, language := range languages {
+		codes = append(codes, language.Code)
+	}
+	return codes
+}
+
+// interfacesToStrings keeps the strings of a decoded JSON array, skipping other values.
+func interfacesToStrings(values []interface{}) []string {
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
+
+// containsString reports whether the slice holds the string.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
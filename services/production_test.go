@@ -0,0 +1,99 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCountryCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		codes   []string
+		want    []string
+		wantErr bool
+	}{
+		{"normalized and deduplicated", []string{"ru", "US", " Ru "}, []string{"RU", "US"}, false},
+		{"none", nil, nil, false},
+		{"unknown code", []string{"RU", "XX"}, nil, true},
+		{"alpha-3 code", []string{"RUS"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := countryCodes(tt.codes)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: countryCodes error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: countryCodes = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLanguageCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		codes   []string
+		want    []string
+		wantErr bool
+	}{
+		{"normalized and deduplicated", []string{"RU", "en", "ru"}, []string{"ru", "en"}, false},
+		{"unknown code", []string{"zz"}, nil, true},
+		{"regional tag", []string{"en-GB"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := languageCodes(tt.codes)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: languageCodes error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: languageCodes = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseCertifications(t *testing.T) {
+	tests := []struct {
+		name    string
+		ratings map[string]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"country codes normalized and ratings trimmed", map[string]string{"ru": " 16+ ", "US": "PG-13"}, map[string]string{"RU": "16+", "US": "PG-13"}, false},
+		{"unknown country", map[string]string{"XX": "16+"}, nil, true},
+		{"empty rating", map[string]string{"RU": "  "}, nil, true},
+		{"rating too long", map[string]string{"RU": "not suitable"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		certifications, err := parseCertifications(tt.ratings)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: parseCertifications error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(certificationRatings(certifications), tt.want) {
+			t.Errorf("%s: parseCertifications = %v, want %v", tt.name, certificationRatings(certifications), tt.want)
+		}
+	}
+}
+
+func TestParseMovieEditRuntime(t *testing.T) {
+	tests := []struct {
+		runtime interface{}
+		wantErr bool
+	}{
+		{120.0, false},
+		{0.0, false},
+		{-5.0, true},
+		{95.5, true},
+		{"120", true},
+	}
+
+	for _, tt := range tests {
+		if _, err := parseMovieEdit(map[string]interface{}{"runtime": tt.runtime}); (err != nil) != tt.wantErr {
+			t.Errorf("parseMovieEdit runtime %v: error = %v, want error %v", tt.runtime, err, tt.wantErr)
+		}
+	}
+}
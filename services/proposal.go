@@ -0,0 +1,463 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// movieEditFields and actorEditFields list the update fields accepted by MovieEdit and ActorEdit, and so by proposals.
+var (
+	movieEditFields = map[string]bool{"title": true, "description": true, "releasedate": true, "rating": true, "runtime": true,
+		"actors": true, "genres": true, "tags": true, "studios": true, "countries": true, "languages": true, "certifications": true}
+	actorEditFields = map[string]bool{"name": true, "gender": true, "dateOfBirth": true, "dateOfDeath": true, "placeOfBirth": true,
+		"biography": true, "aliases": true, "movies": true}
+)
+
+// proposalStatuses lists the states of an edit proposal.
+var proposalStatuses = map[string]bool{"pending": true, "approved": true, "rejected": true}
+
+// errProposalReviewed is returned when a proposal that was already approved or rejected is reviewed again.
+var errProposalReviewed = errors.New("proposal already reviewed")
+
+// MovieProposal godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Proposes a change to a movie
+// @Description Submits a change to the movie with the specified ID for an admin to approve. The body takes the same update fields as MovieEdit; nothing is changed until the proposal is approved. Available to both 'admin' and 'user' roles.
+// @Tags proposal
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Param updates body map[string]interface{} true "Fields to update, as for MovieEdit"
+// @Success 200 {object} models.EditProposal "Successfully submitted the proposal"
+// @Failure 400 "Invalid movie ID, request body, unknown update fields or invalid values"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Movie not found"
+// @Failure 500 "Error saving the proposal"
+// @Router /v1/movie-propose/{id} [post]
+func (PG *Postgresql) MovieProposal(w http.ResponseWriter, r *http.Request) (*models.EditProposal, error) {
+	log.Info().Msg("MovieProposal called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	return PG.propose(w, r, &models.Movie{}, "movie", movieID, movieEditFields, func(updates map[string]interface{}) error {
+		_, err := parseMovieEdit(updates)
+		return err
+	})
+}
+
+// ActorProposal godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Proposes a change to an actor
+// @Description Submits a change to the actor with the specified ID for an admin to approve. The body takes the same update fields as ActorEdit; nothing is changed until the proposal is approved. Available to both 'admin' and 'user' roles.
+// @Tags proposal
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Param updates body map[string]interface{} true "Fields to update, as for ActorEdit"
+// @Success 200 {object} models.EditProposal "Successfully submitted the proposal"
+// @Failure 400 "Invalid actor ID, request body, unknown update fields or invalid values"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Actor not found"
+// @Failure 500 "Error saving the proposal"
+// @Router /v1/actor-propose/{id} [post]
+func (PG *Postgresql) ActorProposal(w http.ResponseWriter, r *http.Request) (*models.EditProposal, error) {
+	log.Info().Msg("ActorProposal called")
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return PG.propose(w, r, &models.Actor{}, "actor", actorID, actorEditFields, func(updates map[string]interface{}) error {
+		_, err := parseActorEdit(updates)
+		return err
+	})
+}
+
+// ProposalList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the moderation queue
+// @Description Retrieves the edit proposals with the given status, oldest first, with their submitters. Pending proposals carry the changes they would make, with the current and the proposed value of each field. Requires 'admin' role.
+// @Tags proposal
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param status query string false "Status of the proposals [pending|approved|rejected] (default: 'pending')"
+// @Param type query string false "Only proposals for [movie|actor]"
+// @Success 200 {array} models.EditProposal "Successfully retrieved the proposals"
+// @Failure 400 "Invalid status or type"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving the proposals"
+// @Router /v1/proposal-list [get]
+func (PG *Postgresql) ProposalList(w http.ResponseWriter, r *http.Request) (*[]models.EditProposal, error) {
+	log.Info().Msg("ProposalList called")
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = "pending"
+	}
+	entityType := r.URL.Query().Get("type")
+	if !proposalStatuses[status] || (entityType != "" && entityType != "movie" && entityType != "actor") {
+		log.Error().Str("status", status).Str("type", entityType).Msg("Invalid proposal filter")
+		http.Error(w, "Invalid status or type", http.StatusBadRequest)
+		return nil, errors.New("invalid proposal filter")
+	}
+
+	query := PG.DB.Preload("User").Where("status = ?", status)
+	if entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+
+	var data []models.EditProposal
+	if err := query.Order("id").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving proposals")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	if status == "pending" {
+		PG.diffProposals(data)
+	}
+
+	log.Info().Int("count", len(data)).Msg("Proposals retrieved successfully")
+	return &data, nil
+}
+
+// ProposalMine godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the user's own proposals
+// @Description Retrieves the edit proposals submitted by the authenticated user, newest first, with their status and the reason of rejected ones. Available to both 'admin' and 'user' roles.
+// @Tags proposal
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param status query string false "Only proposals with this status [pending|approved|rejected]"
+// @Success 200 {array} models.EditProposal "Successfully retrieved the proposals"
+// @Failure 400 "Invalid status"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 500 "Error retrieving the proposals"
+// @Router /v1/proposal-mine [get]
+func (PG *Postgresql) ProposalMine(w http.ResponseWriter, r *http.Request) (*[]models.EditProposal, error) {
+	log.Info().Msg("ProposalMine called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := PG.DB.Where("user_id = ?", claims.UserID)
+	if status := r.URL.Query().Get("status"); status != "" {
+		if !proposalStatuses[status] {
+			log.Error().Str("status", status).Msg("Invalid proposal status")
+			http.Error(w, "Invalid status", http.StatusBadRequest)
+			return nil, errors.New("invalid proposal status")
+		}
+		query = query.Where("status = ?", status)
+	}
+
+	var data []models.EditProposal
+	if err := query.Order("id DESC").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving proposals")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Proposals retrieved successfully")
+	return &data, nil
+}
+
+// ProposalApprove godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Approves an edit proposal
+// @Description Applies the pending proposal with the specified ID as an edit of its movie or actor, recorded in their history under the approving admin, and marks it approved. A proposal for an actor that was merged into another applies to the actor they were merged into. A proposal that no longer applies, for instance because it names a deleted genre, fails like the edit would and stays pending. Requires 'admin' role.
+// @Tags proposal
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Proposal ID"
+// @Success 200 {object} models.EditProposal "Successfully approved the proposal"
+// @Failure 400 "Invalid proposal ID or a proposal that no longer applies"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Proposal, movie or actor not found"
+// @Failure 409 "Proposal already approved or rejected"
+// @Failure 500 "Error applying the proposal"
+// @Router /v1/proposal-approve/{id} [put]
+func (PG *Postgresql) ProposalApprove(w http.ResponseWriter, r *http.Request) (*models.EditProposal, error) {
+	log.Info().Msg("ProposalApprove called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+	proposalID, err := idFromPath(w, r, "proposal")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := PG.pendingProposal(w, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.Unmarshal([]byte(data.Updates), &updates); err != nil {
+		log.Error().Err(err).Int("proposalID", proposalID).Msg("Invalid proposal updates")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	// A proposal for an actor merged into another since applies to the actor they were merged into.
+	entityID := data.EntityID
+	if data.EntityType == "actor" {
+		entityID = PG.resolveActorID(entityID)
+	}
+
+	// The proposal is claimed before the edit is applied, and the claim, the edit and the revision commit together:
+	// of two admins approving at once the second waits for the first and then finds the proposal no longer pending.
+	approve := func(tx *gorm.DB, apply func() (*models.Revision, error)) error {
+		review := map[string]interface{}{"status": "approved", "entity_id": entityID, "reviewer_id": claims.UserID, "reviewed_at": time.Now()}
+		result := tx.Model(&models.EditProposal{}).Where("id = ? AND status = ?", proposalID, "pending").Updates(review)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errProposalReviewed
+		}
+		revision, err := apply()
+		if err != nil || revision == nil {
+			return err
+		}
+		return tx.Model(&models.EditProposal{}).Where("id = ?", proposalID).Update("revision_id", revision.ID).Error
+	}
+
+	if data.EntityType == "movie" {
+		_, _, err = PG.editMovie(w, r, entityID, updates, &models.Revision{Action: "edit"}, approve)
+	} else {
+		_, _, err = PG.editActor(w, r, entityID, updates, &models.Revision{Action: "edit"}, approve)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(data, "id = ?", proposalID).Error; err != nil {
+		log.Error().Err(err).Msg("Error loading the proposal")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("proposalID", proposalID).Msg("Proposal approved")
+	return data, nil
+}
+
+// ProposalReject godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Rejects an edit proposal
+// @Description Rejects the pending proposal with the specified ID without applying it. The reason is shown to the submitter. Requires 'admin' role.
+// @Tags proposal
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Proposal ID"
+// @Param rejection body models.ProposalRejection true "Why the proposal is rejected"
+// @Success 200 {object} models.EditProposal "Successfully rejected the proposal"
+// @Failure 400 "Invalid proposal ID, request body or missing reason"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Proposal not found"
+// @Failure 409 "Proposal already approved or rejected"
+// @Failure 500 "Error saving the proposal"
+// @Router /v1/proposal-reject/{id} [put]
+func (PG *Postgresql) ProposalReject(w http.ResponseWriter, r *http.Request) (*models.EditProposal, error) {
+	log.Info().Msg("ProposalReject called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+	proposalID, err := idFromPath(w, r, "proposal")
+	if err != nil {
+		return nil, err
+	}
+
+	var rejection models.ProposalRejection
+	if err := json.NewDecoder(r.Body).Decode(&rejection); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	rejection.Reason = strings.TrimSpace(rejection.Reason)
+	if rejection.Reason == "" {
+		log.Error().Msg("Missing rejection reason")
+		http.Error(w, "A reason is required", http.StatusBadRequest)
+		return nil, errors.New("missing rejection reason")
+	}
+
+	data, err := PG.pendingProposal(w, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	review := map[string]interface{}{"status": "rejected", "reason": truncate(rejection.Reason, 1000), "reviewer_id": claims.UserID, "reviewed_at": time.Now()}
+	if err := PG.reviewProposal(w, data, review); err != nil {
+		return nil, err
+	}
+
+	log.Info().Int("proposalID", proposalID).Msg("Proposal rejected")
+	return data, nil
+}
+
+// propose stores the update fields in the request body as a pending proposal of the authenticated user
+// for the movie or actor, which must exist and not be in the trash. The fields are checked with validate,
+// the parser of the edit the proposal becomes, so an admin is only asked to approve edits that can apply.
+func (PG *Postgresql) propose(w http.ResponseWriter, r *http.Request, model interface{}, entityType string, entityID int, fields map[string]bool, validate func(map[string]interface{}) error) (*models.EditProposal, error) {
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Error decoding updates")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	if len(updates) == 0 {
+		log.Error().Msg("Empty proposal")
+		http.Error(w, "No fields to update", http.StatusBadRequest)
+		return nil, errors.New("empty proposal")
+	}
+	for field := range updates {
+		if !fields[field] {
+			log.Error().Str("field", field).Msg("Unknown update field")
+			http.Error(w, fmt.Sprintf("Unknown field %q", field), http.StatusBadRequest)
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+	}
+	if err := validate(updates); err != nil {
+		log.Error().Err(err).Msgf("Invalid %s update", entityType)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	var count int64
+	if err := PG.DB.Model(model).Where("id = ?", entityID).Count(&count).Error; err != nil || count == 0 {
+		log.Error().Err(err).Int("id", entityID).Msgf("%s not found", entityType)
+		http.Error(w, "Not found", http.StatusNotFound)
+		return nil, fmt.Errorf("%s %d not found", entityType, entityID)
+	}
+
+	encoded, err := json.Marshal(updates)
+	if err != nil {
+		log.Error().Err(err).Msg("Error encoding updates")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	data := models.EditProposal{EntityType: entityType, EntityID: entityID, UserID: claims.UserID, Updates: models.JSONValue(encoded), Status: "pending"}
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error saving the proposal")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("proposalID", data.ID).Str("entityType", entityType).Int("entityID", entityID).Msg("Proposal submitted")
+	return &data, nil
+}
+
+// pendingProposal loads the proposal, answering with 404 Not Found when it does not exist and 409 Conflict when it was already reviewed.
+func (PG *Postgresql) pendingProposal(w http.ResponseWriter, proposalID int) (*models.EditProposal, error) {
+	var data models.EditProposal
+	if err := PG.DB.First(&data, "id = ?", proposalID).Error; err != nil {
+		log.Error().Err(err).Int("proposalID", proposalID).Msg("Proposal not found")
+		http.Error(w, "Proposal not found", http.StatusNotFound)
+		return nil, err
+	}
+	if data.Status != "pending" {
+		log.Error().Int("proposalID", proposalID).Str("status", data.Status).Msg("Proposal already reviewed")
+		http.Error(w, "Proposal already "+data.Status, http.StatusConflict)
+		return nil, errProposalReviewed
+	}
+	return &data, nil
+}
+
+// reviewProposal stores the review of the pending proposal and reloads it. The update only applies while the proposal is
+// still pending, so two admins reviewing it at once cannot both succeed.
+func (PG *Postgresql) reviewProposal(w http.ResponseWriter, data *models.EditProposal, review map[string]interface{}) error {
+	result := PG.DB.Model(&models.EditProposal{}).Where("id = ? AND status = ?", data.ID, "pending").Updates(review)
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Error saving the proposal")
+		http.Error(w, result.Error.Error(), http.StatusInternalServerError)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		log.Error().Int("proposalID", data.ID).Msg("Proposal already reviewed")
+		http.Error(w, "Proposal already reviewed", http.StatusConflict)
+		return errProposalReviewed
+	}
+
+	if err := PG.DB.First(data, "id = ?", data.ID).Error; err != nil {
+		log.Error().Err(err).Msg("Error loading the proposal")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
+	}
+	return nil
+}
+
+// diffProposals sets the changes of the pending proposals: each proposed field whose value differs from the current one.
+// Proposals for merged actors are compared with the actor they were merged into; proposals whose movie or actor
+// can no longer be loaded are left without changes.
+func (PG *Postgresql) diffProposals(proposals []models.EditProposal) {
+	for i := range proposals {
+		proposal := &proposals[i]
+
+		var updates map[string]interface{}
+		if err := json.Unmarshal([]byte(proposal.Updates), &updates); err != nil {
+			log.Error().Err(err).Int("proposalID", proposal.ID).Msg("Invalid proposal updates")
+			continue
+		}
+
+		var current map[string]interface{}
+		var err error
+		if proposal.EntityType == "movie" {
+			current, err = movieSnapshot(PG.DB, proposal.EntityID)
+		} else {
+			current, err = actorSnapshot(PG.DB, PG.resolveActorID(proposal.EntityID))
+		}
+		if err != nil {
+			log.Error().Err(err).Int("proposalID", proposal.ID).Msg("Error loading the proposal's subject")
+			continue
+		}
+
+		fields := make([]string, 0, len(updates))
+		for field := range updates {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		proposal.Changes = fieldChanges(fields, current, updates)
+	}
+}
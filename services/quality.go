@@ -0,0 +1,318 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"vk.com/m/models"
+)
+
+// Severities of the data-quality checks, from the most to the least serious.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// severityRanks orders the severities, so a report can be compared against a threshold.
+var severityRanks = map[string]int{SeverityInfo: 1, SeverityWarning: 2, SeverityError: 3}
+
+// qualityDuplicateScore is the score from which two actors are reported as likely duplicates, the default of the duplicate finder.
+const qualityDuplicateScore = 0.85
+
+// qualityCheck is one data-quality rule. run returns the IDs of the movies or actors that break it.
+type qualityCheck struct {
+	severity    string
+	entity      string
+	description string
+	run         func(PG *Postgresql) ([]int, error)
+}
+
+// qualityChecks maps the check names to their implementation. A new check only needs an entry here
+// to be run by the report endpoint and the quality command.
+var qualityChecks = map[string]qualityCheck{
+	"movie-without-actors": {
+		severity:    SeverityWarning,
+		entity:      "movie",
+		description: "Movies with no actor in their cast",
+		run:         moviesWithoutActors,
+	},
+	"movie-invalid-release-date": {
+		severity:    SeverityError,
+		entity:      "movie",
+		description: "Movies whose release date is not a valid YYYY-MM-DD date, such as the \"Invalid date format\" placeholder",
+		run:         moviesWithInvalidReleaseDate,
+	},
+	"movie-missing-rating": {
+		severity:    SeverityWarning,
+		entity:      "movie",
+		description: "Movies without a rating",
+		run:         moviesWithoutRating,
+	},
+	"movie-duplicates": {
+		severity:    SeverityWarning,
+		entity:      "movie",
+		description: "Movies sharing their title and release year with another movie",
+		run:         duplicateMovies,
+	},
+	"actor-without-credits": {
+		severity:    SeverityInfo,
+		entity:      "actor",
+		description: "Actors with no movie, crew credit or episode appearance",
+		run:         actorsWithoutCredits,
+	},
+	"actor-invalid-dates": {
+		severity:    SeverityError,
+		entity:      "actor",
+		description: "Actors whose date of birth or death is not a valid YYYY-MM-DD date, such as the \"Invalid date format\" placeholder",
+		run:         actorsWithInvalidDates,
+	},
+	"actor-death-before-birth": {
+		severity:    SeverityError,
+		entity:      "actor",
+		description: "Actors whose date of death is before their date of birth",
+		run:         actorsDyingBeforeBirth,
+	},
+	"actor-duplicates": {
+		severity:    SeverityWarning,
+		entity:      "actor",
+		description: "Actors that are likely the same person as another actor, as found by the duplicate finder",
+		run:         duplicateActors,
+	},
+}
+
+// QualityReport godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Reports data-quality issues
+// @Description Runs the data-quality checks over the catalogue, such as movies without actors, actors without credits, invalid dates, missing ratings and likely duplicates, and reports the IDs each check flags. Trashed movies and actors are left out. Requires 'admin' role.
+// @Tags quality
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param check query []string false "Only run these checks, all of them when omitted" collectionFormat(multi)
+// @Success 200 {object} models.QualityReport "The result of every check run"
+// @Failure 400 "Unknown check"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error running the checks"
+// @Router /v1/quality-report [get]
+func (PG *Postgresql) QualityReport(w http.ResponseWriter, r *http.Request) (*models.QualityReport, error) {
+	log.Info().Msg("QualityReport called")
+
+	names := r.URL.Query()["check"]
+	if err := validateQualityChecks(names); err != nil {
+		log.Error().Err(err).Strs("check", names).Msg("Unknown quality check")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	data, err := PG.RunQualityChecks(names)
+	if err != nil {
+		log.Error().Err(err).Msg("Error running the quality checks")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("issues", data.Issues).Msg("Quality report generated successfully")
+	return data, nil
+}
+
+// RunQualityChecks runs the named checks, or every check when names is empty, and collects their results in name order.
+// Returns an error if a name is unknown or a check fails.
+func (PG *Postgresql) RunQualityChecks(names []string) (*models.QualityReport, error) {
+	if err := validateQualityChecks(names); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		names = QualityCheckNames()
+	} else {
+		names = append([]string(nil), names...)
+		sort.Strings(names)
+	}
+
+	data := &models.QualityReport{GeneratedAt: time.Now().UTC(), Results: []models.QualityResult{}}
+	for i, name := range names {
+		if i > 0 && name == names[i-1] {
+			continue
+		}
+		check := qualityChecks[name]
+		ids, err := check.run(PG)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", name, err)
+		}
+		if ids == nil {
+			ids = []int{}
+		}
+
+		data.Results = append(data.Results, models.QualityResult{
+			Check:       name,
+			Severity:    check.severity,
+			Description: check.description,
+			EntityType:  check.entity,
+			Count:       len(ids),
+			AffectedIDs: sortedIDs(ids),
+		})
+		if len(ids) > 0 {
+			data.Issues++
+		}
+	}
+
+	return data, nil
+}
+
+// QualityCheckNames returns the names of all data-quality checks in alphabetical order.
+func QualityCheckNames() []string {
+	names := make([]string, 0, len(qualityChecks))
+	for name := range qualityChecks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SeverityAtLeast reports whether severity is as serious as threshold or more. Unknown severities are never.
+func SeverityAtLeast(severity, threshold string) bool {
+	rank, ok := severityRanks[severity]
+	return ok && rank >= severityRanks[threshold]
+}
+
+// validateQualityChecks returns an error naming the first unknown check.
+func validateQualityChecks(names []string) error {
+	for _, name := range names {
+		if _, ok := qualityChecks[name]; !ok {
+			return fmt.Errorf("unknown check %q, expected one of %s", name, strings.Join(QualityCheckNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// moviesWithoutActors returns the movies none of whose actors is outside of the trash.
+func moviesWithoutActors(PG *Postgresql) ([]int, error) {
+	var ids []int
+	err := PG.DB.Model(&models.Movie{}).
+		Where("id NOT IN (SELECT movie_id FROM actormovies WHERE actor_id IN ("+activeActorIDs+"))").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// moviesWithInvalidReleaseDate returns the movies with a release date that does not parse. Empty dates are not flagged.
+func moviesWithInvalidReleaseDate(PG *Postgresql) ([]int, error) {
+	var movies []models.Movie
+	if err := PG.DB.Select("id, release_date").Where("release_date <> ''").Find(&movies).Error; err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, movie := range movies {
+		if !validDate(movie.ReleaseDate) {
+			ids = append(ids, movie.ID)
+		}
+	}
+	return ids, nil
+}
+
+// moviesWithoutRating returns the movies whose rating is missing or zero.
+func moviesWithoutRating(PG *Postgresql) ([]int, error) {
+	var ids []int
+	err := PG.DB.Model(&models.Movie{}).Where("rating IS NULL OR rating = 0").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// duplicateMovies returns the movies with the same title, ignoring case, and release year as another movie.
+func duplicateMovies(PG *Postgresql) ([]int, error) {
+	var ids []int
+	err := PG.DB.Model(&models.Movie{}).
+		Where(`EXISTS (SELECT 1 FROM movies other WHERE other.deleted_at IS NULL AND other.id <> movies.id
+			AND lower(other.title) = lower(movies.title) AND left(other.release_date, 4) = left(movies.release_date, 4))`).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// actorsWithoutCredits returns the actors credited on no movie, crew or episode outside of the trash.
+func actorsWithoutCredits(PG *Postgresql) ([]int, error) {
+	var ids []int
+	err := PG.DB.Model(&models.Actor{}).
+		Where("id NOT IN (SELECT actor_id FROM actormovies WHERE movie_id IN ("+activeMovieIDs+"))").
+		Where("id NOT IN (SELECT actor_id FROM crew_credits WHERE movie_id IN ("+activeMovieIDs+"))").
+		Where("id NOT IN (SELECT actor_id FROM episodeactors)").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// actorsWithInvalidDates returns the actors with a date of birth or death that does not parse. Empty dates are not flagged.
+func actorsWithInvalidDates(PG *Postgresql) ([]int, error) {
+	var actors []models.Actor
+	err := PG.DB.Select("id, date_of_birth, date_of_death").
+		Where("date_of_birth <> '' OR date_of_death <> ''").Find(&actors).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, actor := range actors {
+		if hasInvalidDates(&actor) {
+			ids = append(ids, actor.ID)
+		}
+	}
+	return ids, nil
+}
+
+// actorsDyingBeforeBirth returns the actors whose valid date of death is before their valid date of birth.
+func actorsDyingBeforeBirth(PG *Postgresql) ([]int, error) {
+	var actors []models.Actor
+	err := PG.DB.Select("id, date_of_birth, date_of_death").
+		Where("date_of_birth <> '' AND date_of_death <> ''").Find(&actors).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, actor := range actors {
+		if diesBeforeBirth(&actor) {
+			ids = append(ids, actor.ID)
+		}
+	}
+	return ids, nil
+}
+
+// duplicateActors returns both actors of every pair the duplicate finder scores as likely the same person.
+func duplicateActors(PG *Postgresql) ([]int, error) {
+	seen := map[int]bool{}
+	var ids []int
+	err := PG.scanDuplicates(0, qualityDuplicateScore, func(candidates []models.DuplicateCandidate) {
+		for _, candidate := range candidates {
+			for _, id := range []int{candidate.Actor.ID, candidate.Duplicate.ID} {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// hasInvalidDates reports whether the actor's date of birth or death is set but does not parse.
+func hasInvalidDates(actor *models.Actor) bool {
+	return (actor.DateOfBirth != "" && !validDate(actor.DateOfBirth)) || (actor.DateOfDeath != "" && !validDate(actor.DateOfDeath))
+}
+
+// diesBeforeBirth reports whether the actor's date of death is before their date of birth. Dates that do not parse are not compared.
+func diesBeforeBirth(actor *models.Actor) bool {
+	return validDate(actor.DateOfBirth) && validDate(actor.DateOfDeath) && actor.DateOfDeath < actor.DateOfBirth
+}
+
+// validDate reports whether value is a YYYY-MM-DD date, the format utils.FormatTime stores.
+func validDate(value string) bool {
+	_, err := time.Parse("2006-01-02", value)
+	return err == nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"vk.com/m/models"
+)
+
+func TestQualityChecks(t *testing.T) {
+	names := QualityCheckNames()
+	if len(names) != len(qualityChecks) || !sort.StringsAreSorted(names) {
+		t.Errorf("QualityCheckNames = %v, want all %d checks in alphabetical order", names, len(qualityChecks))
+	}
+	for _, name := range names {
+		check := qualityChecks[name]
+		if _, ok := severityRanks[check.severity]; !ok {
+			t.Errorf("%s: unknown severity %q", name, check.severity)
+		}
+		if check.entity != "movie" && check.entity != "actor" {
+			t.Errorf("%s: entity = %q, want movie or actor", name, check.entity)
+		}
+		if check.description == "" || check.run == nil {
+			t.Errorf("%s: missing description or implementation", name)
+		}
+	}
+}
+
+func TestValidateQualityChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"known", []string{"movie-missing-rating", "actor-duplicates"}, false},
+		{"unknown", []string{"movie-missing-rating", "movie-typos"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := validateQualityChecks(tt.names); (err != nil) != tt.wantErr {
+			t.Errorf("%s: validateQualityChecks error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSeverityAtLeast(t *testing.T) {
+	tests := []struct {
+		severity, threshold string
+		want                bool
+	}{
+		{SeverityError, SeverityError, true},
+		{SeverityError, SeverityInfo, true},
+		{SeverityWarning, SeverityError, false},
+		{SeverityInfo, SeverityWarning, false},
+		{SeverityInfo, SeverityInfo, true},
+		{"fatal", SeverityInfo, false},
+	}
+
+	for _, tt := range tests {
+		if got := SeverityAtLeast(tt.severity, tt.threshold); got != tt.want {
+			t.Errorf("SeverityAtLeast(%q, %q) = %v, want %v", tt.severity, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestRunQualityChecks(t *testing.T) {
+	calls := map[string]int{}
+	checks := map[string]qualityCheck{
+		"test-clean":   {severity: SeverityInfo, entity: "movie", description: "Nothing flagged", run: func(*Postgresql) ([]int, error) { calls["test-clean"]++; return nil, nil }},
+		"test-flagged": {severity: SeverityError, entity: "actor", description: "Three flagged", run: func(*Postgresql) ([]int, error) { calls["test-flagged"]++; return []int{9, 2, 5}, nil }},
+		"test-failing": {severity: SeverityError, entity: "actor", description: "Broken", run: func(*Postgresql) ([]int, error) { return nil, errors.New("boom") }},
+	}
+	for name, check := range checks {
+		qualityChecks[name] = check
+	}
+	defer func() {
+		for name := range checks {
+			delete(qualityChecks, name)
+		}
+	}()
+
+	report, err := (&Postgresql{}).RunQualityChecks([]string{"test-flagged", "test-clean", "test-flagged"})
+	if err != nil {
+		t.Fatalf("RunQualityChecks returned error: %v", err)
+	}
+	want := []models.QualityResult{
+		{Check: "test-clean", Severity: SeverityInfo, Description: "Nothing flagged", EntityType: "movie", Count: 0, AffectedIDs: []int{}},
+		{Check: "test-flagged", Severity: SeverityError, Description: "Three flagged", EntityType: "actor", Count: 3, AffectedIDs: []int{2, 5, 9}},
+	}
+	if !reflect.DeepEqual(report.Results, want) {
+		t.Errorf("RunQualityChecks results = %+v, want %+v", report.Results, want)
+	}
+	if report.Issues != 1 {
+		t.Errorf("RunQualityChecks issues = %d, want 1", report.Issues)
+	}
+	if calls["test-flagged"] != 1 {
+		t.Errorf("check named twice ran %d times, want once", calls["test-flagged"])
+	}
+
+	if _, err := (&Postgresql{}).RunQualityChecks([]string{"test-clean", "test-failing"}); err == nil {
+		t.Error("RunQualityChecks with a failing check returned no error")
+	}
+	if _, err := (&Postgresql{}).RunQualityChecks([]string{"test-unknown"}); err == nil {
+		t.Error("RunQualityChecks with an unknown check returned no error")
+	}
+}
+
+func TestActorDateChecks(t *testing.T) {
+	tests := []struct {
+		name                     string
+		dateOfBirth, dateOfDeath string
+		wantInvalid, wantBefore  bool
+	}{
+		{"no dates", "", "", false, false},
+		{"alive", "1945-10-21", "", false, false},
+		{"lifespan", "1920-03-01", "1998-07-15", false, false},
+		{"born and died the same day", "1950-01-01", "1950-01-01", false, false},
+		{"died before birth", "1998-07-15", "1920-03-01", false, true},
+		{"placeholder birth date", "Invalid date format", "", true, false},
+		{"impossible death date", "1920-03-01", "1998-02-30", true, false},
+		{"invalid date is not compared", "2000-01-01", "1900-13-01", true, false},
+	}
+
+	for _, tt := range tests {
+		actor := &models.Actor{DateOfBirth: tt.dateOfBirth, DateOfDeath: tt.dateOfDeath}
+		if got := hasInvalidDates(actor); got != tt.wantInvalid {
+			t.Errorf("%s: hasInvalidDates = %v, want %v", tt.name, got, tt.wantInvalid)
+		}
+		if got := diesBeforeBirth(actor); got != tt.wantBefore {
+			t.Errorf("%s: diesBeforeBirth = %v, want %v", tt.name, got, tt.wantBefore)
+		}
+	}
+}
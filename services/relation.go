@@ -0,0 +1,187 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// relationInverses lists the accepted relation types, each mapped to the type of the same relation read from the other movie.
+// If B is the sequel of A, then A is the prequel of B.
+var relationInverses = map[string]string{
+	"sequel":            "prequel",
+	"prequel":           "sequel",
+	"remake":            "original",
+	"original":          "remake",
+	"directors-cut":     "theatrical-cut",
+	"theatrical-cut":    "directors-cut",
+	"alternate-version": "alternate-version",
+	"spin-off":          "spun-off-from",
+	"spun-off-from":     "spin-off",
+}
+
+// MovieRelationAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Links two related movies
+// @Description Records that RelatedMovieID is the Type of MovieID, e.g. its sequel, remake or director's cut. The relation is listed on both movies, with the inverse type on the related one. Requires 'admin' role.
+// @Tags movie
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param relation body models.MovieRelation true "Relation to add, with MovieID, RelatedMovieID and Type [sequel|prequel|remake|original|directors-cut|theatrical-cut|alternate-version|spin-off|spun-off-from]"
+// @Success 200 {object} models.MovieRelation "Successfully added the relation"
+// @Failure 400 "Invalid request body, type or movie"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "The movies are already related this way"
+// @Failure 500 "Error creating relation"
+// @Router /v1/movie-relation-add [post]
+func (PG *Postgresql) MovieRelationAdd(w http.ResponseWriter, r *http.Request) (*models.MovieRelation, error) {
+
+	log.Info().Msg("MovieRelationAdd called")
+
+	var data models.MovieRelation
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID, data.RelatedMovie = 0, nil
+
+	inverse, ok := relationInverses[data.Type]
+	if !ok {
+		log.Error().Str("type", data.Type).Msg("Invalid relation type")
+		http.Error(w, "Invalid relation type", http.StatusBadRequest)
+		return nil, errors.New("invalid relation type")
+	}
+	if data.MovieID == data.RelatedMovieID {
+		log.Error().Int("movieID", data.MovieID).Msg("Movie related to itself")
+		http.Error(w, "A movie cannot be related to itself", http.StatusBadRequest)
+		return nil, errors.New("movie related to itself")
+	}
+
+	var count int64
+	if err := PG.DB.Model(&models.Movie{}).Where("id IN ?", []int{data.MovieID, data.RelatedMovieID}).Count(&count).Error; err != nil {
+		log.Error().Err(err).Msg("Error checking movies")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if count != 2 {
+		log.Error().Int("movieID", data.MovieID).Int("relatedMovieID", data.RelatedMovieID).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusBadRequest)
+		return nil, errors.New("movie not found")
+	}
+
+	// The same relation may already be stored from the other side, e.g. a prequel link when adding the matching sequel.
+	err := PG.DB.Model(&models.MovieRelation{}).
+		Where("(movie_id = ? AND related_movie_id = ? AND type = ?) OR (movie_id = ? AND related_movie_id = ? AND type = ?)",
+			data.MovieID, data.RelatedMovieID, data.Type, data.RelatedMovieID, data.MovieID, inverse).
+		Count(&count).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error checking relations")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if count > 0 {
+		log.Warn().Int("movieID", data.MovieID).Int("relatedMovieID", data.RelatedMovieID).Msg("Duplicate relation")
+		http.Error(w, "The movies are already related this way", http.StatusConflict)
+		return nil, errors.New("duplicate relation")
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating relation")
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "The movies are already related this way", http.StatusConflict)
+			return nil, err
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("relationID", data.ID).Msg("Movie relation added successfully")
+	return &data, nil
+}
+
+// MovieRelationDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Unlinks two related movies
+// @Description Deletes the movie relation with the specified ID, as listed in the relationId of a related title. Requires 'admin' role.
+// @Tags movie
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Relation ID"
+// @Success 200 "Successfully deleted the relation"
+// @Failure 400 "Invalid relation ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Relation not found"
+// @Failure 500 "Relation could not be deleted"
+// @Router /v1/movie-relation-delete/{id} [delete]
+func (PG *Postgresql) MovieRelationDelete(w http.ResponseWriter, r *http.Request) (*models.MovieRelation, error) {
+
+	log.Info().Msg("MovieRelationDelete called")
+
+	var data models.MovieRelation
+
+	relationID, err := idFromPath(w, r, "relation")
+	if err != nil {
+		return nil, err
+	}
+
+	result := PG.DB.Where("id = ?", relationID).Delete(&models.MovieRelation{})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Relation could not be deleted")
+		http.Error(w, "Relation could not be deleted", http.StatusInternalServerError)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		log.Error().Int("relationID", relationID).Msg("Relation not found")
+		http.Error(w, "Relation not found", http.StatusNotFound)
+		return nil, errors.New("relation not found")
+	}
+
+	log.Info().Int("relationID", relationID).Msg("Movie relation deleted successfully")
+	return &data, nil
+}
+
+// loadMovieRelations sets the titles related to the movie, seen from the movie: relations stored from the other side are
+// listed with their inverse type. Related titles are ordered by release date.
+func (PG *Postgresql) loadMovieRelations(movie *models.Movie) error {
+	var relations []models.MovieRelation
+	if err := PG.DB.Where("movie_id = ? OR related_movie_id = ?", movie.ID, movie.ID).Find(&relations).Error; err != nil {
+		return err
+	}
+
+	movieIDs := make([]int, 0, len(relations))
+	for _, relation := range relations {
+		movieIDs = append(movieIDs, relation.MovieID, relation.RelatedMovieID)
+	}
+	var movies []models.Movie
+	if err := PG.DB.Where("id IN ? AND id <> ?", movieIDs, movie.ID).Order("release_date, id").Find(&movies).Error; err != nil {
+		return err
+	}
+
+	related := make([]*models.RelatedTitle, 0, len(relations))
+	for i := range movies {
+		for _, relation := range relations {
+			switch movies[i].ID {
+			case relation.RelatedMovieID:
+				related = append(related, &models.RelatedTitle{RelationID: relation.ID, Type: relation.Type, Movie: &movies[i]})
+			case relation.MovieID:
+				related = append(related, &models.RelatedTitle{RelationID: relation.ID, Type: relationInverses[relation.Type], Movie: &movies[i]})
+			}
+		}
+	}
+
+	movie.Relations = related
+	return nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"vk.com/m/auth"
+)
+
+// idFromPath extracts the trailing numeric ID from URLs such as /v1/actor-collaborators/{id}.
+// On failure it logs the problem, answers the request with 400 Bad Request and returns the error,
+// mirroring how the edit and delete handlers validate their path.
+// The entity name is only used in log and error messages, e.g. "actor" yields "Invalid actor ID".
+func idFromPath(w http.ResponseWriter, r *http.Request, entity string) (int, error) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		log.Error().Msg("Invalid URL format")
+		http.Error(w, "Invalid URL format", http.StatusBadRequest)
+		return 0, errors.New("invalid URL format")
+	}
+
+	id, err := strconv.Atoi(pathParts[len(pathParts)-1])
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid %s ID", entity)
+		http.Error(w, "Invalid "+entity+" ID", http.StatusBadRequest)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// intQuery reads an optional integer query parameter, returning def when the parameter is absent.
+// On a malformed value it logs the problem, answers the request with 400 Bad Request and returns the error.
+func intQuery(w http.ResponseWriter, r *http.Request, name string, def int) (int, error) {
+	valueStr := r.URL.Query().Get(name)
+	if valueStr == "" {
+		return def, nil
+	}
+
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		log.Error().Err(err).Str("param", name).Msg("Invalid query parameter")
+		http.Error(w, "Invalid "+name+" parameter", http.StatusBadRequest)
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// boundedIntQuery reads an optional integer query parameter like intQuery, such as a page size or a search depth,
+// and caps it at most, so no request can ask for an unbounded amount of work.
+// A value below least is answered with 400 Bad Request.
+func boundedIntQuery(w http.ResponseWriter, r *http.Request, name string, def, least, most int) (int, error) {
+	value, err := intQuery(w, r, name, def)
+	if err != nil {
+		return 0, err
+	}
+	if value < least {
+		log.Error().Str("param", name).Int("value", value).Msg("Query parameter out of range")
+		http.Error(w, "Invalid "+name+" parameter, expected at least "+strconv.Itoa(least), http.StatusBadRequest)
+		return 0, errors.New("invalid " + name + " parameter")
+	}
+	return min(value, most), nil
+}
+
+// requestClaims returns the claims of the authenticated user, which AuthMiddleware stores in the request context.
+// If they are missing, which means the handler was registered without the middleware, it answers with 401 Unauthorized.
+func requestClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, error) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		log.Error().Msg("Request has no authenticated user")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, errors.New("request has no authenticated user")
+	}
+	return claims, nil
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// ReviewAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Rates and reviews a movie
+// @Description Submits the authenticated user's rating from 1 to 10 of the movie with the given MovieID, with an optional text review. Each user can review a movie only once; edit the existing review instead. Available to both 'admin' and 'user' roles.
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param review body models.Review true "Review to add, with MovieID, Rating and an optional Text"
+// @Success 200 {object} models.Review "Successfully added the review"
+// @Failure 400 "Invalid request body, rating or movie"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "The user already reviewed this movie"
+// @Failure 500 "Error creating review"
+// @Router /v1/review-add [post]
+func (PG *Postgresql) ReviewAdd(w http.ResponseWriter, r *http.Request) (*models.Review, error) {
+
+	log.Info().Msg("ReviewAdd called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Review
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID, data.UserID, data.User = 0, claims.UserID, nil
+
+	if data.Rating < 1 || data.Rating > 10 {
+		log.Error().Int("rating", data.Rating).Msg("Invalid rating")
+		http.Error(w, "Rating must be between 1 and 10", http.StatusBadRequest)
+		return nil, errors.New("invalid rating")
+	}
+
+	if err := PG.DB.First(&models.Movie{}, "id = ?", data.MovieID).Error; err != nil {
+		log.Error().Err(err).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusBadRequest)
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&data).Error; err != nil {
+			return err
+		}
+		return refreshCommunityRating(tx, data.MovieID)
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		log.Warn().Int("userID", data.UserID).Int("movieID", data.MovieID).Msg("Movie already reviewed")
+		http.Error(w, "You already reviewed this movie", http.StatusConflict)
+		return nil, err
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating review")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("reviewID", data.ID).Msg("Review added successfully")
+	return &data, nil
+}
+
+// ReviewEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Edits one's own review
+// @Description Edits the rating or text of the review with the specified ID. Only the author of a review can edit it. Available to both 'admin' and 'user' roles.
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Review ID"
+// @Param updates body map[string]interface{} true "Fields to update: rating and text"
+// @Success 200 {object} models.Review "Successfully updated the review"
+// @Failure 400 "Invalid request body, review ID or rating"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Not the author of the review"
+// @Failure 404 "Review not found"
+// @Failure 500 "Failed to save review"
+// @Router /v1/review-edit/{id} [put]
+func (PG *Postgresql) ReviewEdit(w http.ResponseWriter, r *http.Request) (*models.Review, error) {
+
+	log.Info().Msg("ReviewEdit called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Review
+
+	reviewID, err := idFromPath(w, r, "review")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", reviewID).Error; err != nil {
+		log.Error().Err(err).Msg("Review not found")
+		http.Error(w, "Review not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	if data.UserID != claims.UserID {
+		log.Warn().Int("userID", claims.UserID).Int("reviewID", reviewID).Msg("Attempt to edit another user's review")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return nil, errors.New("not the author of the review")
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "rating":
+			rating, ok := value.(float64)
+			if !ok || rating < 1 || rating > 10 || rating != math.Trunc(rating) {
+				log.Error().Interface("rating", value).Msg("Invalid rating")
+				http.Error(w, "Rating must be a whole number between 1 and 10", http.StatusBadRequest)
+				return nil, errors.New("invalid rating")
+			}
+			data.Rating = int(rating)
+		case "text":
+			if text, ok := value.(string); ok {
+				data.Text = text
+			}
+		}
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&data).Error; err != nil {
+			return err
+		}
+		return refreshCommunityRating(tx, data.MovieID)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save review")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("reviewID", reviewID).Msg("Review updated successfully")
+	return &data, nil
+}
+
+// ReviewDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a review
+// @Description Deletes the review with the specified ID. Users can delete their own reviews; admins can delete any review. Available to both 'admin' and 'user' roles.
+// @Tags review
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Review ID"
+// @Success 200 "Successfully deleted the review"
+// @Failure 400 "Invalid review ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Not the author of the review"
+// @Failure 404 "Review not found"
+// @Failure 500 "Review could not be deleted"
+// @Router /v1/review-delete/{id} [delete]
+func (PG *Postgresql) ReviewDelete(w http.ResponseWriter, r *http.Request) (*models.Review, error) {
+
+	log.Info().Msg("ReviewDelete called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Review
+
+	reviewID, err := idFromPath(w, r, "review")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", reviewID).Error; err != nil {
+		log.Error().Err(err).Msg("Review not found")
+		http.Error(w, "Review not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	if data.UserID != claims.UserID && claims.Role != "admin" {
+		log.Warn().Int("userID", claims.UserID).Int("reviewID", reviewID).Msg("Attempt to delete another user's review")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return nil, errors.New("not the author of the review")
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&data).Error; err != nil {
+			return err
+		}
+		return refreshCommunityRating(tx, data.MovieID)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Review could not be deleted")
+		http.Error(w, "Review could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("reviewID", reviewID).Msg("Review deleted successfully")
+	return &data, nil
+}
+
+// MovieReviews godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the reviews of a movie
+// @Description Retrieves the user reviews of the movie with the specified ID, most recent first, with their authors. Available to both 'admin' and 'user' roles.
+// @Tags review
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Success 200 {array} models.Review "Successfully retrieved the reviews"
+// @Failure 400 "Invalid movie ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving reviews"
+// @Router /v1/movie-reviews/{id} [get]
+func (PG *Postgresql) MovieReviews(w http.ResponseWriter, r *http.Request) (*[]models.Review, error) {
+	log.Info().Msg("MovieReviews called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.Review
+
+	if err := PG.DB.Preload("User").Where("movie_id = ?", movieID).Order("created_at DESC, id DESC").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving reviews")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("movieID", movieID).Int("count", len(data)).Msg("Reviews retrieved successfully")
+	return &data, nil
+}
+
+// refreshCommunityRating recomputes the average user rating and vote count stored on the movie from its reviews.
+// It runs in the transaction that changed the reviews, so the aggregates never disagree with them.
+func refreshCommunityRating(tx *gorm.DB, movieID int) error {
+	return tx.Exec(`UPDATE movies SET
+		community_rating = COALESCE((SELECT ROUND(AVG(rating), 1) FROM reviews WHERE movie_id = @id), 0),
+		vote_count = (SELECT COUNT(*) FROM reviews WHERE movie_id = @id)
+		WHERE id = @id`, map[string]interface{}{"id": movieID}).Error
+}
@@ -0,0 +1,415 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/auth"
+	"vk.com/m/models"
+)
+
+// revisionGuard makes the revisions and revision_changes tables append-only: updates, deletions and truncation are refused by the database.
+var revisionGuard = []string{
+	`CREATE OR REPLACE FUNCTION revisions_append_only() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+	RAISE EXCEPTION 'the revision history is append-only';
+END
+$$`,
+	"DROP TRIGGER IF EXISTS revisions_append_only ON revisions",
+	"CREATE TRIGGER revisions_append_only BEFORE UPDATE OR DELETE OR TRUNCATE ON revisions FOR EACH STATEMENT EXECUTE FUNCTION revisions_append_only()",
+	"DROP TRIGGER IF EXISTS revision_changes_append_only ON revision_changes",
+	"CREATE TRIGGER revision_changes_append_only BEFORE UPDATE OR DELETE OR TRUNCATE ON revision_changes FOR EACH STATEMENT EXECUTE FUNCTION revisions_append_only()",
+}
+
+// MovieHistory godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the revisions of a movie
+// @Description Retrieves every change made to the movie with the specified ID, newest first: who made it, when, and the fields it changed with their values before and after. Cast changes list the full cast with the credits before and after. The history is kept when the movie is trashed or purged. Requires 'admin' role.
+// @Tags revision
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Success 200 {array} models.Revision "Successfully retrieved the history"
+// @Failure 400 "Invalid movie ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving the history"
+// @Router /v1/movie-history/{id} [get]
+func (PG *Postgresql) MovieHistory(w http.ResponseWriter, r *http.Request) (*[]models.Revision, error) {
+	log.Info().Msg("MovieHistory called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	return PG.history(w, "movie", movieID)
+}
+
+// ActorHistory godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the revisions of an actor
+// @Description Retrieves every change made to the actor with the specified ID, newest first: who made it, when, and the fields it changed with their values before and after. Filmography changes list all the actor's movies with the credits before and after. The history is kept when the actor is trashed or purged. Requires 'admin' role.
+// @Tags revision
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Success 200 {array} models.Revision "Successfully retrieved the history"
+// @Failure 400 "Invalid actor ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving the history"
+// @Router /v1/actor-history/{id} [get]
+func (PG *Postgresql) ActorHistory(w http.ResponseWriter, r *http.Request) (*[]models.Revision, error) {
+	log.Info().Msg("ActorHistory called")
+
+	actorID, err := idFromPath(w, r, "actor")
+	if err != nil {
+		return nil, err
+	}
+
+	return PG.history(w, "actor", actorID)
+}
+
+// MovieRevert godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Reverts a movie to a previous revision
+// @Description Puts the title, description, release date, ratings, runtime, cast with their credits, genres, tags, studios, countries, languages and certifications of the movie back to how they were after the given revision, and records the revert as a new revision. Revisions of deletions and restores carry no state and cannot be reverted to; trashed movies must be restored first. Requires 'admin' role.
+// @Tags revision
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Param revision query int true "ID of the revision to revert to"
+// @Success 200 {object} models.Movie "Successfully reverted the movie"
+// @Failure 400 "Invalid movie ID, missing revision or a revision that cannot be reverted to"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Movie or revision not found"
+// @Failure 500 "Error reverting the movie"
+// @Router /v1/movie-revert/{id} [put]
+func (PG *Postgresql) MovieRevert(w http.ResponseWriter, r *http.Request) (*models.Movie, error) {
+	log.Info().Msg("MovieRevert called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	revision, updates, err := PG.revisionToRevert(w, r, "movie", movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := PG.editMovie(w, r, movieID, updates, &models.Revision{Action: "revert", RevertedTo: &revision.ID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int("movieID", movieID).Int("revisionID", revision.ID).Msg("Movie reverted successfully")
+	return data, nil
+}
+
+// ActorRevert godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Reverts an actor to a previous revision
+// @Description Puts the name, gender, dates, place of birth, biography, aliases and movies with their credits of the actor back to how they were after the given revision, and records the revert as a new revision. Revisions of deletions and restores carry no state and cannot be reverted to; trashed actors must be restored first. Requires 'admin' role.
+// @Tags revision
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Param revision query int true "ID of the revision to revert to"
+// @Success 200 {object} models.Actor "Successfully reverted the actor"
+// @Failure 400 "Invalid actor ID, missing revision or a revision that cannot be reverted to"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Actor or revision not found"
+// @Failure 500 "Error reverting the actor"
+// @Router /v1/actor-revert/{id} [put]
+func (PG *Postgresql) ActorRevert(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
+	log.Info().Msg("ActorRevert called")
+
+	actorID, err := idFromPath(w, r, "actor")
+	if err != nil {
+		return nil, err
+	}
+
+	revision, updates, err := PG.revisionToRevert(w, r, "actor", actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := PG.editActor(w, r, actorID, updates, &models.Revision{Action: "revert", RevertedTo: &revision.ID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int("actorID", actorID).Int("revisionID", revision.ID).Msg("Actor reverted successfully")
+	return data, nil
+}
+
+// history loads the revisions of the movie or actor, newest first, with their changes and authors.
+func (PG *Postgresql) history(w http.ResponseWriter, entityType string, entityID int) (*[]models.Revision, error) {
+	var data []models.Revision
+	err := PG.DB.Preload("User").Preload("Changes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("entity_type = ? AND entity_id = ?", entityType, entityID).Order("id DESC").Find(&data).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving the history")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Str("entityType", entityType).Int("entityID", entityID).Int("count", len(data)).Msg("History retrieved successfully")
+	return &data, nil
+}
+
+// revisionToRevert loads the revision named by the "revision" query parameter and decodes its snapshot into edit update fields.
+// It answers with 404 Not Found when the revision does not belong to the entity and with 400 Bad Request when it carries no state.
+func (PG *Postgresql) revisionToRevert(w http.ResponseWriter, r *http.Request, entityType string, entityID int) (*models.Revision, map[string]interface{}, error) {
+	revisionID, err := intQuery(w, r, "revision", 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if revisionID <= 0 {
+		log.Error().Msg("Missing revision parameter")
+		http.Error(w, "Missing revision parameter", http.StatusBadRequest)
+		return nil, nil, errors.New("missing revision parameter")
+	}
+
+	var revision models.Revision
+	if err := PG.DB.Where("id = ? AND entity_type = ? AND entity_id = ?", revisionID, entityType, entityID).First(&revision).Error; err != nil {
+		log.Error().Err(err).Int("revisionID", revisionID).Msg("Revision not found")
+		http.Error(w, "Revision not found", http.StatusNotFound)
+		return nil, nil, err
+	}
+	if revision.Snapshot == "" {
+		log.Error().Int("revisionID", revisionID).Str("action", revision.Action).Msg("Revision cannot be reverted to")
+		http.Error(w, "A "+revision.Action+" revision cannot be reverted to", http.StatusBadRequest)
+		return nil, nil, errors.New("revision without a snapshot")
+	}
+
+	var updates map[string]interface{}
+	if err := json.Unmarshal([]byte(revision.Snapshot), &updates); err != nil {
+		log.Error().Err(err).Int("revisionID", revisionID).Msg("Invalid revision snapshot")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil, err
+	}
+
+	return &revision, updates, nil
+}
+
+// movieSnapshot returns the editable fields of the movie keyed like the MovieEdit update fields, so a snapshot can be applied as an edit.
+// The cast lists the credits of the actors that are not in the trash, in billing order. Trashed movies are included.
+func movieSnapshot(db *gorm.DB, movieID int) (map[string]interface{}, error) {
+	var movie models.Movie
+	if err := preloadProduction(db.Unscoped()).Preload("Genres").Preload("Tags").First(&movie, "id = ?", movieID).Error; err != nil {
+		return nil, err
+	}
+
+	var links []models.ActorMovie
+	if err := db.Where("movie_id = ? AND actor_id IN ("+activeActorIDs+")", movieID).Order("billing_order = 0, billing_order, actor_id").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	actors := make([]interface{}, 0, len(links))
+	for _, link := range links {
+		actors = append(actors, creditSnapshot(link.ActorID, link.Credit))
+	}
+
+	certifications := make(map[string]string, len(movie.Certifications))
+	for _, certification := range movie.Certifications {
+		certifications[certification.CountryCode] = certification.Rating
+	}
+
+	return map[string]interface{}{
+		"title":          movie.Title,
+		"description":    movie.Description,
+		"releasedate":    optionalString(movie.ReleaseDate),
+		"rating":         movie.Rating,
+		"runtime":        movie.Runtime,
+		"actors":         actors,
+		"genres":         sortedIDs(genreIDsOf(movie.Genres)),
+		"tags":           sortedIDs(tagIDsOf(movie.Tags)),
+		"studios":        sortedIDs(studioIDsOf(movie.Studios)),
+		"countries":      sortedStrings(countryCodesOf(movie.Countries)),
+		"languages":      sortedStrings(languageCodesOf(movie.SpokenLanguages)),
+		"certifications": certifications,
+	}, nil
+}
+
+// actorSnapshot returns the editable fields of the actor keyed like the ActorEdit update fields, so a snapshot can be applied as an edit.
+// The movies list the actor's credits in the movies that are not in the trash. Trashed actors are included.
+func actorSnapshot(db *gorm.DB, actorID int) (map[string]interface{}, error) {
+	var actor models.Actor
+	if err := preloadAliases(db.Unscoped()).First(&actor, "id = ?", actorID).Error; err != nil {
+		return nil, err
+	}
+
+	var links []models.ActorMovie
+	if err := db.Where("actor_id = ? AND movie_id IN ("+activeMovieIDs+")", actorID).Order("movie_id").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	movies := make([]interface{}, 0, len(links))
+	for _, link := range links {
+		movies = append(movies, creditSnapshot(link.MovieID, link.Credit))
+	}
+
+	aliases := make([]interface{}, 0, len(actor.Aliases))
+	for _, alias := range actor.Aliases {
+		aliases = append(aliases, map[string]interface{}{"name": alias.Name, "kind": alias.Kind})
+	}
+
+	return map[string]interface{}{
+		"name":         actor.Name,
+		"gender":       actor.Gender,
+		"dateOfBirth":  optionalString(actor.DateOfBirth),
+		"dateOfDeath":  optionalString(actor.DateOfDeath),
+		"placeOfBirth": actor.PlaceOfBirth,
+		"biography":    actor.Biography,
+		"aliases":      aliases,
+		"movies":       movies,
+	}, nil
+}
+
+// recordMovieRevision records the change of the movie from the before snapshot to its state within the transaction.
+// before is nil for a new movie. Returns the revision, or nil when none was recorded.
+func recordMovieRevision(tx *gorm.DB, r *http.Request, movieID int, action string, before map[string]interface{}) (*models.Revision, error) {
+	after, err := movieSnapshot(tx, movieID)
+	if err != nil {
+		return nil, err
+	}
+	return recordRevision(tx, r, &models.Revision{EntityType: "movie", EntityID: movieID, Action: action}, before, after)
+}
+
+// recordActorRevision records the change of the actor from the before snapshot to its state within the transaction.
+// before is nil for a new actor. Returns the revision, or nil when none was recorded.
+func recordActorRevision(tx *gorm.DB, r *http.Request, actorID int, action string, before map[string]interface{}) (*models.Revision, error) {
+	after, err := actorSnapshot(tx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	return recordRevision(tx, r, &models.Revision{EntityType: "actor", EntityID: actorID, Action: action}, before, after)
+}
+
+// recordRevision stores the revision, made by the authenticated user, with one change per field whose value differs between the snapshots.
+// after becomes the state the revision can be reverted to; it is nil for deletions and restores, which change no fields.
+// An edit that changed nothing is not recorded. It runs in the transaction of the change, so a change is never committed
+// without its revision. Returns the stored revision, or nil when none was recorded.
+func recordRevision(tx *gorm.DB, r *http.Request, revision *models.Revision, before, after map[string]interface{}) (*models.Revision, error) {
+	if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+		revision.UserID = claims.UserID
+	}
+
+	if after != nil {
+		snapshot, err := json.Marshal(after)
+		if err != nil {
+			return nil, err
+		}
+		revision.Snapshot = string(snapshot)
+	}
+
+	fields := make([]string, 0, len(after))
+	for field := range after {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	revision.Changes = fieldChanges(fields, before, after)
+	if revision.Action == "edit" && len(revision.Changes) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Create(revision).Error; err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// editHook wraps the writes of a movie or actor edit within its transaction. apply loads the entity, writes the edit and
+// records the revision, returning it, or nil when the edit changed nothing. An error from the hook rolls back the edit.
+type editHook func(tx *gorm.DB, apply func() (*models.Revision, error)) error
+
+// run calls the hook, or just apply when there is none.
+func (hook editHook) run(tx *gorm.DB, apply func() (*models.Revision, error)) error {
+	if hook == nil {
+		_, err := apply()
+		return err
+	}
+	return hook(tx, apply)
+}
+
+// installRevisionGuard makes the revision history append-only in the database. It is idempotent and runs on every start.
+func (PG *Postgresql) installRevisionGuard() error {
+	for _, statement := range revisionGuard {
+		if err := PG.DB.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldChanges compares the given fields of two snapshots and returns one change per field whose value differs.
+// A nil before snapshot makes every field a change from null.
+func fieldChanges(fields []string, before, after map[string]interface{}) []*models.RevisionChange {
+	var changes []*models.RevisionChange
+	for _, field := range fields {
+		change := &models.RevisionChange{Field: field, After: jsonValue(after[field])}
+		if before != nil {
+			change.Before = jsonValue(before[field])
+		}
+		if change.Before != change.After {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// creditSnapshot describes a cast link in the form accepted by parseCastEntries.
+func creditSnapshot(id int, credit models.Credit) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           id,
+		"character":    credit.Character,
+		"billingOrder": credit.BillingOrder,
+		"creditType":   credit.CreditType,
+	}
+}
+
+// jsonValue encodes a snapshot field, keeping null as the empty value.
+func jsonValue(value interface{}) models.JSONValue {
+	encoded, err := json.Marshal(value)
+	if err != nil || string(encoded) == "null" {
+		return ""
+	}
+	return models.JSONValue(encoded)
+}
+
+// optionalString returns nil for an empty string, which the edit endpoints take as clearing a date.
+func optionalString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// sortedIDs sorts the IDs in place, so snapshots do not differ by the order associations were loaded in.
+func sortedIDs(ids []int) []int {
+	sort.Ints(ids)
+	return ids
+}
+
+// sortedStrings sorts the codes in place, for the same reason as sortedIDs.
+func sortedStrings(codes []string) []string {
+	sort.Strings(codes)
+	return codes
+}
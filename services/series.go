@@ -0,0 +1,264 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+	"vk.com/m/utils"
+)
+
+// SeriesAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a new series
+// @Description Adds a new TV series with the given details including title, description, start and end dates, and rating. Seasons and episodes are added separately. Requires 'admin' role.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param series body models.Series true "Series to add"
+// @Success 200 {object} models.Series "Successfully added the series"
+// @Failure 400 "Invalid request body, empty title or invalid start or end date"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error creating series"
+// @Router /v1/series-add [post]
+func (PG *Postgresql) SeriesAdd(w http.ResponseWriter, r *http.Request) (*models.Series, error) {
+
+	log.Info().Msg("SeriesAdd called")
+
+	var data models.Series
+	var err error
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	data.Title = strings.TrimSpace(data.Title)
+	if data.Title == "" {
+		log.Error().Msg("Empty series title")
+		http.Error(w, "Series title is required", http.StatusBadRequest)
+		return nil, errors.New("empty series title")
+	}
+
+	if data.StartDate, err = optionalDate("start date", data.StartDate); err == nil {
+		data.EndDate, err = optionalDate("end date", data.EndDate)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid series date")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.Seasons = nil
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating series")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("seriesID", data.ID).Msg("Series added successfully")
+	return &data, nil
+}
+
+// SeriesEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Edits an existing series
+// @Description Edits the series with the specified ID based on the given update fields such as title, description, startdate, enddate and rating. Requires 'admin' role.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Series ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Series "Successfully updated the series"
+// @Failure 400 "Invalid request body, series ID or date"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Series not found"
+// @Failure 500 "Failed to save series"
+// @Router /v1/series-edit/{id} [put]
+func (PG *Postgresql) SeriesEdit(w http.ResponseWriter, r *http.Request) (*models.Series, error) {
+
+	log.Info().Msg("SeriesEdit called")
+
+	var data models.Series
+
+	seriesID, err := idFromPath(w, r, "series")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.First(&data, "id = ?", seriesID).Error; err != nil {
+		log.Error().Err(err).Msg("Series not found")
+		http.Error(w, "Series not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "title":
+			if title, ok := value.(string); ok && strings.TrimSpace(title) != "" {
+				data.Title = strings.TrimSpace(title)
+			}
+		case "description":
+			if description, ok := value.(string); ok {
+				data.Description = description
+			}
+		case "startdate":
+			if startDate, ok := value.(string); ok {
+				if data.StartDate, err = optionalDate("start date", startDate); err != nil {
+					log.Error().Err(err).Msg("Invalid start date")
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return nil, err
+				}
+			}
+		case "enddate":
+			if endDate, ok := value.(string); ok {
+				if data.EndDate, err = optionalDate("end date", endDate); err != nil {
+					log.Error().Err(err).Msg("Invalid end date")
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return nil, err
+				}
+			}
+		case "rating":
+			if rating, ok := value.(float64); ok {
+				data.Rating = rating
+			}
+		}
+	}
+
+	if err := PG.DB.Save(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save series")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("seriesID", seriesID).Msg("Series updated successfully")
+	return &data, nil
+}
+
+// SeriesList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists all series
+// @Description Retrieves a list of all series with their seasons, episodes and guest stars, with sorting. Available to both 'admin' and 'user' roles.
+// @Tags series
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param sort query string false "Sort by field [title|-title|rating|-rating|startdate|-startdate] (default: '-rating')"
+// @Success 200 {array} models.Series "Successfully retrieved all series"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving series list"
+// @Router /v1/series-list [get]
+func (PG *Postgresql) SeriesList(w http.ResponseWriter, r *http.Request) (*[]models.Series, error) {
+	log.Info().Msg("SeriesList called")
+
+	var data []models.Series
+
+	sortFields := map[string]string{
+		"title":      "title",
+		"-title":     "title DESC",
+		"rating":     "rating",
+		"-rating":    "rating DESC",
+		"startdate":  "start_date",
+		"-startdate": "start_date DESC",
+	}
+	sortOrder, ok := sortFields[r.URL.Query().Get("sort")]
+	if !ok {
+		sortOrder = "rating DESC"
+	}
+
+	err := PG.DB.
+		Preload("Seasons", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		Preload("Seasons.Episodes", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		Preload("Seasons.Episodes.GuestStars").
+		Order(sortOrder).
+		Find(&data).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving series list")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("series_count", len(data)).Msg("Series retrieved successfully")
+	return &data, nil
+}
+
+// SeriesDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a series
+// @Description Deletes the series with the specified ID together with its seasons, episodes and guest star links. Requires 'admin' role.
+// @Tags series
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Series ID"
+// @Success 200 "Successfully deleted the series"
+// @Failure 400 "Invalid series ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Series not found or could not be deleted"
+// @Router /v1/series-delete/{id} [delete]
+func (PG *Postgresql) SeriesDelete(w http.ResponseWriter, r *http.Request) (*models.Series, error) {
+
+	log.Info().Msg("SeriesDelete called")
+
+	var data models.Series
+
+	seriesID, err := idFromPath(w, r, "series")
+	if err != nil {
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		seasons := tx.Model(&models.Season{}).Select("id").Where("series_id = ?", seriesID)
+		if err := deleteEpisodes(tx, tx.Model(&models.Episode{}).Select("id").Where("season_id IN (?)", seasons)); err != nil {
+			return err
+		}
+		if err := tx.Where("series_id = ?", seriesID).Delete(&models.Season{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", seriesID).Delete(&models.Series{}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Series not found or could not be deleted")
+		http.Error(w, "Series not found or could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("seriesID", seriesID).Msg("Series deleted successfully")
+	return &data, nil
+}
+
+// optionalDate normalizes a date given as YYYY-MM-DD. An empty date stays empty, as it is for a series still running.
+func optionalDate(name, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if !validDate(value) {
+		return "", fmt.Errorf("invalid %s %q, expected YYYY-MM-DD", name, value)
+	}
+	return utils.FormatTime(value), nil
+}
@@ -0,0 +1,340 @@
+package services
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+const (
+	// similarityListSize is the number of recommendations kept per movie.
+	similarityListSize = 20
+	// similarityBatchSize is the number of queued movies the similarity worker refreshes per transaction.
+	similarityBatchSize = 200
+	// similarityEraSpan is the release year gap, in years, past which two movies get no era proximity at all.
+	similarityEraSpan = 20.0
+
+	similarityCastWeight   = 0.4
+	similarityGenreWeight  = 0.2
+	similarityTagWeight    = 0.1
+	similarityEraWeight    = 0.15
+	similarityRatingWeight = 0.15
+)
+
+// movieFeatures holds the attributes of a movie the similarity score is computed from.
+type movieFeatures struct {
+	id     int
+	year   int
+	rating float64
+	actors map[int]bool
+	genres map[int]bool
+	tags   map[int]bool
+}
+
+// MovieSimilar godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists movies similar to a movie
+// @Description Retrieves the precomputed recommendations for the movie with the specified ID, best matches first. Scores combine shared cast, genre and tag overlap, release era proximity and rating. Available to both 'admin' and 'user' roles.
+// @Tags movie
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Param limit query int false "Maximum number of recommendations to return (default: 10, at most 20)"
+// @Success 200 {array} models.MovieSimilarity "Successfully retrieved the similar movies"
+// @Failure 400 "Invalid movie ID or limit"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving similar movies"
+// @Router /v1/movie-similar/{id} [get]
+func (PG *Postgresql) MovieSimilar(w http.ResponseWriter, r *http.Request) (*[]models.MovieSimilarity, error) {
+	log.Info().Msg("MovieSimilar called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := intQuery(w, r, "limit", 10)
+	if err != nil {
+		return nil, err
+	}
+	if limit > similarityListSize {
+		limit = similarityListSize
+	}
+
+	var data []models.MovieSimilarity
+
+	if err := PG.DB.Preload("SimilarMovie").Where("movie_id = ? AND similar_movie_id IN ("+activeMovieIDs+")", movieID).Order("score DESC, similar_movie_id").Limit(limit).Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving similar movies")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("movieID", movieID).Int("count", len(data)).Msg("Similar movies retrieved successfully")
+	return &data, nil
+}
+
+// RefreshAllSimilarities recomputes the recommendations of every movie.
+// It is the offline job run by the "similarity" command.
+func (PG *Postgresql) RefreshAllSimilarities() error {
+	var movieIDs []int
+	if err := PG.DB.Model(&models.Movie{}).Order("id").Pluck("id", &movieIDs).Error; err != nil {
+		return err
+	}
+
+	return PG.RefreshSimilarities(movieIDs)
+}
+
+// RefreshSimilarities recomputes and replaces the recommendations of the given movies.
+// Features of the whole catalogue are loaded once, then each movie is scored against every other one and its best matches are stored.
+func (PG *Postgresql) RefreshSimilarities(movieIDs []int) error {
+	if len(movieIDs) == 0 {
+		return nil
+	}
+
+	return PG.DB.Transaction(func(tx *gorm.DB) error {
+		return refreshSimilarities(tx, movieIDs)
+	})
+}
+
+// ProcessSimilarityQueue recomputes the recommendations of the movies queued by edits, in batches of at most similarityBatchSize movies,
+// until the queue is empty. Each batch is claimed with SKIP LOCKED and removed from the queue in the transaction that refreshes it,
+// so several API instances can work through the queue together and a failed batch stays queued.
+// Returns the number of movies refreshed.
+func (PG *Postgresql) ProcessSimilarityQueue() (int, error) {
+	refreshed := 0
+	for {
+		var movieIDs []int
+		err := PG.DB.Transaction(func(tx *gorm.DB) error {
+			err := tx.Raw("SELECT movie_id FROM similarity_refreshes ORDER BY queued_at, movie_id LIMIT ? FOR UPDATE SKIP LOCKED", similarityBatchSize).
+				Scan(&movieIDs).Error
+			if err != nil || len(movieIDs) == 0 {
+				return err
+			}
+			if err := refreshSimilarities(tx, movieIDs); err != nil {
+				return err
+			}
+			return tx.Where("movie_id IN ?", movieIDs).Delete(&models.SimilarityRefresh{}).Error
+		})
+		if err != nil {
+			return refreshed, err
+		}
+		if len(movieIDs) == 0 {
+			return refreshed, nil
+		}
+		refreshed += len(movieIDs)
+	}
+}
+
+// StartSimilarityWorker processes the similarity queue in the background every interval, for as long as the process runs.
+// Failures are logged; the movies stay queued and are retried on the next tick.
+func (PG *Postgresql) StartSimilarityWorker(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := PG.ProcessSimilarityQueue(); err != nil {
+				log.Error().Err(err).Msg("Failed to process the similarity queue")
+			}
+		}
+	}()
+}
+
+// refreshSimilarities replaces the recommendations of the given movies within the transaction.
+func refreshSimilarities(tx *gorm.DB, movieIDs []int) error {
+	features, err := loadMovieFeatures(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, movieID := range movieIDs {
+		if err := tx.Where("movie_id = ?", movieID).Delete(&models.MovieSimilarity{}).Error; err != nil {
+			return err
+		}
+
+		target, ok := features[movieID]
+		if !ok {
+			continue
+		}
+
+		rows := rankSimilar(target, features)
+		if len(rows) == 0 {
+			continue
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+	}
+
+	log.Info().Int("movies", len(movieIDs)).Msg("Movie similarities refreshed")
+	return nil
+}
+
+// refreshAfterCastChange queues the recommendations affected by a change of cast for the similarity worker.
+// Only the cast overlap depends on the actors, so the scores that can move are those of the given movies and of every movie
+// sharing an actor with them, counting both their current cast and the given actors who just left it.
+// The movies are found and queued by a single statement; failures are logged and do not abort the edit that triggered them,
+// since the next run of the similarity job repairs any stale rows.
+func (PG *Postgresql) refreshAfterCastChange(formerActorIDs []int, movieIDs ...int) {
+	actors := PG.DB.Table("actormovies").Select("actor_id").Where("movie_id IN ?", movieIDs)
+	neighbours := PG.DB.Table("actormovies").Select("movie_id").Where("actor_id IN (?) OR actor_id IN ?", actors, formerActorIDs)
+
+	if err := PG.queueSimilarityRefresh(movieIDs, neighbours); err != nil {
+		log.Error().Err(err).Msg("Failed to queue the movies affected by the cast change")
+	}
+}
+
+// refreshAfterTaxonomyChange queues the recommendations affected by a change of a movie's genres or tags for the similarity worker.
+// Besides the movie itself, only the movies sharing one of its genres or tags, current or just removed, can see their scores move.
+// Like refreshAfterCastChange, failures are only logged.
+func (PG *Postgresql) refreshAfterTaxonomyChange(movieID int, genreIDs, tagIDs, changedGenreIDs, changedTagIDs []int) {
+	neighbours := PG.DB.Table("moviegenres").Select("movie_id").Where("genre_id IN ?", append(genreIDs, changedGenreIDs...))
+	if err := PG.queueSimilarityRefresh([]int{movieID}, neighbours); err != nil {
+		log.Error().Err(err).Msg("Failed to queue the movies affected by the genre change")
+	}
+
+	neighbours = PG.DB.Table("movietags").Select("movie_id").Where("tag_id IN ?", append(tagIDs, changedTagIDs...))
+	if err := PG.queueSimilarityRefresh(nil, neighbours); err != nil {
+		log.Error().Err(err).Msg("Failed to queue the movies affected by the tag change")
+	}
+}
+
+// queueSimilarityRefresh queues the given movies and those selected by the neighbours subquery, which must select a movie_id column.
+// A movie already queued has its queued time bumped; the statement waits for a worker holding it, so an edit made while the
+// movie is being refreshed is never lost.
+func (PG *Postgresql) queueSimilarityRefresh(movieIDs []int, neighbours *gorm.DB) error {
+	return PG.DB.Exec(`INSERT INTO similarity_refreshes (movie_id, queued_at)
+		SELECT movie_id, now() FROM (SELECT id AS movie_id FROM movies WHERE id IN ? UNION SELECT movie_id FROM (?) AS neighbours) AS affected
+		ON CONFLICT (movie_id) DO UPDATE SET queued_at = EXCLUDED.queued_at`, movieIDs, neighbours).Error
+}
+
+// loadMovieFeatures reads the attributes every similarity score needs for the whole catalogue.
+func loadMovieFeatures(db *gorm.DB) (map[int]*movieFeatures, error) {
+	var movies []models.Movie
+	if err := db.Select("id, release_date, rating").Find(&movies).Error; err != nil {
+		return nil, err
+	}
+
+	features := make(map[int]*movieFeatures, len(movies))
+	for _, movie := range movies {
+		year := 0
+		if len(movie.ReleaseDate) >= 4 {
+			year, _ = strconv.Atoi(movie.ReleaseDate[:4])
+		}
+		features[movie.ID] = &movieFeatures{
+			id:     movie.ID,
+			year:   year,
+			rating: movie.Rating,
+			actors: map[int]bool{},
+			genres: map[int]bool{},
+			tags:   map[int]bool{},
+		}
+	}
+
+	// Trashed actors no longer count as shared cast; trashed movies are already left out of features.
+	joins := []struct {
+		table   string
+		column  string
+		members string
+		set     func(*movieFeatures) map[int]bool
+	}{
+		{"actormovies", "actor_id", activeActorIDs, func(m *movieFeatures) map[int]bool { return m.actors }},
+		{"moviegenres", "genre_id", "", func(m *movieFeatures) map[int]bool { return m.genres }},
+		{"movietags", "tag_id", "", func(m *movieFeatures) map[int]bool { return m.tags }},
+	}
+	for _, join := range joins {
+		var links []struct {
+			MemberID int
+			MovieID  int
+		}
+		query := db.Table(join.table).Select(join.column + " AS member_id, movie_id")
+		if join.members != "" {
+			query = query.Where(join.column + " IN (" + join.members + ")")
+		}
+		if err := query.Scan(&links).Error; err != nil {
+			return nil, err
+		}
+		for _, link := range links {
+			if movie, ok := features[link.MovieID]; ok {
+				join.set(movie)[link.MemberID] = true
+			}
+		}
+	}
+
+	return features, nil
+}
+
+// rankSimilar scores the target against every other movie and returns its best matches, highest score first.
+func rankSimilar(target *movieFeatures, features map[int]*movieFeatures) []models.MovieSimilarity {
+	var rows []models.MovieSimilarity
+	for id, candidate := range features {
+		if id == target.id {
+			continue
+		}
+		if score := similarityScore(target, candidate); score > 0 {
+			rows = append(rows, models.MovieSimilarity{MovieID: target.id, SimilarMovieID: id, Score: score})
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Score != rows[j].Score {
+			return rows[i].Score > rows[j].Score
+		}
+		return rows[i].SimilarMovieID < rows[j].SimilarMovieID
+	})
+
+	if len(rows) > similarityListSize {
+		rows = rows[:similarityListSize]
+	}
+	return rows
+}
+
+// similarityScore rates how good a recommendation the candidate is for the target, from 0 to 1.
+// It combines the Jaccard indexes of the two casts, genre sets and tag sets, the proximity of the release years
+// and the candidate's own rating. Movies sharing neither cast, genres, tags nor era are not related at all and score 0,
+// however well rated the candidate is.
+func similarityScore(target, candidate *movieFeatures) float64 {
+	cast := jaccard(target.actors, candidate.actors)
+	genres := jaccard(target.genres, candidate.genres)
+	tags := jaccard(target.tags, candidate.tags)
+
+	era := 0.0
+	if target.year != 0 && candidate.year != 0 {
+		era = math.Max(0, 1-math.Abs(float64(target.year-candidate.year))/similarityEraSpan)
+	}
+
+	if cast == 0 && genres == 0 && tags == 0 && era == 0 {
+		return 0
+	}
+
+	score := similarityCastWeight*cast +
+		similarityGenreWeight*genres +
+		similarityTagWeight*tags +
+		similarityEraWeight*era +
+		similarityRatingWeight*candidate.rating/10
+	return math.Round(score*10000) / 10000
+}
+
+// jaccard returns the size of the intersection of two sets divided by the size of their union, or 0 if both are empty.
+func jaccard(a, b map[int]bool) float64 {
+	shared, union := 0, len(a)
+	for member := range b {
+		if a[member] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+// set builds a set of IDs.
+func set(ids ...int) map[int]bool {
+	members := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		members[id] = true
+	}
+	return members
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b map[int]bool
+		want float64
+	}{
+		{"both empty", set(), set(), 0},
+		{"one empty", set(1, 2), set(), 0},
+		{"identical", set(1, 2), set(1, 2), 1},
+		{"disjoint", set(1, 2), set(3, 4), 0},
+		{"half shared", set(1, 2, 3), set(2, 3, 4), 0.5},
+		{"subset", set(1), set(1, 2, 3, 4), 0.25},
+	}
+
+	for _, tt := range tests {
+		if got := jaccard(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: jaccard = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarityScore(t *testing.T) {
+	target := &movieFeatures{id: 1, year: 2000, rating: 8, actors: set(1, 2), genres: set(1), tags: set(1)}
+
+	tests := []struct {
+		name      string
+		candidate *movieFeatures
+		want      float64
+	}{
+		{
+			"unrelated movie scores zero however well rated",
+			&movieFeatures{id: 2, year: 1950, rating: 10, actors: set(9), genres: set(9), tags: set(9)},
+			0,
+		},
+		{
+			"same cast, genres, tags and year",
+			&movieFeatures{id: 2, year: 2000, rating: 10, actors: set(1, 2), genres: set(1), tags: set(1)},
+			1,
+		},
+		{
+			"era only, ten years apart",
+			&movieFeatures{id: 2, year: 2010, rating: 0, actors: set(), genres: set(), tags: set()},
+			similarityEraWeight * 0.5,
+		},
+		{
+			"unknown year gives no era proximity",
+			&movieFeatures{id: 2, year: 0, rating: 5, actors: set(1), genres: set(), tags: set()},
+			math.Round((similarityCastWeight*0.5+similarityRatingWeight*0.5)*10000) / 10000,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := similarityScore(target, tt.candidate); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: similarityScore = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRankSimilar(t *testing.T) {
+	features := map[int]*movieFeatures{
+		1: {id: 1, year: 2000, rating: 7, actors: set(1, 2), genres: set(1), tags: set()},
+		2: {id: 2, year: 2000, rating: 7, actors: set(1, 2), genres: set(1), tags: set()},
+		3: {id: 3, year: 2001, rating: 7, actors: set(1), genres: set(1), tags: set()},
+		4: {id: 4, year: 1900, rating: 9, actors: set(7), genres: set(7), tags: set()},
+	}
+	for id := 5; id < 5+similarityListSize+5; id++ {
+		features[id] = &movieFeatures{id: id, year: 2019, rating: 1, actors: set(), genres: set(), tags: set()}
+	}
+
+	rows := rankSimilar(features[1], features)
+
+	if len(rows) != similarityListSize {
+		t.Fatalf("rankSimilar returned %d rows, want %d", len(rows), similarityListSize)
+	}
+	if rows[0].SimilarMovieID != 2 || rows[1].SimilarMovieID != 3 {
+		t.Errorf("rankSimilar best matches = %d, %d, want 2, 3", rows[0].SimilarMovieID, rows[1].SimilarMovieID)
+	}
+	for i, row := range rows {
+		if row.MovieID != 1 || row.SimilarMovieID == 1 || row.SimilarMovieID == 4 {
+			t.Errorf("rankSimilar row %d = %+v, want a match for movie 1 other than itself and the unrelated movie 4", i, row)
+		}
+		if i > 0 && (row.Score > rows[i-1].Score || row.Score == rows[i-1].Score && row.SimilarMovieID < rows[i-1].SimilarMovieID) {
+			t.Errorf("rankSimilar rows %d and %d are out of order", i-1, i)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// taxonomy describes a flat vocabulary of unique names movies are classified by, such as genres or tags.
+// The terms of every taxonomy share the same CRUD endpoints, implemented once by the generic functions below.
+type taxonomy struct {
+	// entity is the name of a term in messages, e.g. "genre".
+	entity string
+	// title is entity capitalized, for the start of messages.
+	title string
+	// joinTable links the terms to movies.
+	joinTable string
+	// column is the column of joinTable holding the term ID.
+	column string
+}
+
+var (
+	genreTaxonomy = taxonomy{entity: "genre", title: "Genre", joinTable: "moviegenres", column: "genre_id"}
+	tagTaxonomy   = taxonomy{entity: "tag", title: "Tag", joinTable: "movietags", column: "tag_id"}
+)
+
+// GenreAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a new genre
+// @Description Adds a new genre with the given name. Genre names are unique. Requires 'admin' role.
+// @Tags genre
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param genre body models.Genre true "Genre to add"
+// @Success 200 {object} models.Genre "Successfully added the genre"
+// @Failure 400 "Invalid request body or empty name"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "A genre with this name already exists"
+// @Failure 500 "Error creating genre"
+// @Router /v1/genre-add [post]
+func (PG *Postgresql) GenreAdd(w http.ResponseWriter, r *http.Request) (*models.Genre, error) {
+	log.Info().Msg("GenreAdd called")
+	return addTerm(PG, w, r, genreTaxonomy, func(name string) *models.Genre { return &models.Genre{Name: name} })
+}
+
+// GenreEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Renames a genre
+// @Description Edits the genre with the specified ID based on the given update fields. Requires 'admin' role.
+// @Tags genre
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Genre ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Genre "Successfully updated the genre"
+// @Failure 400 "Invalid request body or genre ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Genre not found"
+// @Failure 409 "A genre with this name already exists"
+// @Failure 500 "Failed to save genre"
+// @Router /v1/genre-edit/{id} [put]
+func (PG *Postgresql) GenreEdit(w http.ResponseWriter, r *http.Request) (*models.Genre, error) {
+	log.Info().Msg("GenreEdit called")
+	return editTerm[models.Genre](PG, w, r, genreTaxonomy)
+}
+
+// GenreList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists all genres
+// @Description Retrieves all genres ordered by name. Available to both 'admin' and 'user' roles.
+// @Tags genre
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.Genre "Successfully retrieved all genres"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving genres"
+// @Router /v1/genre-list [get]
+func (PG *Postgresql) GenreList(w http.ResponseWriter, r *http.Request) (*[]models.Genre, error) {
+	log.Info().Msg("GenreList called")
+	return listTerms[models.Genre](PG, w, genreTaxonomy)
+}
+
+// GenreDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a genre
+// @Description Deletes the genre with the specified ID and unlinks it from all movies. Requires 'admin' role.
+// @Tags genre
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Genre ID"
+// @Success 200 "Successfully deleted the genre"
+// @Failure 400 "Invalid genre ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Genre not found"
+// @Failure 500 "Genre could not be deleted"
+// @Router /v1/genre-delete/{id} [delete]
+func (PG *Postgresql) GenreDelete(w http.ResponseWriter, r *http.Request) (*models.Genre, error) {
+	log.Info().Msg("GenreDelete called")
+	return deleteTerm[models.Genre](PG, w, r, genreTaxonomy)
+}
+
+// TagAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Adds a new tag
+// @Description Adds a new tag with the given keyword. Tag names are unique. Requires 'admin' role.
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param tag body models.Tag true "Tag to add"
+// @Success 200 {object} models.Tag "Successfully added the tag"
+// @Failure 400 "Invalid request body or empty name"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 409 "A tag with this name already exists"
+// @Failure 500 "Error creating tag"
+// @Router /v1/tag-add [post]
+func (PG *Postgresql) TagAdd(w http.ResponseWriter, r *http.Request) (*models.Tag, error) {
+	log.Info().Msg("TagAdd called")
+	return addTerm(PG, w, r, tagTaxonomy, func(name string) *models.Tag { return &models.Tag{Name: name} })
+}
+
+// TagEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Renames a tag
+// @Description Edits the tag with the specified ID based on the given update fields. Requires 'admin' role.
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Tag ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Tag "Successfully updated the tag"
+// @Failure 400 "Invalid request body or tag ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Tag not found"
+// @Failure 409 "A tag with this name already exists"
+// @Failure 500 "Failed to save tag"
+// @Router /v1/tag-edit/{id} [put]
+func (PG *Postgresql) TagEdit(w http.ResponseWriter, r *http.Request) (*models.Tag, error) {
+	log.Info().Msg("TagEdit called")
+	return editTerm[models.Tag](PG, w, r, tagTaxonomy)
+}
+
+// TagList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists all tags
+// @Description Retrieves all tags ordered by name. Available to both 'admin' and 'user' roles.
+// @Tags tag
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.Tag "Successfully retrieved all tags"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving tags"
+// @Router /v1/tag-list [get]
+func (PG *Postgresql) TagList(w http.ResponseWriter, r *http.Request) (*[]models.Tag, error) {
+	log.Info().Msg("TagList called")
+	return listTerms[models.Tag](PG, w, tagTaxonomy)
+}
+
+// TagDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a tag
+// @Description Deletes the tag with the specified ID and unlinks it from all movies. Requires 'admin' role.
+// @Tags tag
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Tag ID"
+// @Success 200 "Successfully deleted the tag"
+// @Failure 400 "Invalid tag ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Tag not found"
+// @Failure 500 "Tag could not be deleted"
+// @Router /v1/tag-delete/{id} [delete]
+func (PG *Postgresql) TagDelete(w http.ResponseWriter, r *http.Request) (*models.Tag, error) {
+	log.Info().Msg("TagDelete called")
+	return deleteTerm[models.Tag](PG, w, r, tagTaxonomy)
+}
+
+// addTerm creates the term named in the request body, built by newTerm from the trimmed name.
+// A name already taken answers 409 Conflict.
+func addTerm[T any](PG *Postgresql, w http.ResponseWriter, r *http.Request, kind taxonomy, newTerm func(name string) *T) (*T, error) {
+	var body struct{ Name string }
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	name := strings.TrimSpace(body.Name)
+	if name == "" {
+		log.Error().Msgf("Empty %s name", kind.entity)
+		http.Error(w, kind.title+" name is required", http.StatusBadRequest)
+		return nil, errors.New("empty " + kind.entity + " name")
+	}
+
+	data := newTerm(name)
+	if err := PG.DB.Create(data).Error; err != nil {
+		log.Error().Err(err).Msgf("Error creating %s", kind.entity)
+		http.Error(w, termError(kind, err), associationStatus(err))
+		return nil, err
+	}
+
+	log.Info().Str("name", name).Msgf("%s added successfully", kind.title)
+	return data, nil
+}
+
+// editTerm renames the term with the ID in the path to the name in the request body. An empty name leaves it unchanged.
+func editTerm[T any](PG *Postgresql, w http.ResponseWriter, r *http.Request, kind taxonomy) (*T, error) {
+	id, err := idFromPath(w, r, kind.entity)
+	if err != nil {
+		return nil, err
+	}
+
+	data := new(T)
+	if err := PG.DB.First(data, "id = ?", id).Error; err != nil {
+		log.Error().Err(err).Msgf("%s not found", kind.title)
+		http.Error(w, kind.title+" not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	if name, ok := updates["name"].(string); ok && strings.TrimSpace(name) != "" {
+		if err := PG.DB.Model(data).Update("name", strings.TrimSpace(name)).Error; err != nil {
+			log.Error().Err(err).Msgf("Failed to save %s", kind.entity)
+			http.Error(w, termError(kind, err), associationStatus(err))
+			return nil, err
+		}
+		if err := PG.DB.First(data, "id = ?", id).Error; err != nil {
+			log.Error().Err(err).Msgf("Failed to reload %s", kind.entity)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return nil, err
+		}
+	}
+
+	log.Info().Int("id", id).Msgf("%s updated successfully", kind.title)
+	return data, nil
+}
+
+// listTerms returns every term of the taxonomy ordered by name.
+func listTerms[T any](PG *Postgresql, w http.ResponseWriter, kind taxonomy) (*[]T, error) {
+	var data []T
+	if err := PG.DB.Order("name").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msgf("Error retrieving %ss", kind.entity)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msgf("Successfully retrieved %ss", kind.entity)
+	return &data, nil
+}
+
+// deleteTerm deletes the term with the ID in the path together with its movie links, in one transaction.
+func deleteTerm[T any](PG *Postgresql, w http.ResponseWriter, r *http.Request, kind taxonomy) (*T, error) {
+	id, err := idFromPath(w, r, kind.entity)
+	if err != nil {
+		return nil, err
+	}
+
+	errNotFound := errors.New(kind.entity + " not found")
+	data := new(T)
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM "+kind.joinTable+" WHERE "+kind.column+" = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(data)
+		if result.Error == nil && result.RowsAffected == 0 {
+			return errNotFound
+		}
+		return result.Error
+	})
+	if errors.Is(err, errNotFound) {
+		log.Error().Int("id", id).Msgf("%s not found", kind.title)
+		http.Error(w, kind.title+" not found", http.StatusNotFound)
+		return nil, err
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("%s could not be deleted", kind.title)
+		http.Error(w, kind.title+" could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("id", id).Msgf("%s deleted successfully", kind.title)
+	return data, nil
+}
+
+// termError is the message answered when saving a term fails.
+func termError(kind taxonomy, err error) string {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return "A " + kind.entity + " with this name already exists"
+	}
+	return err.Error()
+}
@@ -0,0 +1,470 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+	"vk.com/m/models"
+)
+
+// languageTagPattern matches the BCP 47 language tags accepted for translations, such as "en", "en-GB" or "sr-Latn".
+var languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// catalogueLanguage returns the language the titles, descriptions and names stored on movies and actors are written in,
+// read from the CATALOGUE_LANGUAGE environment variable and defaulting to Russian.
+func catalogueLanguage() string {
+	if language, ok := normalizeLanguage(os.Getenv("CATALOGUE_LANGUAGE")); ok {
+		return language
+	}
+	return "ru"
+}
+
+// normalizeLanguage lower-cases a language tag and reports whether it is well formed.
+func normalizeLanguage(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	return tag, languageTagPattern.MatchString(tag)
+}
+
+// validTranslationLanguage checks the language of a translation, answering with a 400 status when it is malformed
+// or is the catalogue language itself, whose text is edited on the movie or actor directly.
+func (PG *Postgresql) validTranslationLanguage(w http.ResponseWriter, tag string) (string, error) {
+	language, ok := normalizeLanguage(tag)
+	if !ok {
+		log.Error().Str("language", tag).Msg("Invalid language tag")
+		http.Error(w, "Invalid language tag", http.StatusBadRequest)
+		return "", errors.New("invalid language tag")
+	}
+	if language == PG.Language {
+		log.Error().Str("language", language).Msg("Translation into the catalogue language")
+		http.Error(w, "The catalogue language is edited on the entity itself", http.StatusBadRequest)
+		return "", errors.New("translation into the catalogue language")
+	}
+	return language, nil
+}
+
+// MovieTranslationSet godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Sets a movie translation
+// @Description Creates or replaces the title and description of the movie with the specified ID in the given language. Requires 'admin' role.
+// @Tags translation
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Param translation body models.MovieTranslation true "Language, title and optional description"
+// @Success 200 {object} models.MovieTranslation "Successfully saved the translation"
+// @Failure 400 "Invalid request body, movie ID, language or empty title"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Movie not found"
+// @Failure 500 "Error saving translation"
+// @Router /v1/movie-translation/{id} [put]
+func (PG *Postgresql) MovieTranslationSet(w http.ResponseWriter, r *http.Request) (*models.MovieTranslation, error) {
+
+	log.Info().Msg("MovieTranslationSet called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.MovieTranslation
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.MovieID = movieID
+
+	if data.Language, err = PG.validTranslationLanguage(w, data.Language); err != nil {
+		return nil, err
+	}
+	data.Title = strings.TrimSpace(data.Title)
+	if data.Title == "" {
+		log.Error().Msg("Empty translated title")
+		http.Error(w, "Title is required", http.StatusBadRequest)
+		return nil, errors.New("empty translated title")
+	}
+
+	if err := PG.DB.First(&models.Movie{}, "id = ?", movieID).Error; err != nil {
+		log.Error().Err(err).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	err = PG.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "movie_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description"}),
+	}).Create(&data).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error saving translation")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("movieID", movieID).Str("language", data.Language).Msg("Movie translation saved successfully")
+	return &data, nil
+}
+
+// MovieTranslationList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the translations of a movie
+// @Description Retrieves every translation of the movie with the specified ID, ordered by language. Available to both 'admin' and 'user' roles.
+// @Tags translation
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Success 200 {array} models.MovieTranslation "Successfully retrieved the translations"
+// @Failure 400 "Invalid movie ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving translations"
+// @Router /v1/movie-translations/{id} [get]
+func (PG *Postgresql) MovieTranslationList(w http.ResponseWriter, r *http.Request) (*[]models.MovieTranslation, error) {
+	log.Info().Msg("MovieTranslationList called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.MovieTranslation
+
+	if err := PG.DB.Where("movie_id = ?", movieID).Order("language").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving translations")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Successfully retrieved movie translations")
+	return &data, nil
+}
+
+// MovieTranslationDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes a movie translation
+// @Description Deletes the translation of the movie with the specified ID into the given language. Requires 'admin' role.
+// @Tags translation
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Param language query string true "Language of the translation"
+// @Success 200 "Successfully deleted the translation"
+// @Failure 400 "Invalid movie ID or language"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Translation could not be deleted"
+// @Router /v1/movie-translation-delete/{id} [delete]
+func (PG *Postgresql) MovieTranslationDelete(w http.ResponseWriter, r *http.Request) (*models.MovieTranslation, error) {
+
+	log.Info().Msg("MovieTranslationDelete called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	language, err := PG.validTranslationLanguage(w, r.URL.Query().Get("language"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.Where("movie_id = ? AND language = ?", movieID, language).Delete(&models.MovieTranslation{}).Error; err != nil {
+		log.Error().Err(err).Msg("Translation could not be deleted")
+		http.Error(w, "Translation could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("movieID", movieID).Str("language", language).Msg("Movie translation deleted successfully")
+	return &models.MovieTranslation{}, nil
+}
+
+// ActorTranslationSet godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Sets an actor name transliteration
+// @Description Creates or replaces the name of the actor with the specified ID as written in the given language. Requires 'admin' role.
+// @Tags translation
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Param translation body models.ActorTranslation true "Language and name"
+// @Success 200 {object} models.ActorTranslation "Successfully saved the translation"
+// @Failure 400 "Invalid request body, actor ID, language or empty name"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Actor not found"
+// @Failure 500 "Error saving translation"
+// @Router /v1/actor-translation/{id} [put]
+func (PG *Postgresql) ActorTranslationSet(w http.ResponseWriter, r *http.Request) (*models.ActorTranslation, error) {
+
+	log.Info().Msg("ActorTranslationSet called")
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.ActorTranslation
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ActorID = actorID
+
+	if data.Language, err = PG.validTranslationLanguage(w, data.Language); err != nil {
+		return nil, err
+	}
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		log.Error().Msg("Empty translated name")
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return nil, errors.New("empty translated name")
+	}
+
+	if err := PG.DB.First(&models.Actor{}, "id = ?", actorID).Error; err != nil {
+		log.Error().Err(err).Msg("Actor not found")
+		http.Error(w, "Actor not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	err = PG.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "actor_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}).Create(&data).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error saving translation")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("actorID", actorID).Str("language", data.Language).Msg("Actor translation saved successfully")
+	return &data, nil
+}
+
+// ActorTranslationList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the name transliterations of an actor
+// @Description Retrieves every translation of the name of the actor with the specified ID, ordered by language. Available to both 'admin' and 'user' roles.
+// @Tags translation
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Success 200 {array} models.ActorTranslation "Successfully retrieved the translations"
+// @Failure 400 "Invalid actor ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving translations"
+// @Router /v1/actor-translations/{id} [get]
+func (PG *Postgresql) ActorTranslationList(w http.ResponseWriter, r *http.Request) (*[]models.ActorTranslation, error) {
+	log.Info().Msg("ActorTranslationList called")
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.ActorTranslation
+
+	if err := PG.DB.Where("actor_id = ?", actorID).Order("language").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving translations")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("count", len(data)).Msg("Successfully retrieved actor translations")
+	return &data, nil
+}
+
+// ActorTranslationDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes an actor name transliteration
+// @Description Deletes the name of the actor with the specified ID in the given language. Requires 'admin' role.
+// @Tags translation
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Param language query string true "Language of the translation"
+// @Success 200 "Successfully deleted the translation"
+// @Failure 400 "Invalid actor ID or language"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Translation could not be deleted"
+// @Router /v1/actor-translation-delete/{id} [delete]
+func (PG *Postgresql) ActorTranslationDelete(w http.ResponseWriter, r *http.Request) (*models.ActorTranslation, error) {
+
+	log.Info().Msg("ActorTranslationDelete called")
+
+	actorID, err := PG.actorIDFromPath(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	language, err := PG.validTranslationLanguage(w, r.URL.Query().Get("language"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.DB.Where("actor_id = ? AND language = ?", actorID, language).Delete(&models.ActorTranslation{}).Error; err != nil {
+		log.Error().Err(err).Msg("Translation could not be deleted")
+		http.Error(w, "Translation could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("actorID", actorID).Str("language", language).Msg("Actor translation deleted successfully")
+	return &models.ActorTranslation{}, nil
+}
+
+// LocalizeMovies rewrites the titles and descriptions of the movies and their related titles, and the names of their cast and crew,
+// in the first of the given languages they are available in. The languages are lower-cased tags, most preferred first.
+// Text in a language the client did not ask for, or asked for with a lower preference than the catalogue language, is left as stored.
+func (PG *Postgresql) LocalizeMovies(movies []models.Movie, languages []string) error {
+	var moviesToLocalize []*models.Movie
+	var actorsToLocalize []*models.Actor
+	for i := range movies {
+		moviesToLocalize = append(moviesToLocalize, &movies[i])
+		actorsToLocalize = append(actorsToLocalize, movies[i].Actors...)
+		for _, related := range movies[i].Relations {
+			moviesToLocalize = append(moviesToLocalize, related.Movie)
+		}
+		for _, credit := range movies[i].Crew {
+			if credit.Person != nil {
+				actorsToLocalize = append(actorsToLocalize, credit.Person)
+			}
+		}
+	}
+	return PG.localize(moviesToLocalize, actorsToLocalize, languages)
+}
+
+// LocalizeActors rewrites the names of the actors, and the titles and descriptions of the movies they are credited on,
+// in the same way as LocalizeMovies.
+func (PG *Postgresql) LocalizeActors(actors []models.Actor, languages []string) error {
+	var moviesToLocalize []*models.Movie
+	var actorsToLocalize []*models.Actor
+	for i := range actors {
+		actorsToLocalize = append(actorsToLocalize, &actors[i])
+		moviesToLocalize = append(moviesToLocalize, actors[i].Movies...)
+		for _, credit := range actors[i].CrewCredits {
+			if credit.Movie != nil {
+				moviesToLocalize = append(moviesToLocalize, credit.Movie)
+			}
+		}
+	}
+	return PG.localize(moviesToLocalize, actorsToLocalize, languages)
+}
+
+// localize fetches the translations of the movies and actors into the acceptable languages and applies the best one of each.
+// Every localized entity gets its Language set, to the translation picked or to the catalogue language.
+func (PG *Postgresql) localize(movies []*models.Movie, actors []*models.Actor, languages []string) error {
+	ranks := languageRanks(languages)
+	if len(ranks) == 0 {
+		return nil
+	}
+	candidates := make([]string, 0, len(ranks))
+	for language := range ranks {
+		candidates = append(candidates, language)
+	}
+
+	if len(movies) > 0 {
+		movieIDs := make([]int, len(movies))
+		for i, movie := range movies {
+			movieIDs[i] = movie.ID
+		}
+		var translations []models.MovieTranslation
+		if err := PG.DB.Where("movie_id IN ? AND language IN ?", movieIDs, candidates).Find(&translations).Error; err != nil {
+			return err
+		}
+
+		best := make(map[int]models.MovieTranslation, len(translations))
+		for _, translation := range translations {
+			if current, ok := best[translation.MovieID]; !ok || ranks[translation.Language] < ranks[current.Language] {
+				best[translation.MovieID] = translation
+			}
+		}
+		for _, movie := range movies {
+			movie.Language = PG.Language
+			translation, ok := best[movie.ID]
+			if !ok || !PG.prefersTranslation(ranks, translation.Language) {
+				continue
+			}
+			movie.Title, movie.Language = translation.Title, translation.Language
+			if translation.Description != "" {
+				movie.Description = translation.Description
+			}
+		}
+	}
+
+	if len(actors) > 0 {
+		actorIDs := make([]int, len(actors))
+		for i, actor := range actors {
+			actorIDs[i] = actor.ID
+		}
+		var translations []models.ActorTranslation
+		if err := PG.DB.Where("actor_id IN ? AND language IN ?", actorIDs, candidates).Find(&translations).Error; err != nil {
+			return err
+		}
+
+		best := make(map[int]models.ActorTranslation, len(translations))
+		for _, translation := range translations {
+			if current, ok := best[translation.ActorID]; !ok || ranks[translation.Language] < ranks[current.Language] {
+				best[translation.ActorID] = translation
+			}
+		}
+		for _, actor := range actors {
+			actor.Language = PG.Language
+			translation, ok := best[actor.ID]
+			if !ok || !PG.prefersTranslation(ranks, translation.Language) {
+				continue
+			}
+			actor.Name, actor.Language = translation.Name, translation.Language
+		}
+	}
+
+	return nil
+}
+
+// prefersTranslation reports whether a translation into the language beats the stored text for a client with the given ranks.
+func (PG *Postgresql) prefersTranslation(ranks map[string]int, language string) bool {
+	catalogueRank, ok := ranks[PG.Language]
+	return !ok || ranks[language] < catalogueRank
+}
+
+// languageRanks maps the acceptable languages to their preference rank, lowest first.
+// Each regional tag is directly followed by its primary language, so "en-gb" also accepts an "en" translation
+// before any less preferred language, while an exact regional translation still wins over the generic one.
+func languageRanks(languages []string) map[string]int {
+	ranks := map[string]int{}
+	accept := func(language string) {
+		if _, ok := ranks[language]; !ok {
+			ranks[language] = len(ranks)
+		}
+	}
+	for _, language := range languages {
+		accept(language)
+		if primary, _, regional := strings.Cut(language, "-"); regional {
+			accept(primary)
+		}
+	}
+	return ranks
+}
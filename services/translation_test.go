@@ -0,0 +1,49 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLanguageRanks(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []string
+		want      map[string]int
+	}{
+		{"none", nil, map[string]int{}},
+		{"primary languages keep their order", []string{"fr", "de"}, map[string]int{"fr": 0, "de": 1}},
+		{"regional tag followed by its primary language", []string{"en-gb", "fr"}, map[string]int{"en-gb": 0, "en": 1, "fr": 2}},
+		{"explicit primary language not moved down", []string{"en", "en-gb"}, map[string]int{"en": 0, "en-gb": 1}},
+		{"duplicates keep the first rank", []string{"de", "fr", "de"}, map[string]int{"de": 0, "fr": 1}},
+		{"several regions of one language", []string{"pt-br", "pt-pt"}, map[string]int{"pt-br": 0, "pt": 1, "pt-pt": 2}},
+	}
+
+	for _, tt := range tests {
+		if got := languageRanks(tt.languages); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: languageRanks(%v) = %v, want %v", tt.name, tt.languages, got, tt.want)
+		}
+	}
+}
+
+func TestPrefersTranslation(t *testing.T) {
+	PG := &Postgresql{Language: "ru"}
+
+	tests := []struct {
+		name      string
+		languages []string
+		language  string
+		want      bool
+	}{
+		{"catalogue language not accepted", []string{"en"}, "en", true},
+		{"translation preferred over catalogue language", []string{"en", "ru"}, "en", true},
+		{"catalogue language preferred over translation", []string{"ru", "en"}, "en", false},
+		{"primary language of a region preferred", []string{"en-gb", "ru"}, "en", true},
+	}
+
+	for _, tt := range tests {
+		if got := PG.prefersTranslation(languageRanks(tt.languages), tt.language); got != tt.want {
+			t.Errorf("%s: prefersTranslation = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/models"
+)
+
+// activeMovieIDs and activeActorIDs select the movies and actors outside of the trash, for the queries that read
+// join tables directly and so are not covered by the soft delete scope GORM adds to queries on the models.
+const (
+	activeMovieIDs = "SELECT id FROM movies WHERE deleted_at IS NULL"
+	activeActorIDs = "SELECT id FROM actors WHERE deleted_at IS NULL"
+)
+
+// defaultTrashRetention is how long trashed movies and actors are kept when TRASH_RETENTION_DAYS is not set.
+const defaultTrashRetention = 30 * 24 * time.Hour
+
+// trashRetention returns how long trashed movies and actors are kept before the purge job removes them,
+// read in days from the TRASH_RETENTION_DAYS environment variable.
+func trashRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || days < 0 {
+		return defaultTrashRetention
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// TrashList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the trash
+// @Description Retrieves the movies and actors in the trash, most recently deleted first, with the time the purge job removes each of them for good. Requires 'admin' role.
+// @Tags trash
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param type query string false "Only list one kind of item [movie|actor]"
+// @Success 200 {array} models.TrashItem "Successfully retrieved the trash"
+// @Failure 400 "Invalid type"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error retrieving the trash"
+// @Router /v1/trash-list [get]
+func (PG *Postgresql) TrashList(w http.ResponseWriter, r *http.Request) (*[]models.TrashItem, error) {
+	log.Info().Msg("TrashList called")
+
+	kind := r.URL.Query().Get("type")
+	if kind != "" && kind != "movie" && kind != "actor" {
+		log.Error().Str("type", kind).Msg("Invalid trash item type")
+		http.Error(w, "Invalid type, expected movie or actor", http.StatusBadRequest)
+		return nil, errors.New("invalid trash item type")
+	}
+
+	var movies, actors []models.TrashItem
+	if kind != "actor" {
+		err := PG.DB.Unscoped().Model(&models.Movie{}).Select("'movie' AS type, id, title AS name, deleted_at").
+			Where("deleted_at IS NOT NULL").Scan(&movies).Error
+		if err != nil {
+			log.Error().Err(err).Msg("Error retrieving trashed movies")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return nil, err
+		}
+	}
+	if kind != "movie" {
+		err := PG.DB.Unscoped().Model(&models.Actor{}).Select("'actor' AS type, id, name, deleted_at").
+			Where("deleted_at IS NOT NULL").Scan(&actors).Error
+		if err != nil {
+			log.Error().Err(err).Msg("Error retrieving trashed actors")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return nil, err
+		}
+	}
+
+	data := append(movies, actors...)
+	for i := range data {
+		data[i].PurgeAt = data[i].DeletedAt.Add(PG.TrashRetention)
+	}
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].DeletedAt.After(data[j].DeletedAt)
+	})
+
+	log.Info().Int("count", len(data)).Msg("Successfully retrieved the trash")
+	return &data, nil
+}
+
+// MovieRestore godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Restores a movie from the trash
+// @Description Takes the movie with the specified ID out of the trash. Its cast, crew, genres, reviews, watchlist entries and every other link come back with it, since they are kept while the movie is trashed. Requires 'admin' role.
+// @Tags trash
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Success 200 {object} models.Movie "Successfully restored the movie"
+// @Failure 400 "Invalid movie ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Movie not in the trash"
+// @Failure 500 "Error restoring movie"
+// @Router /v1/movie-restore/{id} [put]
+func (PG *Postgresql) MovieRestore(w http.ResponseWriter, r *http.Request) (*models.Movie, error) {
+	log.Info().Msg("MovieRestore called")
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.restore(w, r, &models.Movie{}, "movie", movieID); err != nil {
+		return nil, err
+	}
+
+	PG.refreshAfterCastChange(nil, movieID)
+
+	movies, err := PG.movieDetails([]int{movieID})
+	if err != nil {
+		log.Error().Err(err).Msg("Error loading restored movie")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("movieID", movieID).Msg("Movie restored successfully")
+	return movies[movieID], nil
+}
+
+// ActorRestore godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Restores an actor from the trash
+// @Description Takes the actor with the specified ID out of the trash. Their movies, crew credits, guest appearances, followers and every other link come back with them, since they are kept while the actor is trashed. Requires 'admin' role.
+// @Tags trash
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Actor ID"
+// @Success 200 {object} models.Actor "Successfully restored the actor"
+// @Failure 400 "Invalid actor ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 404 "Actor not in the trash"
+// @Failure 500 "Error restoring actor"
+// @Router /v1/actor-restore/{id} [put]
+func (PG *Postgresql) ActorRestore(w http.ResponseWriter, r *http.Request) (*models.Actor, error) {
+	log.Info().Msg("ActorRestore called")
+
+	actorID, err := idFromPath(w, r, "actor")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := PG.restore(w, r, &models.Actor{}, "actor", actorID); err != nil {
+		return nil, err
+	}
+
+	var movieIDs []int
+	if err := PG.DB.Table("actormovies").Where("actor_id = ?", actorID).Pluck("movie_id", &movieIDs).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to load the actor's movies")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	PG.refreshAfterCastChange(nil, movieIDs...)
+
+	var data models.Actor
+	if err := PG.DB.First(&data, "id = ?", actorID).Error; err != nil {
+		log.Error().Err(err).Msg("Error loading restored actor")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	actors := []models.Actor{data}
+	if err := PG.loadFilmography(actors); err != nil {
+		log.Error().Err(err).Msg("Error loading filmography")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("actorID", actorID).Msg("Actor restored successfully")
+	return &actors[0], nil
+}
+
+// restore clears the deletion time of the trashed movie or actor and records the restore in the same transaction,
+// answering with 404 Not Found when it is not in the trash.
+func (PG *Postgresql) restore(w http.ResponseWriter, r *http.Request, model interface{}, entity string, id int) error {
+	errNotInTrash := errors.New(entity + " not in the trash")
+	err := PG.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(model).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errNotInTrash
+		}
+		_, err := recordRevision(tx, r, &models.Revision{EntityType: entity, EntityID: id, Action: "restore"}, nil, nil)
+		return err
+	})
+	if errors.Is(err, errNotInTrash) {
+		log.Error().Int("id", id).Msgf("%s not in the trash", entity)
+		http.Error(w, "Not in the trash", http.StatusNotFound)
+		return err
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Error restoring %s", entity)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
+	}
+	return nil
+}
+
+// PurgeTrash permanently removes the movies and actors that were moved to the trash longer than retention ago,
+// together with everything linked to them. Each item is purged in its own transaction, so a failure leaves the
+// items purged so far removed. Returns the number of movies and actors purged.
+func (PG *Postgresql) PurgeTrash(retention time.Duration) (int, int, error) {
+	cutoff := time.Now().Add(-retention)
+
+	var movieIDs, actorIDs []int
+	if err := PG.DB.Unscoped().Model(&models.Movie{}).Where("deleted_at < ?", cutoff).Order("id").Pluck("id", &movieIDs).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := PG.DB.Unscoped().Model(&models.Actor{}).Where("deleted_at < ?", cutoff).Order("id").Pluck("id", &actorIDs).Error; err != nil {
+		return 0, 0, err
+	}
+
+	for i, movieID := range movieIDs {
+		if err := PG.purgeMovie(movieID); err != nil {
+			return i, 0, err
+		}
+		log.Info().Int("movieID", movieID).Msg("Movie purged")
+	}
+	for i, actorID := range actorIDs {
+		if err := PG.purgeActor(actorID); err != nil {
+			return len(movieIDs), i, err
+		}
+		log.Info().Int("actorID", actorID).Msg("Actor purged")
+	}
+
+	return len(movieIDs), len(actorIDs), nil
+}
+
+// purgeMovie removes the movie for good, with its credits, reviews, watchlist and watched entries, translations,
+// collection entries, relations, nominations, production details, external IDs, notifications, queued similarity
+// refresh, edit proposals and poster.
+func (PG *Postgresql) purgeMovie(movieID int) error {
+	var posterKeys []string
+	err := PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Movie{}).Where("id = ?", movieID).Pluck("poster_key", &posterKeys).Error; err != nil {
+			return err
+		}
+
+		linked := []interface{}{&models.CrewCredit{}, &models.WatchlistEntry{}, &models.WatchedMovie{}, &models.Review{},
+			&models.MovieTranslation{}, &models.CollectionEntry{}, &models.Nomination{}, &models.ExternalID{}, &models.Certification{},
+			&models.Notification{}, &models.SimilarityRefresh{}}
+		for _, model := range linked {
+			if err := tx.Where("movie_id = ?", movieID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("entity_type = ? AND entity_id = ?", "movie", movieID).Delete(&models.EditProposal{}).Error; err != nil {
+			return err
+		}
+
+		statements := []string{
+			"DELETE FROM movie_similarities WHERE movie_id = @id OR similar_movie_id = @id",
+			"DELETE FROM movie_relations WHERE movie_id = @id OR related_movie_id = @id",
+			"DELETE FROM actormovies WHERE movie_id = @id",
+			"DELETE FROM moviegenres WHERE movie_id = @id",
+			"DELETE FROM movietags WHERE movie_id = @id",
+			"DELETE FROM moviestudios WHERE movie_id = @id",
+			"DELETE FROM moviecountries WHERE movie_id = @id",
+			"DELETE FROM movielanguages WHERE movie_id = @id",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement, map[string]interface{}{"id": movieID}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Where("id = ?", movieID).Delete(&models.Movie{}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range posterKeys {
+		PG.deleteImage(key)
+	}
+	return nil
+}
+
+// purgeActor removes the actor for good, with their movie and episode credits, crew credits, followers,
+// translations, aliases, nominations, external IDs, notifications, edit proposals, the redirects of actors merged
+// into them and photo.
+func (PG *Postgresql) purgeActor(actorID int) error {
+	var photoKeys []string
+	err := PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Actor{}).Where("id = ?", actorID).Pluck("photo_key", &photoKeys).Error; err != nil {
+			return err
+		}
+
+		linked := []interface{}{&models.CrewCredit{}, &models.ActorFollow{}, &models.ActorTranslation{}, &models.ActorAlias{},
+			&models.Nomination{}, &models.ExternalID{}, &models.Notification{}}
+		for _, model := range linked {
+			if err := tx.Where("actor_id = ?", actorID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("entity_type = ? AND entity_id = ?", "actor", actorID).Delete(&models.EditProposal{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("to_id = ?", actorID).Delete(&models.ActorRedirect{}).Error; err != nil {
+			return err
+		}
+
+		for _, joinTable := range []string{"episodeactors", "actormovies"} {
+			if err := tx.Exec("DELETE FROM "+joinTable+" WHERE actor_id = ?", actorID).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Where("id = ?", actorID).Delete(&models.Actor{}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range photoKeys {
+		PG.deleteImage(key)
+	}
+	return nil
+}
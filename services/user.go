@@ -0,0 +1,123 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"vk.com/m/auth"
+	"vk.com/m/models"
+)
+
+// ErrInvalidCredentials is returned by Authenticate when the username is unknown or the password does not match.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ErrMissingCredentials is returned by CreateUser when the username or the password is empty.
+var ErrMissingCredentials = errors.New("username and password are required")
+
+// ErrInvalidEmail is returned by CreateUser when the email address cannot be parsed.
+var ErrInvalidEmail = errors.New("invalid email address")
+
+// ErrUsernameTaken is returned by CreateUser when another account already uses the username.
+var ErrUsernameTaken = errors.New("username already taken")
+
+// defaultUsers are created on first start, with the password read from passwordEnv.
+// When that variable is not set a random password is generated and printed once to standard output, never to the log.
+var defaultUsers = []struct{ username, role, passwordEnv string }{
+	{"admin", "admin", "ADMIN_PASSWORD"},
+	{"user", "user", "USER_PASSWORD"},
+}
+
+// Authenticate returns the user with the given username if the password matches.
+func (PG *Postgresql) Authenticate(username, password string) (*models.User, error) {
+	var user models.User
+	if err := PG.DB.First(&user, "username = ?", username).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if !auth.CheckPassword(user.PasswordHash, password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &user, nil
+}
+
+// CreateUser stores a new account with the given role, keeping only the hash of the password.
+// The email address is optional and only used to deliver notifications.
+func (PG *Postgresql) CreateUser(username, password, email, role string) (*models.User, error) {
+	username = strings.TrimSpace(username)
+	if username == "" || password == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	if email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil {
+			return nil, ErrInvalidEmail
+		}
+		email = address.Address
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := models.User{Username: username, PasswordHash: hash, Role: role, Email: email}
+	if err := PG.DB.Create(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrUsernameTaken
+		}
+		return nil, err
+	}
+
+	log.Info().Int("userID", user.ID).Str("role", role).Msg("User created")
+	return &user, nil
+}
+
+// seedUsers creates the default accounts when the users table is empty.
+func (PG *Postgresql) seedUsers() error {
+	var count int64
+	if err := PG.DB.Model(&models.User{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	for _, user := range defaultUsers {
+		password := os.Getenv(user.passwordEnv)
+		if password == "" {
+			generated, err := randomPassword()
+			if err != nil {
+				return err
+			}
+			password = generated
+			log.Warn().Str("username", user.username).
+				Msgf("%s is not set, generated a password for the default account", user.passwordEnv)
+			fmt.Printf("Generated password for the default %q account: %s\n", user.username, password)
+		}
+		if _, err := PG.CreateUser(user.username, password, "", user.role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// randomPassword returns a random URL-safe password of 22 characters.
+func randomPassword() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,585 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"vk.com/m/models"
+	"vk.com/m/utils"
+)
+
+// WatchlistAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Creates a watchlist
+// @Description Creates an empty watchlist with the given name for the authenticated user. Lists are private unless Public is set. Available to both 'admin' and 'user' roles.
+// @Tags watchlist
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param watchlist body models.Watchlist true "Watchlist to create, with Name and Public"
+// @Success 200 {object} models.Watchlist "Successfully created the watchlist"
+// @Failure 400 "Invalid request body or empty name"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 403 "Forbidden - Role not allowed"
+// @Failure 500 "Error creating watchlist"
+// @Router /v1/watchlist-add [post]
+func (PG *Postgresql) WatchlistAdd(w http.ResponseWriter, r *http.Request) (*models.Watchlist, error) {
+
+	log.Info().Msg("WatchlistAdd called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Watchlist
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.ID, data.UserID, data.Entries = 0, claims.UserID, nil
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		log.Error().Msg("Empty watchlist name")
+		http.Error(w, "Watchlist name is required", http.StatusBadRequest)
+		return nil, errors.New("empty watchlist name")
+	}
+
+	if err := PG.DB.Create(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error creating watchlist")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("watchlistID", data.ID).Msg("Watchlist created successfully")
+	return &data, nil
+}
+
+// WatchlistEdit godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Edits one's own watchlist
+// @Description Edits the watchlist with the specified ID based on the given update fields: name, public, and movies, an array of movie IDs replacing the list's contents in the given order. Only the owner of a list can edit it. Available to both 'admin' and 'user' roles.
+// @Tags watchlist
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Watchlist ID"
+// @Param updates body map[string]interface{} true "Fields to update"
+// @Success 200 {object} models.Watchlist "Successfully updated the watchlist"
+// @Failure 400 "Invalid request body, watchlist ID or unknown movie IDs"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Watchlist not found or owned by another user"
+// @Failure 500 "Failed to save watchlist"
+// @Router /v1/watchlist-edit/{id} [put]
+func (PG *Postgresql) WatchlistEdit(w http.ResponseWriter, r *http.Request) (*models.Watchlist, error) {
+
+	log.Info().Msg("WatchlistEdit called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlistID, err := idFromPath(w, r, "watchlist")
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Watchlist
+
+	// Lists of other users are reported as missing, as in WatchlistGet and WatchlistDelete.
+	if err := PG.DB.Preload("Entries").First(&data, "id = ? AND user_id = ?", watchlistID, claims.UserID).Error; err != nil {
+		log.Error().Err(err).Msg("Watchlist not found")
+		http.Error(w, "Watchlist not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		log.Error().Err(err).Msg("Failed to decode request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "name":
+			if name, ok := value.(string); ok && strings.TrimSpace(name) != "" {
+				data.Name = strings.TrimSpace(name)
+			}
+		case "public":
+			if public, ok := value.(bool); ok {
+				data.Public = public
+			}
+		}
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Entries").Save(&data).Error; err != nil {
+			return err
+		}
+		if movies, ok := updates["movies"].([]interface{}); ok {
+			return replaceWatchlistEntries(tx, &data, utils.InterfacesToInts(movies))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save watchlist")
+		http.Error(w, err.Error(), associationStatus(err))
+		return nil, err
+	}
+
+	if err := PG.loadWatchlistEntries(&data); err != nil {
+		log.Error().Err(err).Msg("Error loading watchlist entries")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("watchlistID", watchlistID).Msg("Watchlist updated successfully")
+	return &data, nil
+}
+
+// WatchlistGet godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Shows a watchlist
+// @Description Retrieves the watchlist with the specified ID with the full details of its movies, in order. Users can read their own lists and public lists of other users. Available to both 'admin' and 'user' roles.
+// @Tags watchlist
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Watchlist ID"
+// @Success 200 {object} models.Watchlist "Successfully retrieved the watchlist"
+// @Failure 400 "Invalid watchlist ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Watchlist not found or private"
+// @Failure 500 "Error retrieving watchlist"
+// @Router /v1/watchlist/{id} [get]
+func (PG *Postgresql) WatchlistGet(w http.ResponseWriter, r *http.Request) (*models.Watchlist, error) {
+	log.Info().Msg("WatchlistGet called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlistID, err := idFromPath(w, r, "watchlist")
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Watchlist
+
+	// Private lists of other users are reported as missing rather than forbidden, so their existence is not revealed.
+	if err := PG.DB.Where("user_id = ? OR public", claims.UserID).First(&data, "id = ?", watchlistID).Error; err != nil {
+		log.Error().Err(err).Msg("Watchlist not found")
+		http.Error(w, "Watchlist not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	if err := PG.loadWatchlistEntries(&data); err != nil {
+		log.Error().Err(err).Msg("Error retrieving watchlist")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("watchlistID", watchlistID).Int("count", len(data.Entries)).Msg("Watchlist retrieved successfully")
+	return &data, nil
+}
+
+// WatchlistList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists watchlists
+// @Description Retrieves the authenticated user's watchlists with the full details of their movies. With userId, retrieves the public watchlists of that user instead. Available to both 'admin' and 'user' roles.
+// @Tags watchlist
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param userId query int false "List the public watchlists of this user"
+// @Success 200 {array} models.Watchlist "Successfully retrieved the watchlists"
+// @Failure 400 "Invalid user ID"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 500 "Error retrieving watchlists"
+// @Router /v1/watchlist-list [get]
+func (PG *Postgresql) WatchlistList(w http.ResponseWriter, r *http.Request) (*[]models.Watchlist, error) {
+	log.Info().Msg("WatchlistList called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := intQuery(w, r, "userId", claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := PG.DB.Where("user_id = ?", userID)
+	if userID != claims.UserID {
+		query = query.Where("public")
+	}
+
+	var data []models.Watchlist
+
+	if err := preloadWatchlistEntries(query).Order("created_at, id").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving watchlists")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	var entries []*models.WatchlistEntry
+	for _, watchlist := range data {
+		entries = append(entries, watchlist.Entries...)
+	}
+	if err := PG.loadEntryMovies(entries); err != nil {
+		log.Error().Err(err).Msg("Error retrieving watchlist entries")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("userID", userID).Int("count", len(data)).Msg("Watchlists retrieved successfully")
+	return &data, nil
+}
+
+// WatchlistDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Deletes one's own watchlist
+// @Description Deletes the watchlist with the specified ID. Only the owner of a list can delete it. Available to both 'admin' and 'user' roles.
+// @Tags watchlist
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Watchlist ID"
+// @Success 200 "Successfully deleted the watchlist"
+// @Failure 400 "Invalid watchlist ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Watchlist not found"
+// @Failure 500 "Watchlist could not be deleted"
+// @Router /v1/watchlist-delete/{id} [delete]
+func (PG *Postgresql) WatchlistDelete(w http.ResponseWriter, r *http.Request) (*models.Watchlist, error) {
+
+	log.Info().Msg("WatchlistDelete called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlistID, err := idFromPath(w, r, "watchlist")
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.Watchlist
+
+	if err := PG.DB.First(&data, "id = ? AND user_id = ?", watchlistID, claims.UserID).Error; err != nil {
+		log.Error().Err(err).Msg("Watchlist not found")
+		http.Error(w, "Watchlist not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	err = PG.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("watchlist_id = ?", watchlistID).Delete(&models.WatchlistEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&data).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Watchlist could not be deleted")
+		http.Error(w, "Watchlist could not be deleted", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	log.Info().Int("watchlistID", watchlistID).Msg("Watchlist deleted successfully")
+	return &data, nil
+}
+
+// WatchedAdd godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Marks a movie as watched
+// @Description Records that the authenticated user watched the movie with the given MovieID on WatchedOn, which defaults to today. Marking a movie again updates the date. Available to both 'admin' and 'user' roles.
+// @Tags watchlist
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param watched body models.WatchedMovie true "Movie watched, with MovieID and an optional WatchedOn date"
+// @Success 200 {object} models.WatchedMovie "Successfully marked the movie as watched"
+// @Failure 400 "Invalid request body or date"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Movie not found"
+// @Failure 500 "Error saving watched movie"
+// @Router /v1/watched-add [post]
+func (PG *Postgresql) WatchedAdd(w http.ResponseWriter, r *http.Request) (*models.WatchedMovie, error) {
+
+	log.Info().Msg("WatchedAdd called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.WatchedMovie
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		log.Error().Err(err).Msg("Error decoding request body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	data.UserID, data.Movie = claims.UserID, nil
+
+	if data.WatchedOn == "" {
+		data.WatchedOn = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", data.WatchedOn); err != nil {
+		log.Error().Err(err).Msg("Invalid watched date")
+		http.Error(w, "Invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return nil, err
+	}
+
+	var movie models.Movie
+	if err := PG.DB.First(&movie, "id = ?", data.MovieID).Error; err != nil {
+		log.Error().Err(err).Msg("Movie not found")
+		http.Error(w, "Movie not found", http.StatusNotFound)
+		return nil, err
+	}
+
+	err = PG.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "movie_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"watched_on"}),
+	}).Create(&data).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Error saving watched movie")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	data.Movie = &movie
+
+	log.Info().Int("movieID", data.MovieID).Msg("Movie marked as watched")
+	return &data, nil
+}
+
+// WatchedList godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Lists the movies one has watched
+// @Description Retrieves the movies the authenticated user marked as watched, most recently watched first, with their full details. Available to both 'admin' and 'user' roles.
+// @Tags watchlist
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Success 200 {array} models.WatchedMovie "Successfully retrieved the watched movies"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 500 "Error retrieving watched movies"
+// @Router /v1/watched-list [get]
+func (PG *Postgresql) WatchedList(w http.ResponseWriter, r *http.Request) (*[]models.WatchedMovie, error) {
+	log.Info().Msg("WatchedList called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []models.WatchedMovie
+
+	if err := PG.DB.Where("user_id = ? AND movie_id IN ("+activeMovieIDs+")", claims.UserID).Order("watched_on DESC, movie_id").Find(&data).Error; err != nil {
+		log.Error().Err(err).Msg("Error retrieving watched movies")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	movieIDs := make([]int, len(data))
+	for i, watched := range data {
+		movieIDs[i] = watched.MovieID
+	}
+	movies, err := PG.movieDetails(movieIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving watched movies")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	for i := range data {
+		data[i].Movie = movies[data[i].MovieID]
+	}
+
+	log.Info().Int("count", len(data)).Msg("Watched movies retrieved successfully")
+	return &data, nil
+}
+
+// WatchedDelete godoc
+//
+// @Security ApiKeyAuth
+// @SecurityRequirement ApiKeyAuth
+// @Summary Unmarks a watched movie
+// @Description Removes the movie with the specified ID from the authenticated user's watched movies. Available to both 'admin' and 'user' roles.
+// @Tags watchlist
+// @Produce json
+// @Param Authorization header string true "Bearer [JWT token]"
+// @Param id path int true "Movie ID"
+// @Success 200 "Successfully unmarked the movie"
+// @Failure 400 "Invalid movie ID or URL format"
+// @Failure 401 "Unauthorized or Invalid token"
+// @Failure 404 "Movie not marked as watched"
+// @Failure 500 "Watched movie could not be deleted"
+// @Router /v1/watched-delete/{id} [delete]
+func (PG *Postgresql) WatchedDelete(w http.ResponseWriter, r *http.Request) (*models.WatchedMovie, error) {
+
+	log.Info().Msg("WatchedDelete called")
+
+	claims, err := requestClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.WatchedMovie
+
+	movieID, err := idFromPath(w, r, "movie")
+	if err != nil {
+		return nil, err
+	}
+
+	result := PG.DB.Where("user_id = ? AND movie_id = ?", claims.UserID, movieID).Delete(&models.WatchedMovie{})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Watched movie could not be deleted")
+		http.Error(w, "Watched movie could not be deleted", http.StatusInternalServerError)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		log.Error().Int("movieID", movieID).Msg("Movie not marked as watched")
+		http.Error(w, "Movie not marked as watched", http.StatusNotFound)
+		return nil, errors.New("movie not marked as watched")
+	}
+
+	log.Info().Int("movieID", movieID).Msg("Movie unmarked as watched")
+	return &data, nil
+}
+
+// replaceWatchlistEntries makes the given movies the contents of the list, in order.
+// Movies already on the list keep the date they were added; duplicates after the first occurrence are ignored.
+// Entries for movies in the trash are kept, after the given movies, so restoring a movie puts it back on the list.
+func replaceWatchlistEntries(tx *gorm.DB, watchlist *models.Watchlist, movieIDs []int) error {
+	var ids []int
+	for _, id := range movieIDs {
+		if !utils.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	var count int64
+	if err := tx.Model(&models.Movie{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(ids) {
+		return fmt.Errorf("%w: movies %v", errUnknownIDs, ids)
+	}
+
+	addedAt := make(map[int]time.Time, len(watchlist.Entries))
+	for _, entry := range watchlist.Entries {
+		addedAt[entry.MovieID] = entry.AddedAt
+	}
+
+	if err := tx.Where("watchlist_id = ? AND movie_id IN ("+activeMovieIDs+")", watchlist.ID).Delete(&models.WatchlistEntry{}).Error; err != nil {
+		return err
+	}
+
+	var trashed []int
+	if err := tx.Model(&models.WatchlistEntry{}).Where("watchlist_id = ?", watchlist.ID).Order("position").Pluck("movie_id", &trashed).Error; err != nil {
+		return err
+	}
+	for i, id := range trashed {
+		err := tx.Model(&models.WatchlistEntry{}).Where("watchlist_id = ? AND movie_id = ?", watchlist.ID, id).
+			Update("position", len(ids)+i+1).Error
+		if err != nil {
+			return err
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	entries := make([]models.WatchlistEntry, len(ids))
+	for i, id := range ids {
+		added, ok := addedAt[id]
+		if !ok {
+			added = time.Now()
+		}
+		entries[i] = models.WatchlistEntry{WatchlistID: watchlist.ID, MovieID: id, Position: i + 1, AddedAt: added}
+	}
+	return tx.Create(&entries).Error
+}
+
+// loadWatchlistEntries fills the entries of the list, in order, each with the full details of its movie.
+func (PG *Postgresql) loadWatchlistEntries(watchlist *models.Watchlist) error {
+	var entries []*models.WatchlistEntry
+	if err := PG.DB.Where("watchlist_id = ? AND movie_id IN ("+activeMovieIDs+")", watchlist.ID).Order("position").Find(&entries).Error; err != nil {
+		return err
+	}
+	if err := PG.loadEntryMovies(entries); err != nil {
+		return err
+	}
+
+	watchlist.Entries = entries
+	return nil
+}
+
+// preloadWatchlistEntries loads the entries of the queried lists in order, leaving out movies in the trash.
+func preloadWatchlistEntries(query *gorm.DB) *gorm.DB {
+	return query.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Where("movie_id IN (" + activeMovieIDs + ")").Order("position")
+	})
+}
+
+// loadEntryMovies fills each entry with the full details of its movie, loading the movies of all entries at once.
+func (PG *Postgresql) loadEntryMovies(entries []*models.WatchlistEntry) error {
+	movieIDs := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if !utils.Contains(movieIDs, entry.MovieID) {
+			movieIDs = append(movieIDs, entry.MovieID)
+		}
+	}
+	movies, err := PG.movieDetails(movieIDs)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entry.Movie = movies[entry.MovieID]
+	}
+	return nil
+}
+
+// movieDetails loads the given movies with everything MovieList returns for them: cast, crew, genres, tags, production details and external IDs.
+func (PG *Postgresql) movieDetails(movieIDs []int) (map[int]*models.Movie, error) {
+	details := make(map[int]*models.Movie, len(movieIDs))
+	if len(movieIDs) == 0 {
+		return details, nil
+	}
+
+	var movies []models.Movie
+	if err := preloadExternalIDs(preloadProduction(preloadCrew(PG.DB.Where("id IN ?", movieIDs)))).Preload("Genres").Preload("Tags").Find(&movies).Error; err != nil {
+		return nil, err
+	}
+	if err := PG.loadCast(movies); err != nil {
+		return nil, err
+	}
+
+	for i := range movies {
+		details[movies[i].ID] = &movies[i]
+	}
+	return details, nil
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 )
@@ -50,3 +52,34 @@ func ContainsInterfaceAsInt(s []interface{}, e int) bool {
 	}
 	return false
 }
+
+// InterfacesToInts converts a slice of interface{} values, typically a JSON array of IDs, to a slice of ints.
+// Elements that cannot be converted by InterfaceToInt are logged and skipped, as ContainsInterfaceAsInt does.
+func InterfacesToInts(s []interface{}) []int {
+	ints := make([]int, 0, len(s))
+	for _, a := range s {
+		if aInt, err := InterfaceToInt(a); err == nil {
+			ints = append(ints, aInt)
+		}
+	}
+	return ints
+}
+
+// ParseIDList parses a comma separated list of integer IDs, such as the value of a query parameter like "1,2,3".
+// Surrounding whitespace and empty elements are ignored, so "1, 2," yields [1 2].
+// Returns an error naming the first element that is not a valid integer.
+func ParseIDList(s string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIDList(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []int
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"1", []int{1}, false},
+		{"1,2,3", []int{1, 2, 3}, false},
+		{" 1, 2, ", []int{1, 2}, false},
+		{",,", nil, false},
+		{"1,x", nil, true},
+		{"1.5", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseIDList(tt.value)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseIDList(%q) = %v, %v, want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
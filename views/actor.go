@@ -46,7 +46,7 @@ func (view *View) ActorEditView() error {
 // ActorListView processes the HTTP request to retrieve a list of all actors.
 // It logs its activation, retrieves the list of actors via the PG interface's ActorList method,
 // handles potential errors by reporting them and sending an HTTP 502 status code,
-// and responds with the actor list in JSON format if the retrieval is successful.
+// and responds with the actor list, localized for the client's Accept-Language header, in JSON format if the retrieval is successful.
 func (view *View) ActorListView() error {
 
 	log.Info().Msg("ActorListView called")
@@ -58,6 +58,7 @@ func (view *View) ActorListView() error {
 		return err
 	}
 
+	view.localizeActors(*data)
 	view.respondWithJSON(data)
 	return nil
 }
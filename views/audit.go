@@ -0,0 +1,43 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AuditListView handles the HTTP request to query the audit trail.
+// It logs the call, loads the entries through the AuditList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the entries in JSON format.
+func (view *View) AuditListView() error {
+
+	log.Info().Msg("AuditListView called")
+
+	data, err := view.PG.AuditList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AuditList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// AuditVerifyView handles the HTTP request to verify the hash chain of the audit trail.
+// It logs the call, checks it through the AuditVerify method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the result of the check in JSON format.
+func (view *View) AuditVerifyView() error {
+
+	log.Info().Msg("AuditVerifyView called")
+
+	data, err := view.PG.AuditVerify(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AuditVerify")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,241 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AwardAddView handles the HTTP request to add a new award.
+// It logs the call, creates it through the AwardAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new award in JSON format.
+func (view *View) AwardAddView() error {
+
+	log.Info().Msg("AwardAddView called")
+
+	data, err := view.PG.AwardAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AwardAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// AwardEditView handles the HTTP request to rename an award.
+// It logs the call, saves the change through the AwardEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated award in JSON format.
+func (view *View) AwardEditView() error {
+
+	log.Info().Msg("AwardEditView called")
+
+	data, err := view.PG.AwardEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AwardEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// AwardListView handles the HTTP request to list all awards with their ceremonies and categories.
+// It logs the call, retrieves them through the AwardList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the awards in JSON format.
+func (view *View) AwardListView() error {
+
+	log.Info().Msg("AwardListView called")
+
+	data, err := view.PG.AwardList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AwardList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// AwardDeleteView handles the HTTP request to delete an award with its ceremonies, categories and nominations.
+// It logs the call, deletes it through the AwardDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) AwardDeleteView() error {
+
+	log.Info().Msg("AwardDeleteView called")
+
+	data, err := view.PG.AwardDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AwardDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CeremonyAddView handles the HTTP request to add a ceremony to an award.
+// It logs the call, creates it through the CeremonyAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new ceremony in JSON format.
+func (view *View) CeremonyAddView() error {
+
+	log.Info().Msg("CeremonyAddView called")
+
+	data, err := view.PG.CeremonyAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CeremonyAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CeremonyDeleteView handles the HTTP request to delete an award ceremony.
+// It logs the call, deletes it through the CeremonyDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) CeremonyDeleteView() error {
+
+	log.Info().Msg("CeremonyDeleteView called")
+
+	data, err := view.PG.CeremonyDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CeremonyDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CeremonyEditView handles the HTTP request to edit an award ceremony.
+// It logs the call, updates it through the CeremonyEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated ceremony in JSON format.
+func (view *View) CeremonyEditView() error {
+
+	log.Info().Msg("CeremonyEditView called")
+
+	data, err := view.PG.CeremonyEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CeremonyEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// AwardCategoryAddView handles the HTTP request to add a category to an award.
+// It logs the call, creates it through the AwardCategoryAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new category in JSON format.
+func (view *View) AwardCategoryAddView() error {
+
+	log.Info().Msg("AwardCategoryAddView called")
+
+	data, err := view.PG.AwardCategoryAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AwardCategoryAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// AwardCategoryDeleteView handles the HTTP request to delete an award category.
+// It logs the call, deletes it through the AwardCategoryDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) AwardCategoryDeleteView() error {
+
+	log.Info().Msg("AwardCategoryDeleteView called")
+
+	data, err := view.PG.AwardCategoryDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AwardCategoryDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// AwardCategoryEditView handles the HTTP request to edit an award category.
+// It logs the call, updates it through the AwardCategoryEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated category in JSON format.
+func (view *View) AwardCategoryEditView() error {
+
+	log.Info().Msg("AwardCategoryEditView called")
+
+	data, err := view.PG.AwardCategoryEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in AwardCategoryEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// NominationAddView handles the HTTP request to record a nomination.
+// It logs the call, creates it through the NominationAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new nomination in JSON format.
+func (view *View) NominationAddView() error {
+
+	log.Info().Msg("NominationAddView called")
+
+	data, err := view.PG.NominationAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in NominationAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// NominationEditView handles the HTTP request to edit a nomination.
+// It logs the call, saves the changes through the NominationEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated nomination in JSON format.
+func (view *View) NominationEditView() error {
+
+	log.Info().Msg("NominationEditView called")
+
+	data, err := view.PG.NominationEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in NominationEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// NominationDeleteView handles the HTTP request to delete a nomination.
+// It logs the call, deletes it through the NominationDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) NominationDeleteView() error {
+
+	log.Info().Msg("NominationDeleteView called")
+
+	data, err := view.PG.NominationDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in NominationDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,97 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CollectionAddView handles the HTTP request to add a new collection.
+// It logs the call, creates it through the CollectionAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new collection in JSON format.
+func (view *View) CollectionAddView() error {
+
+	log.Info().Msg("CollectionAddView called")
+
+	data, err := view.PG.CollectionAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CollectionAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CollectionEditView handles the HTTP request to edit a collection and reorder its movies.
+// It logs the call, saves the changes through the CollectionEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated collection in JSON format.
+func (view *View) CollectionEditView() error {
+
+	log.Info().Msg("CollectionEditView called")
+
+	data, err := view.PG.CollectionEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CollectionEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CollectionGetView handles the HTTP request to show a collection with its movies.
+// It logs the call, retrieves it through the CollectionGet method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the collection in JSON format.
+func (view *View) CollectionGetView() error {
+
+	log.Info().Msg("CollectionGetView called")
+
+	data, err := view.PG.CollectionGet(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CollectionGet")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CollectionListView handles the HTTP request to list all collections.
+// It logs the call, retrieves them through the CollectionList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the collections in JSON format.
+func (view *View) CollectionListView() error {
+
+	log.Info().Msg("CollectionListView called")
+
+	data, err := view.PG.CollectionList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CollectionList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CollectionDeleteView handles the HTTP request to delete a collection.
+// It logs the call, deletes it through the CollectionDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) CollectionDeleteView() error {
+
+	log.Info().Msg("CollectionDeleteView called")
+
+	data, err := view.PG.CollectionDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CollectionDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,43 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CrewAddView handles the HTTP request to credit a person on a movie's crew.
+// It logs the call, creates the credit through the CrewAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new credit in JSON format.
+func (view *View) CrewAddView() error {
+
+	log.Info().Msg("CrewAddView called")
+
+	data, err := view.PG.CrewAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CrewAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CrewDeleteView handles the HTTP request to remove a crew credit.
+// It logs the call, deletes the credit through the CrewDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) CrewDeleteView() error {
+
+	log.Info().Msg("CrewDeleteView called")
+
+	data, err := view.PG.CrewDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CrewDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
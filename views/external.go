@@ -0,0 +1,120 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"vk.com/m/models"
+)
+
+// MovieExternalIDSetView handles the HTTP request to record an external ID of a movie.
+// It logs the call, saves it through the MovieExternalIDSet method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the recorded identifier in JSON format.
+func (view *View) MovieExternalIDSetView() error {
+
+	log.Info().Msg("MovieExternalIDSetView called")
+
+	data, err := view.PG.MovieExternalIDSet(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieExternalIDSet")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorExternalIDSetView handles the HTTP request to record an external ID of an actor.
+// It logs the call, saves it through the ActorExternalIDSet method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the recorded identifier in JSON format.
+func (view *View) ActorExternalIDSetView() error {
+
+	log.Info().Msg("ActorExternalIDSetView called")
+
+	data, err := view.PG.ActorExternalIDSet(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorExternalIDSet")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MovieExternalIDDeleteView handles the HTTP request to remove an external ID of a movie.
+// It logs the call, removes it through the MovieExternalIDDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) MovieExternalIDDeleteView() error {
+
+	log.Info().Msg("MovieExternalIDDeleteView called")
+
+	data, err := view.PG.MovieExternalIDDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieExternalIDDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorExternalIDDeleteView handles the HTTP request to remove an external ID of an actor.
+// It logs the call, removes it through the ActorExternalIDDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) ActorExternalIDDeleteView() error {
+
+	log.Info().Msg("ActorExternalIDDeleteView called")
+
+	data, err := view.PG.ActorExternalIDDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorExternalIDDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MovieLookupView handles the HTTP request to find a movie by external ID.
+// It logs the call, looks it up through the MovieLookup method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the movie localized for the client in JSON format.
+func (view *View) MovieLookupView() error {
+
+	log.Info().Msg("MovieLookupView called")
+
+	data, err := view.PG.MovieLookup(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieLookup")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	movies := []models.Movie{*data}
+	view.localizeMovies(movies)
+	view.respondWithJSON(movies[0])
+	return nil
+}
+
+// ActorLookupView handles the HTTP request to find an actor by external ID.
+// It logs the call, looks it up through the ActorLookup method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the actor localized for the client in JSON format.
+func (view *View) ActorLookupView() error {
+
+	log.Info().Msg("ActorLookupView called")
+
+	data, err := view.PG.ActorLookup(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorLookup")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	actors := []models.Actor{*data}
+	view.localizeActors(actors)
+	view.respondWithJSON(actors[0])
+	return nil
+}
@@ -0,0 +1,115 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ActorFollowView handles the HTTP request to follow an actor.
+// It logs the call, records the follow for the authenticated user through the ActorFollow method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the follow in JSON format.
+func (view *View) ActorFollowView() error {
+
+	log.Info().Msg("ActorFollowView called")
+
+	data, err := view.PG.ActorFollow(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorFollow")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorUnfollowView handles the HTTP request to unfollow an actor.
+// It logs the call, removes the follow through the ActorUnfollow method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the unfollow with a JSON response.
+func (view *View) ActorUnfollowView() error {
+
+	log.Info().Msg("ActorUnfollowView called")
+
+	data, err := view.PG.ActorUnfollow(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorUnfollow")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorFollowListView handles the HTTP request to list the followed actors.
+// It logs the call, retrieves the follows through the ActorFollowList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the followed actors in JSON format.
+func (view *View) ActorFollowListView() error {
+
+	log.Info().Msg("ActorFollowListView called")
+
+	data, err := view.PG.ActorFollowList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorFollowList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// NotificationListView handles the HTTP request to list the user's notifications.
+// It logs the call, retrieves the notification feed through the NotificationList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the notifications in JSON format.
+func (view *View) NotificationListView() error {
+
+	log.Info().Msg("NotificationListView called")
+
+	data, err := view.PG.NotificationList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in NotificationList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// NotificationReadView handles the HTTP request to mark a notification as read.
+// It logs the call, updates the notification through the NotificationRead method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the notification in JSON format.
+func (view *View) NotificationReadView() error {
+
+	log.Info().Msg("NotificationReadView called")
+
+	data, err := view.PG.NotificationRead(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in NotificationRead")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// NotificationReadAllView handles the HTTP request to mark all notifications as read.
+// It logs the call, updates the notifications through the NotificationReadAll method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the update with a JSON response.
+func (view *View) NotificationReadAllView() error {
+
+	log.Info().Msg("NotificationReadAllView called")
+
+	data, err := view.PG.NotificationReadAll(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in NotificationReadAll")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,59 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ActorCollaboratorsView handles the HTTP request to list an actor's frequent collaborators.
+// It logs the call, retrieves the ranked collaborators through the ActorCollaborators method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the collaborators in JSON format.
+func (view *View) ActorCollaboratorsView() error {
+
+	log.Info().Msg("ActorCollaboratorsView called")
+
+	data, err := view.PG.ActorCollaborators(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorCollaborators")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorPathView handles the HTTP request to find the shortest connection between two actors.
+// It logs the call, runs the search through the ActorPath method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the alternating actor and movie nodes in JSON format.
+func (view *View) ActorPathView() error {
+
+	log.Info().Msg("ActorPathView called")
+
+	data, err := view.PG.ActorPath(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorPath")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// GraphExportView handles the HTTP request to export the actor-movie network as a graph document.
+// It logs the call and lets the GraphExport method on the PG interface stream the document straight into the response.
+// Unlike the other views it writes no JSON and no error status of its own: the service reports invalid parameters itself,
+// and once streaming has started the status line has already been sent, so failures are only logged.
+func (view *View) GraphExportView() error {
+
+	log.Info().Msg("GraphExportView called")
+
+	if err := view.PG.GraphExport(view.W, view.R); err != nil {
+		log.Error().Err(err).Msg("Error in GraphExport")
+		return err
+	}
+
+	return nil
+}
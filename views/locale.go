@@ -0,0 +1,85 @@
+package views
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"vk.com/m/models"
+)
+
+// preferredLanguages reads the Accept-Language header of the request and returns the languages the client accepts,
+// lower-cased and ordered from the highest quality value to the lowest. Languages of equal quality keep the client's order.
+// The "*" wildcard and languages refused with q=0 are left out, as are malformed entries.
+func (view *View) preferredLanguages() []string {
+	type weightedLanguage struct {
+		tag     string
+		quality float64
+	}
+
+	var accepted []weightedLanguage
+	for _, entry := range strings.Split(view.R.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(entry, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		accepted = append(accepted, weightedLanguage{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+
+	languages := make([]string, len(accepted))
+	for i, language := range accepted {
+		languages[i] = language.tag
+	}
+	return languages
+}
+
+// localizeMovies translates the movies, and the people credited on them, into the languages preferred by the client.
+// Anything without a suitable translation keeps its stored text. A failure to load the translations is logged and the
+// movies are sent untranslated rather than failing the whole request.
+//
+// Parameters:
+// - movies []models.Movie: The movies about to be written to the response.
+func (view *View) localizeMovies(movies []models.Movie) {
+	view.W.Header().Add("Vary", "Accept-Language")
+	languages := view.preferredLanguages()
+	if len(languages) == 0 {
+		return
+	}
+	if err := view.PG.LocalizeMovies(movies, languages); err != nil {
+		log.Error().Err(err).Msg("Error localizing movies")
+	}
+}
+
+// localizeActors translates the actors, and the movies they are credited on, into the languages preferred by the client.
+// It falls back to the stored text in the same way as localizeMovies.
+//
+// Parameters:
+// - actors []models.Actor: The actors about to be written to the response.
+func (view *View) localizeActors(actors []models.Actor) {
+	view.W.Header().Add("Vary", "Accept-Language")
+	languages := view.preferredLanguages()
+	if len(languages) == 0 {
+		return
+	}
+	if err := view.PG.LocalizeActors(actors, languages); err != nil {
+		log.Error().Err(err).Msg("Error localizing actors")
+	}
+}
@@ -0,0 +1,79 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MoviePosterUploadView handles the HTTP request to upload a movie poster.
+// It logs the call, stores the poster and its thumbnails through the MoviePosterUpload method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the movie with its poster URLs in JSON format.
+func (view *View) MoviePosterUploadView() error {
+
+	log.Info().Msg("MoviePosterUploadView called")
+
+	data, err := view.PG.MoviePosterUpload(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MoviePosterUpload")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MoviePosterDeleteView handles the HTTP request to remove a movie poster.
+// It logs the call, removes the poster and its thumbnails through the MoviePosterDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the movie in JSON format.
+func (view *View) MoviePosterDeleteView() error {
+
+	log.Info().Msg("MoviePosterDeleteView called")
+
+	data, err := view.PG.MoviePosterDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MoviePosterDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorPhotoUploadView handles the HTTP request to upload an actor photo.
+// It logs the call, stores the photo and its thumbnails through the ActorPhotoUpload method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the actor with its photo URLs in JSON format.
+func (view *View) ActorPhotoUploadView() error {
+
+	log.Info().Msg("ActorPhotoUploadView called")
+
+	data, err := view.PG.ActorPhotoUpload(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorPhotoUpload")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorPhotoDeleteView handles the HTTP request to remove an actor photo.
+// It logs the call, removes the photo and its thumbnails through the ActorPhotoDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the actor in JSON format.
+func (view *View) ActorPhotoDeleteView() error {
+
+	log.Info().Msg("ActorPhotoDeleteView called")
+
+	data, err := view.PG.ActorPhotoDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorPhotoDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,43 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ActorDuplicatesView handles the HTTP request to find likely duplicate actors.
+// It logs the call, scores the pairs through the ActorDuplicates method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the candidates in JSON format.
+func (view *View) ActorDuplicatesView() error {
+
+	log.Info().Msg("ActorDuplicatesView called")
+
+	data, err := view.PG.ActorDuplicates(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorDuplicates")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorMergeView handles the HTTP request to merge a duplicate actor into another.
+// It logs the call, merges them through the ActorMerge method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the merged actor in JSON format.
+func (view *View) ActorMergeView() error {
+
+	log.Info().Msg("ActorMergeView called")
+
+	data, err := view.PG.ActorMerge(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorMerge")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/rs/zerolog/log"
+	"vk.com/m/models"
 )
 
 // MovieAddView deals with the HTTP request to add a new movie.
@@ -48,7 +49,7 @@ func (view *View) MovieEditView() error {
 // It begins by logging its execution, then retrieves the list of all movies through the MovieList method on the PG interface.
 // Should any errors arise during this retrieval process, it logs the error, responds to the HTTP request with a 502 Bad Gateway status,
 // indicating a problem with accessing or processing the data, and returns the error. On successful retrieval, it sends the list of movies
-// back to the client in JSON format, providing a comprehensive view of the available movie records.
+// back to the client in JSON format, providing a comprehensive view of the available movie records, along with facet counts if requested.
 func (view *View) MovieListView() error {
 
 	log.Info().Msg("MovieListView called")
@@ -60,10 +61,13 @@ func (view *View) MovieListView() error {
 		return err
 	}
 
-	view.respondWithJSON(data)
+	view.respondWithMovies(data)
 	return nil
 }
 
+// MovieFindView handles the HTTP request to search movies by title or actor name fragments.
+// It logs the call, runs the search through the MovieFind method on the PG interface, answers with a 502 Bad Gateway status on failure,
+// and otherwise responds with the matching movies, together with facet counts when the client requested them.
 func (view *View) MovieFindView() error {
 
 	log.Info().Msg("MovieFindView called")
@@ -75,7 +79,27 @@ func (view *View) MovieFindView() error {
 		return err
 	}
 
-	view.respondWithJSON(data)
+	view.respondWithMovies(data)
+	return nil
+}
+
+// MovieGetView handles the HTTP request to show a single movie with its related titles and collections.
+// It logs the call, retrieves the movie through the MovieGet method on the PG interface, answers with a 502 Bad Gateway status on failure,
+// and otherwise responds with the movie, localized for the client's Accept-Language header, in JSON format.
+func (view *View) MovieGetView() error {
+
+	log.Info().Msg("MovieGetView called")
+
+	data, err := view.PG.MovieGet(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieGet")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	movies := []models.Movie{*data}
+	view.localizeMovies(movies)
+	view.respondWithJSON(movies[0])
 	return nil
 }
 
@@ -99,3 +123,21 @@ func (view *View) MovieDeleteView() error {
 	view.respondWithJSON(data)
 	return nil
 }
+
+// MovieSimilarView handles the HTTP request to list the movies similar to a given movie.
+// It logs the call, loads the precomputed recommendations through the MovieSimilar method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the recommendations with their scores in JSON format.
+func (view *View) MovieSimilarView() error {
+
+	log.Info().Msg("MovieSimilarView called")
+
+	data, err := view.PG.MovieSimilar(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieSimilar")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
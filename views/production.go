@@ -0,0 +1,115 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// StudioAddView handles the HTTP request to add a new studio.
+// It logs the call, creates it through the StudioAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new studio in JSON format.
+func (view *View) StudioAddView() error {
+
+	log.Info().Msg("StudioAddView called")
+
+	data, err := view.PG.StudioAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in StudioAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// StudioEditView handles the HTTP request to rename a studio.
+// It logs the call, saves the change through the StudioEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated studio in JSON format.
+func (view *View) StudioEditView() error {
+
+	log.Info().Msg("StudioEditView called")
+
+	data, err := view.PG.StudioEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in StudioEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// StudioListView handles the HTTP request to list the studios.
+// It logs the call, loads them through the StudioList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the studios in JSON format.
+func (view *View) StudioListView() error {
+
+	log.Info().Msg("StudioListView called")
+
+	data, err := view.PG.StudioList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in StudioList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// StudioDeleteView handles the HTTP request to delete a studio.
+// It logs the call, removes it through the StudioDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) StudioDeleteView() error {
+
+	log.Info().Msg("StudioDeleteView called")
+
+	data, err := view.PG.StudioDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in StudioDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// CountryListView handles the HTTP request to list the ISO countries.
+// It logs the call, loads them through the CountryList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the countries in JSON format.
+func (view *View) CountryListView() error {
+
+	log.Info().Msg("CountryListView called")
+
+	data, err := view.PG.CountryList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in CountryList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// LanguageListView handles the HTTP request to list the ISO languages.
+// It logs the call, loads them through the LanguageList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the languages in JSON format.
+func (view *View) LanguageListView() error {
+
+	log.Info().Msg("LanguageListView called")
+
+	data, err := view.PG.LanguageList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in LanguageList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,115 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MovieProposalView handles the HTTP request to propose a change to a movie.
+// It logs the call, submits it through the MovieProposal method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the pending proposal in JSON format.
+func (view *View) MovieProposalView() error {
+
+	log.Info().Msg("MovieProposalView called")
+
+	data, err := view.PG.MovieProposal(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieProposal")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorProposalView handles the HTTP request to propose a change to an actor.
+// It logs the call, submits it through the ActorProposal method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the pending proposal in JSON format.
+func (view *View) ActorProposalView() error {
+
+	log.Info().Msg("ActorProposalView called")
+
+	data, err := view.PG.ActorProposal(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorProposal")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ProposalListView handles the HTTP request to list the moderation queue.
+// It logs the call, loads the proposals through the ProposalList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the proposals in JSON format.
+func (view *View) ProposalListView() error {
+
+	log.Info().Msg("ProposalListView called")
+
+	data, err := view.PG.ProposalList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ProposalList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ProposalMineView handles the HTTP request to list the proposals of the authenticated user.
+// It logs the call, loads them through the ProposalMine method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the proposals in JSON format.
+func (view *View) ProposalMineView() error {
+
+	log.Info().Msg("ProposalMineView called")
+
+	data, err := view.PG.ProposalMine(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ProposalMine")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ProposalApproveView handles the HTTP request to approve an edit proposal.
+// It logs the call, applies it through the ProposalApprove method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the approved proposal in JSON format.
+func (view *View) ProposalApproveView() error {
+
+	log.Info().Msg("ProposalApproveView called")
+
+	data, err := view.PG.ProposalApprove(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ProposalApprove")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ProposalRejectView handles the HTTP request to reject an edit proposal.
+// It logs the call, rejects it through the ProposalReject method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the rejected proposal in JSON format.
+func (view *View) ProposalRejectView() error {
+
+	log.Info().Msg("ProposalRejectView called")
+
+	data, err := view.PG.ProposalReject(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ProposalReject")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
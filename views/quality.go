@@ -0,0 +1,25 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// QualityReportView handles the HTTP request to report the data-quality issues of the catalogue.
+// It logs the call, runs the data-quality checks through the QualityReport method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the report in JSON format.
+func (view *View) QualityReportView() error {
+
+	log.Info().Msg("QualityReportView called")
+
+	data, err := view.PG.QualityReport(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in QualityReport")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
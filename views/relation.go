@@ -0,0 +1,43 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MovieRelationAddView handles the HTTP request to link two related movies.
+// It logs the call, creates the relation through the MovieRelationAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new relation in JSON format.
+func (view *View) MovieRelationAddView() error {
+
+	log.Info().Msg("MovieRelationAddView called")
+
+	data, err := view.PG.MovieRelationAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieRelationAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MovieRelationDeleteView handles the HTTP request to unlink two related movies.
+// It logs the call, deletes the relation through the MovieRelationDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) MovieRelationDeleteView() error {
+
+	log.Info().Msg("MovieRelationDeleteView called")
+
+	data, err := view.PG.MovieRelationDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieRelationDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
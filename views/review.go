@@ -0,0 +1,79 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReviewAddView handles the HTTP request to rate and review a movie.
+// It logs the call, creates the review through the ReviewAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new review in JSON format.
+func (view *View) ReviewAddView() error {
+
+	log.Info().Msg("ReviewAddView called")
+
+	data, err := view.PG.ReviewAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ReviewAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ReviewEditView handles the HTTP request to edit one's own review.
+// It logs the call, applies the update through the ReviewEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated review in JSON format.
+func (view *View) ReviewEditView() error {
+
+	log.Info().Msg("ReviewEditView called")
+
+	data, err := view.PG.ReviewEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ReviewEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ReviewDeleteView handles the HTTP request to delete a review.
+// It logs the call, deletes the review through the ReviewDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) ReviewDeleteView() error {
+
+	log.Info().Msg("ReviewDeleteView called")
+
+	data, err := view.PG.ReviewDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ReviewDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MovieReviewsView handles the HTTP request to list the reviews of a movie.
+// It logs the call, retrieves the reviews through the MovieReviews method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the reviews in JSON format.
+func (view *View) MovieReviewsView() error {
+
+	log.Info().Msg("MovieReviewsView called")
+
+	data, err := view.PG.MovieReviews(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieReviews")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,79 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MovieHistoryView handles the HTTP request to list the revisions of a movie.
+// It logs the call, loads them through the MovieHistory method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the history in JSON format.
+func (view *View) MovieHistoryView() error {
+
+	log.Info().Msg("MovieHistoryView called")
+
+	data, err := view.PG.MovieHistory(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieHistory")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorHistoryView handles the HTTP request to list the revisions of an actor.
+// It logs the call, loads them through the ActorHistory method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the history in JSON format.
+func (view *View) ActorHistoryView() error {
+
+	log.Info().Msg("ActorHistoryView called")
+
+	data, err := view.PG.ActorHistory(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorHistory")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MovieRevertView handles the HTTP request to revert a movie to a previous revision.
+// It logs the call, applies the revision through the MovieRevert method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the reverted movie in JSON format.
+func (view *View) MovieRevertView() error {
+
+	log.Info().Msg("MovieRevertView called")
+
+	data, err := view.PG.MovieRevert(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieRevert")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorRevertView handles the HTTP request to revert an actor to a previous revision.
+// It logs the call, applies the revision through the ActorRevert method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the reverted actor in JSON format.
+func (view *View) ActorRevertView() error {
+
+	log.Info().Msg("ActorRevertView called")
+
+	data, err := view.PG.ActorRevert(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorRevert")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,187 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SeriesAddView handles the HTTP request to add a new series.
+// It logs the call, creates the series through the SeriesAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new series in JSON format.
+func (view *View) SeriesAddView() error {
+
+	log.Info().Msg("SeriesAddView called")
+
+	data, err := view.PG.SeriesAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in SeriesAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// SeriesEditView handles the HTTP request to edit an existing series.
+// It logs the call, applies the update through the SeriesEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated series in JSON format.
+func (view *View) SeriesEditView() error {
+
+	log.Info().Msg("SeriesEditView called")
+
+	data, err := view.PG.SeriesEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in SeriesEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// SeriesListView handles the HTTP request to list all series.
+// It logs the call, retrieves the series with their seasons and episodes through the SeriesList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the series in JSON format.
+func (view *View) SeriesListView() error {
+
+	log.Info().Msg("SeriesListView called")
+
+	data, err := view.PG.SeriesList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in SeriesList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// SeriesDeleteView handles the HTTP request to delete a series.
+// It logs the call, deletes the series with its seasons and episodes through the SeriesDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) SeriesDeleteView() error {
+
+	log.Info().Msg("SeriesDeleteView called")
+
+	data, err := view.PG.SeriesDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in SeriesDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// SeasonAddView handles the HTTP request to add a season to a series.
+// It logs the call, creates the season through the SeasonAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new season in JSON format.
+func (view *View) SeasonAddView() error {
+
+	log.Info().Msg("SeasonAddView called")
+
+	data, err := view.PG.SeasonAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in SeasonAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// SeasonEditView handles the HTTP request to edit a season.
+// It logs the call, updates the season through the SeasonEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated season in JSON format.
+func (view *View) SeasonEditView() error {
+
+	log.Info().Msg("SeasonEditView called")
+
+	data, err := view.PG.SeasonEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in SeasonEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// SeasonDeleteView handles the HTTP request to delete a season.
+// It logs the call, deletes the season with its episodes through the SeasonDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) SeasonDeleteView() error {
+
+	log.Info().Msg("SeasonDeleteView called")
+
+	data, err := view.PG.SeasonDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in SeasonDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// EpisodeAddView handles the HTTP request to add an episode to a season.
+// It logs the call, creates the episode and links its guest stars through the EpisodeAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new episode in JSON format.
+func (view *View) EpisodeAddView() error {
+
+	log.Info().Msg("EpisodeAddView called")
+
+	data, err := view.PG.EpisodeAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in EpisodeAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// EpisodeEditView handles the HTTP request to edit an existing episode.
+// It logs the call, applies the update through the EpisodeEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated episode in JSON format.
+func (view *View) EpisodeEditView() error {
+
+	log.Info().Msg("EpisodeEditView called")
+
+	data, err := view.PG.EpisodeEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in EpisodeEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// EpisodeDeleteView handles the HTTP request to delete an episode.
+// It logs the call, deletes the episode through the EpisodeDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) EpisodeDeleteView() error {
+
+	log.Info().Msg("EpisodeDeleteView called")
+
+	data, err := view.PG.EpisodeDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in EpisodeDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
@@ -0,0 +1,64 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GenreAddView handles the HTTP request to add a new genre.
+func (view *View) GenreAddView() error {
+	return serveTerm(view, "GenreAdd", view.PG.GenreAdd)
+}
+
+// GenreEditView handles the HTTP request to rename an existing genre.
+func (view *View) GenreEditView() error {
+	return serveTerm(view, "GenreEdit", view.PG.GenreEdit)
+}
+
+// GenreListView handles the HTTP request to list all genres.
+func (view *View) GenreListView() error {
+	return serveTerm(view, "GenreList", view.PG.GenreList)
+}
+
+// GenreDeleteView handles the HTTP request to delete a genre and its movie links.
+func (view *View) GenreDeleteView() error {
+	return serveTerm(view, "GenreDelete", view.PG.GenreDelete)
+}
+
+// TagAddView handles the HTTP request to add a new tag.
+func (view *View) TagAddView() error {
+	return serveTerm(view, "TagAdd", view.PG.TagAdd)
+}
+
+// TagEditView handles the HTTP request to rename an existing tag.
+func (view *View) TagEditView() error {
+	return serveTerm(view, "TagEdit", view.PG.TagEdit)
+}
+
+// TagListView handles the HTTP request to list all tags.
+func (view *View) TagListView() error {
+	return serveTerm(view, "TagList", view.PG.TagList)
+}
+
+// TagDeleteView handles the HTTP request to delete a tag and its movie links.
+func (view *View) TagDeleteView() error {
+	return serveTerm(view, "TagDelete", view.PG.TagDelete)
+}
+
+// serveTerm is the flow every genre and tag view shares. It logs the call, runs the named method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns its result in JSON format.
+func serveTerm[T any](view *View, name string, method func(http.ResponseWriter, *http.Request) (T, error)) error {
+
+	log.Info().Msg(name + "View called")
+
+	data, err := method(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in " + name)
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
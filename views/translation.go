@@ -0,0 +1,115 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MovieTranslationSetView handles the HTTP request to set the translation of a movie into one language.
+// It logs the call, saves the translation through the MovieTranslationSet method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the saved translation in JSON format.
+func (view *View) MovieTranslationSetView() error {
+
+	log.Info().Msg("MovieTranslationSetView called")
+
+	data, err := view.PG.MovieTranslationSet(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieTranslationSet")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MovieTranslationListView handles the HTTP request to list the translations of a movie.
+// It logs the call, retrieves them through the MovieTranslationList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the translations in JSON format.
+func (view *View) MovieTranslationListView() error {
+
+	log.Info().Msg("MovieTranslationListView called")
+
+	data, err := view.PG.MovieTranslationList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieTranslationList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MovieTranslationDeleteView handles the HTTP request to delete the translation of a movie into one language.
+// It logs the call, deletes it through the MovieTranslationDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) MovieTranslationDeleteView() error {
+
+	log.Info().Msg("MovieTranslationDeleteView called")
+
+	data, err := view.PG.MovieTranslationDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieTranslationDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorTranslationSetView handles the HTTP request to set the name of an actor in one language.
+// It logs the call, saves the translation through the ActorTranslationSet method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the saved translation in JSON format.
+func (view *View) ActorTranslationSetView() error {
+
+	log.Info().Msg("ActorTranslationSetView called")
+
+	data, err := view.PG.ActorTranslationSet(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorTranslationSet")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorTranslationListView handles the HTTP request to list the name translations of an actor.
+// It logs the call, retrieves them through the ActorTranslationList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the translations in JSON format.
+func (view *View) ActorTranslationListView() error {
+
+	log.Info().Msg("ActorTranslationListView called")
+
+	data, err := view.PG.ActorTranslationList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorTranslationList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorTranslationDeleteView handles the HTTP request to delete the name of an actor in one language.
+// It logs the call, deletes it through the ActorTranslationDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) ActorTranslationDeleteView() error {
+
+	log.Info().Msg("ActorTranslationDeleteView called")
+
+	data, err := view.PG.ActorTranslationDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorTranslationDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
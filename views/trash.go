@@ -0,0 +1,61 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TrashListView handles the HTTP request to list the trashed movies and actors.
+// It logs the call, loads them through the TrashList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the trash in JSON format.
+func (view *View) TrashListView() error {
+
+	log.Info().Msg("TrashListView called")
+
+	data, err := view.PG.TrashList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in TrashList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// MovieRestoreView handles the HTTP request to restore a movie from the trash.
+// It logs the call, restores it through the MovieRestore method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the restored movie in JSON format.
+func (view *View) MovieRestoreView() error {
+
+	log.Info().Msg("MovieRestoreView called")
+
+	data, err := view.PG.MovieRestore(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in MovieRestore")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// ActorRestoreView handles the HTTP request to restore an actor from the trash.
+// It logs the call, restores it through the ActorRestore method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the restored actor in JSON format.
+func (view *View) ActorRestoreView() error {
+
+	log.Info().Msg("ActorRestoreView called")
+
+	data, err := view.PG.ActorRestore(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in ActorRestore")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
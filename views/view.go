@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/rs/zerolog/log"
+	"vk.com/m/models"
 	"vk.com/m/services"
 )
 
@@ -29,6 +30,22 @@ func (view *View) respondWithJSON(data interface{}) {
 	}
 }
 
+// respondWithMovies writes the result of a movie listing or search.
+// Without facets the movies are sent as a plain JSON array, keeping the response shape existing clients rely on;
+// when facets were requested the whole result object, holding both the movies and the facet counts, is sent instead.
+// The movies are localized for the client's Accept-Language header first.
+//
+// Parameters:
+// - data *models.MovieResults: The movies and optional facets to write to the response.
+func (view *View) respondWithMovies(data *models.MovieResults) {
+	view.localizeMovies(data.Movies)
+	if data.Facets == nil {
+		view.respondWithJSON(data.Movies)
+		return
+	}
+	view.respondWithJSON(data)
+}
+
 // handleError logs the provided error and responds to the HTTP request with the specified status code.
 // This method standardizes error handling across view functions, ensuring that all errors are logged for debugging purposes
 // and that the client receives a consistent error response format. It uses the http.Error utility function
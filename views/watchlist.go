@@ -0,0 +1,151 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WatchlistAddView handles the HTTP request to create a watchlist.
+// It logs the call, creates the list for the authenticated user through the WatchlistAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the new watchlist in JSON format.
+func (view *View) WatchlistAddView() error {
+
+	log.Info().Msg("WatchlistAddView called")
+
+	data, err := view.PG.WatchlistAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in WatchlistAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// WatchlistEditView handles the HTTP request to edit one of the user's watchlists.
+// It logs the call, applies the update through the WatchlistEdit method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the updated watchlist in JSON format.
+func (view *View) WatchlistEditView() error {
+
+	log.Info().Msg("WatchlistEditView called")
+
+	data, err := view.PG.WatchlistEdit(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in WatchlistEdit")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// WatchlistGetView handles the HTTP request to show a watchlist.
+// It logs the call, retrieves the list with its movies through the WatchlistGet method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the watchlist in JSON format.
+func (view *View) WatchlistGetView() error {
+
+	log.Info().Msg("WatchlistGetView called")
+
+	data, err := view.PG.WatchlistGet(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in WatchlistGet")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// WatchlistListView handles the HTTP request to list watchlists.
+// It logs the call, retrieves the lists with their movies through the WatchlistList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the watchlists in JSON format.
+func (view *View) WatchlistListView() error {
+
+	log.Info().Msg("WatchlistListView called")
+
+	data, err := view.PG.WatchlistList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in WatchlistList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// WatchlistDeleteView handles the HTTP request to delete one of the user's watchlists.
+// It logs the call, deletes the list through the WatchlistDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) WatchlistDeleteView() error {
+
+	log.Info().Msg("WatchlistDeleteView called")
+
+	data, err := view.PG.WatchlistDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in WatchlistDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// WatchedAddView handles the HTTP request to mark a movie as watched.
+// It logs the call, records the viewing through the WatchedAdd method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the watched movie in JSON format.
+func (view *View) WatchedAddView() error {
+
+	log.Info().Msg("WatchedAddView called")
+
+	data, err := view.PG.WatchedAdd(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in WatchedAdd")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// WatchedListView handles the HTTP request to list the movies the user has watched.
+// It logs the call, retrieves the watched movies through the WatchedList method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise returns the watched movies in JSON format.
+func (view *View) WatchedListView() error {
+
+	log.Info().Msg("WatchedListView called")
+
+	data, err := view.PG.WatchedList(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in WatchedList")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}
+
+// WatchedDeleteView handles the HTTP request to unmark a watched movie.
+// It logs the call, deletes the record through the WatchedDelete method on the PG interface,
+// responds with a 502 Bad Gateway status on failure, and otherwise confirms the deletion with a JSON response.
+func (view *View) WatchedDeleteView() error {
+
+	log.Info().Msg("WatchedDeleteView called")
+
+	data, err := view.PG.WatchedDelete(view.W, view.R)
+	if err != nil {
+		log.Error().Err(err).Msg("Error in WatchedDelete")
+		view.handleError(err, http.StatusBadGateway)
+		return err
+	}
+
+	view.respondWithJSON(data)
+	return nil
+}